@@ -0,0 +1,347 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/thriftrw/internal/compare"
+)
+
+// outputFormats lists the values accepted by --format.
+var outputFormats = []string{"text", "json", "github", "sarif", "checkstyle", "junit"}
+
+// newOutput builds the output for the given format. It returns a function
+// that reports a diagnostic and a function that must be called after all
+// diagnostics were reported.
+func newOutput(format string, w io.Writer) (write func(compare.Diagnostic) error, flush func() error, err error) {
+	noFlush := func() error { return nil }
+	switch format {
+	case "text":
+		return readableOutput(w), noFlush, nil
+	case "json":
+		return jsonOutput(w), noFlush, nil
+	case "github":
+		return githubOutput(w), noFlush, nil
+	case "sarif":
+		write, flush = sarifOutput(w)
+	case "checkstyle":
+		write, flush = checkstyleOutput(w)
+	case "junit":
+		write, flush = junitOutput(w)
+	default:
+		return nil, nil, fmt.Errorf("unknown output format %q: expected one of %v",
+			format, strings.Join(outputFormats, ", "))
+	}
+	return write, flush, nil
+}
+
+// The following outputs write a single document describing all
+// diagnostics. They return a function that records a diagnostic and a
+// function that writes the document once all diagnostics were recorded.
+
+// collect returns a function that records diagnostics and a function that
+// passes them to the given writer.
+func collect(write func([]compare.Diagnostic) error) (func(compare.Diagnostic) error, func() error) {
+	var diagnostics []compare.Diagnostic
+	add := func(d compare.Diagnostic) error {
+		diagnostics = append(diagnostics, d)
+		return nil
+	}
+	flush := func() error {
+		return write(diagnostics)
+	}
+	return add, flush
+}
+
+// githubOutput prints every lint error as a GitHub Actions workflow command
+// so that it is shown as an annotation on the offending line.
+//
+// See https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions.
+func githubOutput(w io.Writer) func(compare.Diagnostic) error {
+	return func(d compare.Diagnostic) error {
+		command := "error"
+		if d.Severity == compare.SeverityWarning {
+			command = "warning"
+		}
+
+		props := []string{"file=" + githubEscapeProperty(filepath.ToSlash(d.FilePath))}
+		if d.Line > 0 {
+			props = append(props,
+				fmt.Sprintf("line=%d", d.Line),
+				fmt.Sprintf("col=%d", d.Column))
+		}
+		if d.Rule != "" {
+			props = append(props, "title="+githubEscapeProperty(string(d.Rule)))
+		}
+
+		if _, err := fmt.Fprintf(w, "::%s %s::%s\n",
+			command, strings.Join(props, ","), githubEscapeData(d.Message)); err != nil {
+			return fmt.Errorf("failed to output a lint error: %v", err)
+		}
+		return nil
+	}
+}
+
+var (
+	_githubDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	_githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+func githubEscapeData(s string) string     { return _githubDataEscaper.Replace(s) }
+func githubEscapeProperty(s string) string { return _githubPropertyEscaper.Replace(s) }
+
+// sarifOutput writes all lint errors as a SARIF 2.1.0 log.
+//
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+func sarifOutput(w io.Writer) (func(compare.Diagnostic) error, func() error) {
+	return collect(func(diagnostics []compare.Diagnostic) error {
+		type (
+			message struct {
+				Text string `json:"text"`
+			}
+			rule struct {
+				ID string `json:"id"`
+			}
+			driver struct {
+				Name           string `json:"name"`
+				InformationURI string `json:"informationUri"`
+				Rules          []rule `json:"rules"`
+			}
+			tool struct {
+				Driver driver `json:"driver"`
+			}
+			artifactLocation struct {
+				URI string `json:"uri"`
+			}
+			region struct {
+				StartLine   int `json:"startLine"`
+				StartColumn int `json:"startColumn,omitempty"`
+			}
+			physicalLocation struct {
+				ArtifactLocation artifactLocation `json:"artifactLocation"`
+				Region           *region          `json:"region,omitempty"`
+			}
+			location struct {
+				PhysicalLocation physicalLocation `json:"physicalLocation"`
+			}
+			result struct {
+				RuleID    string     `json:"ruleId,omitempty"`
+				Level     string     `json:"level"`
+				Message   message    `json:"message"`
+				Locations []location `json:"locations"`
+			}
+			run struct {
+				Tool    tool     `json:"tool"`
+				Results []result `json:"results"`
+			}
+			log struct {
+				Schema  string `json:"$schema"`
+				Version string `json:"version"`
+				Runs    []run  `json:"runs"`
+			}
+		)
+
+		var (
+			rules   = []rule{}
+			seen    = make(map[compare.Rule]struct{})
+			results = make([]result, 0, len(diagnostics))
+		)
+		for _, d := range diagnostics {
+			if _, ok := seen[d.Rule]; !ok && d.Rule != "" {
+				seen[d.Rule] = struct{}{}
+				rules = append(rules, rule{ID: string(d.Rule)})
+			}
+
+			loc := physicalLocation{
+				ArtifactLocation: artifactLocation{URI: filepath.ToSlash(d.FilePath)},
+			}
+			if d.Line > 0 {
+				loc.Region = &region{StartLine: d.Line, StartColumn: d.Column}
+			}
+
+			level := "error"
+			if d.Severity == compare.SeverityWarning {
+				level = "warning"
+			}
+			results = append(results, result{
+				RuleID:    string(d.Rule),
+				Level:     level,
+				Message:   message{Text: d.Message},
+				Locations: []location{{PhysicalLocation: loc}},
+			})
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err := enc.Encode(log{
+			Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+			Version: "2.1.0",
+			Runs: []run{{
+				Tool: tool{Driver: driver{
+					Name:           "thriftbreak",
+					InformationURI: "https://github.com/thriftrw/thriftrw-go",
+					Rules:          rules,
+				}},
+				Results: results,
+			}},
+		})
+		if err != nil {
+			return fmt.Errorf("encode as SARIF: %v", err)
+		}
+		return nil
+	})
+}
+
+// checkstyleOutput writes all lint errors as a Checkstyle XML report.
+func checkstyleOutput(w io.Writer) (func(compare.Diagnostic) error, func() error) {
+	return collect(func(diagnostics []compare.Diagnostic) error {
+		type (
+			checkstyleError struct {
+				Line     int    `xml:"line,attr,omitempty"`
+				Column   int    `xml:"column,attr,omitempty"`
+				Severity string `xml:"severity,attr"`
+				Message  string `xml:"message,attr"`
+				Source   string `xml:"source,attr,omitempty"`
+			}
+			checkstyleFile struct {
+				Name   string            `xml:"name,attr"`
+				Errors []checkstyleError `xml:"error"`
+			}
+			checkstyle struct {
+				XMLName xml.Name          `xml:"checkstyle"`
+				Version string            `xml:"version,attr"`
+				Files   []*checkstyleFile `xml:"file"`
+			}
+		)
+
+		// Group errors by file, retaining the order in which files were
+		// first reported.
+		report := checkstyle{Version: "4.3"}
+		files := make(map[string]*checkstyleFile)
+		for _, d := range diagnostics {
+			f, ok := files[d.FilePath]
+			if !ok {
+				f = &checkstyleFile{Name: filepath.ToSlash(d.FilePath)}
+				files[d.FilePath] = f
+				report.Files = append(report.Files, f)
+			}
+
+			var source string
+			if d.Rule != "" {
+				source = "thriftbreak." + string(d.Rule)
+			}
+			f.Errors = append(f.Errors, checkstyleError{
+				Line:     d.Line,
+				Column:   d.Column,
+				Severity: severityOrError(d.Severity),
+				Message:  d.Message,
+				Source:   source,
+			})
+		}
+
+		return writeXML(w, report)
+	})
+}
+
+// junitOutput writes all lint errors as a JUnit XML report with a failed
+// test case for every error. Warnings are reported as passing test cases.
+func junitOutput(w io.Writer) (func(compare.Diagnostic) error, func() error) {
+	return collect(func(diagnostics []compare.Diagnostic) error {
+		type (
+			failure struct {
+				Message string `xml:"message,attr"`
+				Type    string `xml:"type,attr"`
+				Text    string `xml:",chardata"`
+			}
+			testCase struct {
+				Name      string   `xml:"name,attr"`
+				ClassName string   `xml:"classname,attr"`
+				Failure   *failure `xml:"failure,omitempty"`
+				SystemOut string   `xml:"system-out,omitempty"`
+			}
+			testSuite struct {
+				Name      string     `xml:"name,attr"`
+				Tests     int        `xml:"tests,attr"`
+				Failures  int        `xml:"failures,attr"`
+				TestCases []testCase `xml:"testcase"`
+			}
+			testSuites struct {
+				XMLName xml.Name    `xml:"testsuites"`
+				Suites  []testSuite `xml:"testsuite"`
+			}
+		)
+
+		suite := testSuite{Name: "thriftbreak"}
+		for _, d := range diagnostics {
+			tc := testCase{
+				Name:      d.String(),
+				ClassName: "thriftbreak." + string(d.Rule),
+			}
+			if d.Severity == compare.SeverityWarning {
+				tc.SystemOut = d.Message
+			} else {
+				suite.Failures++
+				tc.Failure = &failure{
+					Message: d.Message,
+					Type:    string(d.Rule),
+					Text:    d.String(),
+				}
+			}
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+		if len(suite.TestCases) == 0 {
+			// Record a passing test so that the report is not empty.
+			suite.TestCases = append(suite.TestCases, testCase{
+				Name:      "backwards compatibility",
+				ClassName: "thriftbreak",
+			})
+		}
+		suite.Tests = len(suite.TestCases)
+
+		return writeXML(w, testSuites{Suites: []testSuite{suite}})
+	})
+}
+
+func severityOrError(s compare.Severity) string {
+	if s == "" {
+		return string(compare.SeverityError)
+	}
+	return string(s)
+}
+
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("encode as XML: %v", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/thriftrw/internal/compare"
+)
+
+var _formatDiagnostics = []compare.Diagnostic{
+	{
+		FilePath: "a.thrift",
+		Line:     3,
+		Column:   5,
+		Message:  `removing method "foo" in service "Svc"`,
+		Rule:     compare.RuleMethodRemoved,
+		Severity: compare.SeverityError,
+	},
+	{
+		FilePath: "b.thrift",
+		Line:     2,
+		Column:   3,
+		Message:  `renaming field "a" in "S" to "b" breaks the JSON protocol`,
+		Rule:     compare.RuleFieldRenamed,
+		Severity: compare.SeverityWarning,
+	},
+	{
+		FilePath: "a.thrift",
+		Message:  `deleting service "Svc"`,
+		Rule:     compare.RuleServiceRemoved,
+		Severity: compare.SeverityError,
+	},
+}
+
+func formatDiagnostics(t *testing.T, format string, diagnostics []compare.Diagnostic) string {
+	t.Helper()
+
+	var b bytes.Buffer
+	write, flush, err := newOutput(format, &b)
+	require.NoError(t, err)
+	for _, d := range diagnostics {
+		require.NoError(t, write(d))
+	}
+	require.NoError(t, flush())
+	return b.String()
+}
+
+func TestGithubOutput(t *testing.T) {
+	t.Parallel()
+
+	got := formatDiagnostics(t, "github", append(_formatDiagnostics, compare.Diagnostic{
+		FilePath: "c,d.thrift",
+		Message:  "100%\nbroken",
+	}))
+	assert.Equal(t,
+		`::error file=a.thrift,line=3,col=5,title=method-removed::removing method "foo" in service "Svc"`+"\n"+
+			`::warning file=b.thrift,line=2,col=3,title=field-renamed::renaming field "a" in "S" to "b" breaks the JSON protocol`+"\n"+
+			`::error file=a.thrift,title=service-removed::deleting service "Svc"`+"\n"+
+			`::error file=c%2Cd.thrift::100%25%0Abroken`+"\n",
+		got)
+}
+
+func TestSARIFOutput(t *testing.T) {
+	t.Parallel()
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(formatDiagnostics(t, "sarif", _formatDiagnostics)), &got))
+
+	var want map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": [{
+			"tool": {"driver": {
+				"name": "thriftbreak",
+				"informationUri": "https://github.com/thriftrw/thriftrw-go",
+				"rules": [
+					{"id": "method-removed"},
+					{"id": "field-renamed"},
+					{"id": "service-removed"}
+				]
+			}},
+			"results": [
+				{
+					"ruleId": "method-removed",
+					"level": "error",
+					"message": {"text": "removing method \"foo\" in service \"Svc\""},
+					"locations": [{"physicalLocation": {
+						"artifactLocation": {"uri": "a.thrift"},
+						"region": {"startLine": 3, "startColumn": 5}
+					}}]
+				},
+				{
+					"ruleId": "field-renamed",
+					"level": "warning",
+					"message": {"text": "renaming field \"a\" in \"S\" to \"b\" breaks the JSON protocol"},
+					"locations": [{"physicalLocation": {
+						"artifactLocation": {"uri": "b.thrift"},
+						"region": {"startLine": 2, "startColumn": 3}
+					}}]
+				},
+				{
+					"ruleId": "service-removed",
+					"level": "error",
+					"message": {"text": "deleting service \"Svc\""},
+					"locations": [{"physicalLocation": {
+						"artifactLocation": {"uri": "a.thrift"}
+					}}]
+				}
+			]
+		}]
+	}`), &want))
+
+	assert.Equal(t, want, got)
+}
+
+func TestSARIFOutputEmpty(t *testing.T) {
+	t.Parallel()
+
+	var got struct {
+		Runs []struct {
+			Results []interface{} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(formatDiagnostics(t, "sarif", nil)), &got))
+	require.Len(t, got.Runs, 1)
+	assert.NotNil(t, got.Runs[0].Results, "results must be an empty list")
+	assert.Empty(t, got.Runs[0].Results)
+}
+
+func TestCheckstyleOutput(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="4.3">
+  <file name="a.thrift">
+    <error line="3" column="5" severity="error" message="removing method &#34;foo&#34; in service &#34;Svc&#34;" source="thriftbreak.method-removed"></error>
+    <error severity="error" message="deleting service &#34;Svc&#34;" source="thriftbreak.service-removed"></error>
+  </file>
+  <file name="b.thrift">
+    <error line="2" column="3" severity="warning" message="renaming field &#34;a&#34; in &#34;S&#34; to &#34;b&#34; breaks the JSON protocol" source="thriftbreak.field-renamed"></error>
+  </file>
+</checkstyle>
+`, formatDiagnostics(t, "checkstyle", _formatDiagnostics))
+}
+
+func TestJUnitOutput(t *testing.T) {
+	t.Parallel()
+
+	t.Run("issues", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="thriftbreak" tests="3" failures="2">
+    <testcase name="a.thrift:3:5:removing method &#34;foo&#34; in service &#34;Svc&#34;" classname="thriftbreak.method-removed">
+      <failure message="removing method &#34;foo&#34; in service &#34;Svc&#34;" type="method-removed">a.thrift:3:5:removing method &#34;foo&#34; in service &#34;Svc&#34;</failure>
+    </testcase>
+    <testcase name="b.thrift:2:3:warning: renaming field &#34;a&#34; in &#34;S&#34; to &#34;b&#34; breaks the JSON protocol" classname="thriftbreak.field-renamed">
+      <system-out>renaming field &#34;a&#34; in &#34;S&#34; to &#34;b&#34; breaks the JSON protocol</system-out>
+    </testcase>
+    <testcase name="a.thrift:deleting service &#34;Svc&#34;" classname="thriftbreak.service-removed">
+      <failure message="deleting service &#34;Svc&#34;" type="service-removed">a.thrift:deleting service &#34;Svc&#34;</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, formatDiagnostics(t, "junit", _formatDiagnostics))
+	})
+
+	t.Run("no issues", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="thriftbreak" tests="1" failures="0">
+    <testcase name="backwards compatibility" classname="thriftbreak"></testcase>
+  </testsuite>
+</testsuites>
+`, formatDiagnostics(t, "junit", nil))
+	})
+}
+
+func TestThriftBreakFormat(t *testing.T) {
+	dir := t.TempDir()
+	from, to := filepath.Join(dir, "from.thrift"), filepath.Join(dir, "to.thrift")
+	require.NoError(t, os.WriteFile(from, []byte("service Foo {\n    void methodA()\n}"), 0o644))
+	require.NoError(t, os.WriteFile(to, []byte("service Foo {}"), 0o644))
+
+	f, err := os.CreateTemp(dir, "stdout")
+	require.NoError(t, err, "create temporary file")
+	defer func(oldStdout *os.File) {
+		assert.NoError(t, f.Close())
+		os.Stdout = oldStdout
+	}(os.Stdout)
+	os.Stdout = f
+
+	assert.EqualError(t, run([]string{"--format=checkstyle", from, to}), "found 1 issues")
+
+	out, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="4.3">
+  <file name="from.thrift">
+    <error line="1" column="1" severity="error" message="removing method &#34;methodA&#34; in service &#34;Foo&#34;" source="thriftbreak.method-removed"></error>
+  </file>
+</checkstyle>
+`, string(out))
+}
@@ -26,8 +26,11 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"io/fs"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"go.uber.org/thriftrw/internal/compare"
 	"go.uber.org/thriftrw/internal/git"
@@ -66,44 +69,118 @@ func jsonOutput(w io.Writer) func(compare.Diagnostic) error {
 
 func run(args []string) error {
 	flag := flag.NewFlagSet("thriftbreak", flag.ContinueOnError)
+	flag.Usage = func() {
+		out := flag.Output()
+		fmt.Fprintln(out, "usage: thriftbreak [options]")
+		fmt.Fprintln(out, "       thriftbreak [options] FROM TO")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Without arguments, compares Thrift files between two revisions of a git repository.")
+		fmt.Fprintln(out, "With arguments, compares two Thrift files or two directories of Thrift files.")
+		fmt.Fprintln(out)
+		flag.PrintDefaults()
+	}
 	gitRepo := flag.String("C", "",
 		"location of git repository. Defaults to current directory.")
 	jsonOut := flag.Bool("json", false,
-		"output as a list of newline-delimited JSON objects with the following fields: FilePath and Message")
+		"output as a list of newline-delimited JSON objects with the following fields: FilePath, Line, Column, Message, Rule, and Severity. Same as --format=json.")
+	format := flag.String("format", "text",
+		"output format: one of "+strings.Join(outputFormats, ", ")+".")
+	configFile := flag.String("config", "",
+		"YAML file configuring the severity of rules. Defaults to "+defaultConfigFile+" in the git repository or the current directory if it exists.")
+	fromRev := flag.String("from", "",
+		"git revision with the previous version of the Thrift files. Defaults to the first parent of --to.")
+	toRev := flag.String("to", "",
+		"git revision with the new version of the Thrift files. Defaults to HEAD.")
+	mergeBase := flag.Bool("merge-base", false,
+		"compare --to against the merge base of --from and --to, reporting only changes made on the --to branch.")
 	if err := flag.Parse(args); err != nil {
 		return err
 	}
+	if *jsonOut {
+		if *format != "text" && *format != "json" {
+			return fmt.Errorf("--json cannot be used with --format=%v", *format)
+		}
+		*format = "json"
+	}
+	write, flush, err := newOutput(*format, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	var (
+		pass compare.Pass
+		cfg  *compare.Config
+	)
+	switch flag.NArg() {
+	case 0:
+		if *gitRepo == "" {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("cannot determine current directory: %v", err)
+			}
+			*gitRepo = cwd
+		}
 
-	if *gitRepo == "" {
-		cwd, err := os.Getwd()
+		cfg, err = loadConfig(*configFile, *gitRepo)
+		if err != nil {
+			return err
+		}
+		pass, err = git.CompareRevisions(*gitRepo, git.Revisions{
+			From:      *fromRev,
+			To:        *toRev,
+			MergeBase: *mergeBase,
+		}, cfg)
+	case 2:
+		if *gitRepo != "" || *fromRev != "" || *toRev != "" || *mergeBase {
+			return errors.New("-C, --from, --to, and --merge-base cannot be used when comparing paths")
+		}
+		cfg, err = loadConfig(*configFile, ".")
 		if err != nil {
-			return fmt.Errorf("cannot determine current directory: %v", err)
+			return err
 		}
-		*gitRepo = cwd
+		pass, err = compare.ComparePaths(flag.Arg(0), flag.Arg(1), cfg)
+	default:
+		flag.Usage()
+		return fmt.Errorf("expected two paths to compare, got %d", flag.NArg())
 	}
-
-	pass, err := git.Compare(*gitRepo)
 	// Errors in compiling phase, but not in backwards compatibility.
 	if err != nil {
 		return err
 	}
 
-	var write func(compare.Diagnostic) error
-	if *jsonOut {
-		write = jsonOutput(os.Stdout)
-	} else {
-		write = readableOutput(os.Stdout)
-	}
-	lints := pass.Lints()
-	for _, l := range lints {
+	var errs int
+	for _, l := range pass.Lints() {
 		if err := write(l); err != nil {
 			return fmt.Errorf("failed to output error: %v", err)
 		}
+		if l.Severity == compare.SeverityError {
+			errs++
+		}
+	}
+	if err := flush(); err != nil {
+		return fmt.Errorf("failed to output errors: %v", err)
 	}
 
-	if len(lints) > 0 {
-		return fmt.Errorf("found %d issues", len(lints))
+	// Warnings are reported but do not fail the check.
+	if errs > 0 {
+		return fmt.Errorf("found %d issues", errs)
 	}
 
 	return nil
 }
+
+// defaultConfigFile is the name of the configuration file that is used if
+// --config is not specified.
+const defaultConfigFile = ".thriftbreak.yaml"
+
+// loadConfig loads the configuration file at the given path. If path is
+// empty, defaultConfigFile in dir is loaded if it exists.
+func loadConfig(path, dir string) (*compare.Config, error) {
+	if path == "" {
+		path = filepath.Join(dir, defaultConfigFile)
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+	}
+	return compare.LoadConfig(path)
+}
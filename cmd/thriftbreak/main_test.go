@@ -24,6 +24,7 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -40,19 +41,19 @@ func TestThriftBreakIntegration(t *testing.T) {
 	}{
 		{
 			desc: "output",
-			want: `c.thrift:deleting service "Baz"` + "\n" +
-				`d.thrift:deleting service "Qux"` + "\n" +
-				`v2.thrift:deleting service "Bar"` + "\n" +
-				`v1.thrift:removing method "methodA" in service "Foo"` + "\n" +
-				`v1.thrift:adding a required field "C" to "AddedRequiredField"` + "\n",
+			want: `c.thrift:1:1:deleting service "Baz"` + "\n" +
+				`d.thrift:2:3:deleting service "Qux"` + "\n" +
+				`v2.thrift:1:1:deleting service "Bar"` + "\n" +
+				`v1.thrift:7:1:removing method "methodA" in service "Foo"` + "\n" +
+				`v1.thrift:5:5:adding a required field "C" to "AddedRequiredField"` + "\n",
 		},
 		{
 			desc: "json output",
-			want: `{"FilePath":"c.thrift","Message":"deleting service \"Baz\""}` + "\n" +
-				`{"FilePath":"d.thrift","Message":"deleting service \"Qux\""}` + "\n" +
-				`{"FilePath":"v2.thrift","Message":"deleting service \"Bar\""}` + "\n" +
-				`{"FilePath":"v1.thrift","Message":"removing method \"methodA\" in service \"Foo\""}` + "\n" +
-				`{"FilePath":"v1.thrift","Message":"adding a required field \"C\" to \"AddedRequiredField\""}` + "\n",
+			want: `{"FilePath":"c.thrift","Line":1,"Column":1,"Message":"deleting service \"Baz\"","Rule":"service-removed","Severity":"error"}` + "\n" +
+				`{"FilePath":"d.thrift","Line":2,"Column":3,"Message":"deleting service \"Qux\"","Rule":"service-removed","Severity":"error"}` + "\n" +
+				`{"FilePath":"v2.thrift","Line":1,"Column":1,"Message":"deleting service \"Bar\"","Rule":"service-removed","Severity":"error"}` + "\n" +
+				`{"FilePath":"v1.thrift","Line":7,"Column":1,"Message":"removing method \"methodA\" in service \"Foo\"","Rule":"method-removed","Severity":"error"}` + "\n" +
+				`{"FilePath":"v1.thrift","Line":5,"Column":5,"Message":"adding a required field \"C\" to \"AddedRequiredField\"","Rule":"field-required-added","Severity":"error"}` + "\n",
 			extraCmd: "--json",
 		},
 	}
@@ -97,7 +98,11 @@ func TestThriftBreakIntegration(t *testing.T) {
 			}(os.Stdout)
 			os.Stdout = f
 
-			err = run([]string{"-C=" + tmpDir, tt.extraCmd})
+			args := []string{"-C=" + tmpDir}
+			if tt.extraCmd != "" {
+				args = append(args, tt.extraCmd)
+			}
+			err = run(args)
 
 			require.Error(t, err, "expected an error with Thrift backwards incompatible changes")
 			assert.EqualError(t, err, "found 5 issues")
@@ -116,6 +121,7 @@ func TestDiagnosticPrinters(t *testing.T) {
 	tests := []struct {
 		desc   string
 		want   string
+		rule   compare.Rule
 		writer func(io.Writer) func(compare.Diagnostic) error
 	}{
 		{
@@ -123,6 +129,12 @@ func TestDiagnosticPrinters(t *testing.T) {
 			want:   `{"FilePath":"foo.thrift","Message":"error"}` + "\n",
 			writer: jsonOutput,
 		},
+		{
+			desc:   "json writer with rule",
+			want:   `{"FilePath":"foo.thrift","Message":"error","Rule":"service-removed"}` + "\n",
+			rule:   compare.RuleServiceRemoved,
+			writer: jsonOutput,
+		},
 		{
 			desc:   "readable writer",
 			want:   "foo.thrift:error\n",
@@ -138,9 +150,98 @@ func TestDiagnosticPrinters(t *testing.T) {
 			err := w(compare.Diagnostic{
 				FilePath: "foo.thrift",
 				Message:  "error",
+				Rule:     tt.rule,
 			})
 			require.NoError(t, err)
 			assert.Equal(t, tt.want, b.String())
 		})
 	}
 }
+
+func TestThriftBreakPaths(t *testing.T) {
+	dir := t.TempDir()
+	from, to := filepath.Join(dir, "from.thrift"), filepath.Join(dir, "to.thrift")
+	require.NoError(t, os.WriteFile(from, []byte("service Foo {\n    void methodA()\n}"), 0o644))
+	require.NoError(t, os.WriteFile(to, []byte("service Foo {}"), 0o644))
+
+	f, err := os.CreateTemp(dir, "stdout")
+	require.NoError(t, err, "create temporary file")
+	defer func(oldStdout *os.File) {
+		assert.NoError(t, f.Close())
+		os.Stdout = oldStdout
+	}(os.Stdout)
+	os.Stdout = f
+
+	err = run([]string{from, to})
+	assert.EqualError(t, err, "found 1 issues")
+
+	out, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	assert.Equal(t, `from.thrift:1:1:removing method "methodA" in service "Foo"`+"\n", string(out))
+}
+
+func TestThriftBreakArgumentErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		args    []string
+		wantErr string
+	}{
+		{
+			desc:    "one path",
+			args:    []string{"foo.thrift"},
+			wantErr: "expected two paths to compare, got 1",
+		},
+		{
+			desc:    "unknown format",
+			args:    []string{"--format=html", "a.thrift", "b.thrift"},
+			wantErr: `unknown output format "html": expected one of text, json, github, sarif, checkstyle, junit`,
+		},
+		{
+			desc:    "json with another format",
+			args:    []string{"--json", "--format=sarif", "a.thrift", "b.thrift"},
+			wantErr: "--json cannot be used with --format=sarif",
+		},
+		{
+			desc:    "revisions with paths",
+			args:    []string{"--from=main", "a.thrift", "b.thrift"},
+			wantErr: "-C, --from, --to, and --merge-base cannot be used when comparing paths",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.EqualError(t, run(tt.args), tt.wantErr)
+		})
+	}
+}
+
+func TestThriftBreakConfig(t *testing.T) {
+	dir := t.TempDir()
+	from, to := filepath.Join(dir, "from.thrift"), filepath.Join(dir, "to.thrift")
+	require.NoError(t, os.WriteFile(from, []byte("struct S {\n  1: optional string a\n}"), 0o644))
+	require.NoError(t, os.WriteFile(to, []byte("struct S {\n  1: optional string b\n}"), 0o644))
+
+	config := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(config, []byte("rules:\n  field-renamed: error\n"), 0o644))
+
+	f, err := os.CreateTemp(dir, "stdout")
+	require.NoError(t, err, "create temporary file")
+	defer func(oldStdout *os.File) {
+		assert.NoError(t, f.Close())
+		os.Stdout = oldStdout
+	}(os.Stdout)
+	os.Stdout = f
+
+	// Renaming a field is only a warning by default.
+	require.NoError(t, run([]string{from, to}))
+	assert.EqualError(t, run([]string{"--config=" + config, from, to}), "found 1 issues")
+	assert.ErrorContains(t, run([]string{"--config=" + filepath.Join(dir, "missing.yaml"), from, to}),
+		"missing.yaml")
+
+	out, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	assert.Equal(t,
+		`from.thrift:2:3:warning: renaming field "a" in "S" to "b" breaks the JSON protocol`+"\n"+
+			`from.thrift:2:3:renaming field "a" in "S" to "b" breaks the JSON protocol`+"\n",
+		string(out))
+}
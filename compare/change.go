@@ -0,0 +1,145 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compare
+
+import "fmt"
+
+// Compatibility classifies how a change affects existing users of a Thrift
+// file. Values are ordered from the least to the most disruptive so that
+// they may be compared with each other.
+type Compatibility int
+
+const (
+	// WireSafe changes affect neither the wire representation nor code
+	// generated for existing declarations. Additions are usually wire-safe.
+	WireSafe Compatibility = iota
+
+	// SourceBreaking changes keep the wire representation compatible but
+	// change or remove declarations in generated code. For example,
+	// renaming a field or replacing a type with an equivalent typedef.
+	SourceBreaking
+
+	// WireBreaking changes prevent older and newer readers and writers
+	// from exchanging data.
+	WireBreaking
+)
+
+func (c Compatibility) String() string {
+	switch c {
+	case WireSafe:
+		return "wire-safe"
+	case SourceBreaking:
+		return "source-breaking"
+	case WireBreaking:
+		return "wire-breaking"
+	default:
+		return fmt.Sprintf("Compatibility(%d)", int(c))
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c Compatibility) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// Kind identifies what changed between two versions of a Thrift file.
+//
+// The values of Kind are stable and may be stored or compared against.
+type Kind string
+
+// Kinds of changes to services and their methods.
+const (
+	ServiceAdded            Kind = "service-added"
+	ServiceRemoved          Kind = "service-removed"
+	ServiceExtendsChanged   Kind = "service-extends-changed"
+	MethodAdded             Kind = "method-added"
+	MethodRemoved           Kind = "method-removed"
+	MethodOnewayChanged     Kind = "method-oneway-changed"
+	MethodReturnTypeChanged Kind = "method-return-type-changed"
+	ExceptionAdded          Kind = "exception-added"
+	ExceptionRemoved        Kind = "exception-removed"
+	ExceptionTypeChanged    Kind = "exception-type-changed"
+)
+
+// Kinds of changes to fields of structs, unions, and exceptions, and to
+// arguments of methods.
+const (
+	FieldAdded        Kind = "field-added"
+	FieldRemoved      Kind = "field-removed"
+	FieldRenamed      Kind = "field-renamed"
+	FieldRenumbered   Kind = "field-renumbered"
+	FieldTypeChanged  Kind = "field-type-changed"
+	FieldMadeRequired Kind = "field-made-required"
+	FieldMadeOptional Kind = "field-made-optional"
+)
+
+// Kinds of changes to type declarations.
+const (
+	TypeAdded            Kind = "type-added"
+	TypeRemoved          Kind = "type-removed"
+	TypeKindChanged      Kind = "type-kind-changed"
+	TypedefTargetChanged Kind = "typedef-target-changed"
+	EnumItemAdded        Kind = "enum-item-added"
+	EnumItemRemoved      Kind = "enum-item-removed"
+	EnumItemRenamed      Kind = "enum-item-renamed"
+	EnumItemRenumbered   Kind = "enum-item-renumbered"
+)
+
+// Kinds of changes to constants.
+const (
+	ConstantAdded       Kind = "constant-added"
+	ConstantRemoved     Kind = "constant-removed"
+	ConstantTypeChanged Kind = "constant-type-changed"
+)
+
+// Change is a single difference between two versions of a Thrift file.
+type Change struct {
+	Kind Kind
+
+	// Path identifies the changed declaration. It is made up of the
+	// dot-separated names of the declaration and its parents, for example
+	// "Service", "Service.method", "Service.method.arg", "Struct.field",
+	// or "Enum.ITEM". Declarations of included modules are prefixed with
+	// the name of the include, for example "shared.Struct.field".
+	Path string
+
+	// File is the path to the Thrift file that holds the declaration. The
+	// newer file is used unless the declaration was removed with it.
+	File string
+
+	// Before and After hold the previous and new versions of the changed
+	// declaration. Before is nil for additions and After is nil for
+	// removals.
+	//
+	// Depending on the Kind, these hold a *compile.ServiceSpec,
+	// *compile.FunctionSpec, compile.TypeSpec, *compile.FieldSpec,
+	// *compile.EnumItem, or *compile.Constant. Changes to return types of
+	// methods hold the *compile.FunctionSpec; the return types of
+	// functions that return nothing are nil.
+	Before, After interface{}
+
+	// Compatibility classifies the effect of this change.
+	Compatibility Compatibility
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%v: %v (%v)", c.Path, c.Kind, c.Compatibility)
+}
@@ -0,0 +1,414 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package compare finds the differences between two versions of a compiled
+// Thrift module.
+//
+// Modules reports every added, removed, and modified declaration as a
+// Change that identifies the declaration, holds both versions of it, and
+// classifies whether the change breaks generated code or the wire
+// representation. Changelog generators, API review tools, and schema
+// registries may build on this.
+//
+//	from, err := compile.Compile("v1/service.thrift")
+//	...
+//	to, err := compile.Compile("v2/service.thrift")
+//	...
+//	for _, c := range compare.Modules(from, to) {
+//		if c.Compatibility == compare.WireBreaking {
+//			fmt.Println(c)
+//		}
+//	}
+package compare
+
+import (
+	"sort"
+
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/internal/compare/spec"
+)
+
+// Modules compares two versions of a Thrift module and returns the changes
+// made between them in a deterministic order.
+//
+// Modules included by both versions under the same name are compared as
+// well. Changes to their declarations are prefixed with the name of the
+// include.
+func Modules(from, to *compile.Module) []Change {
+	d := differ{visited: make(map[[2]string]struct{})}
+	d.modules("", from, to)
+	return d.changes
+}
+
+type differ struct {
+	changes []Change
+
+	// Pairs of Thrift files that were already compared.
+	visited map[[2]string]struct{}
+}
+
+func (d *differ) add(c Change) {
+	d.changes = append(d.changes, c)
+}
+
+func (d *differ) modules(prefix string, from, to *compile.Module) {
+	key := [2]string{from.ThriftPath, to.ThriftPath}
+	if _, ok := d.visited[key]; ok {
+		return
+	}
+	d.visited[key] = struct{}{}
+
+	for _, name := range unionKeys(from.Services, to.Services) {
+		f, t := from.Services[name], to.Services[name]
+		path := prefix + name
+		switch {
+		case t == nil:
+			d.add(Change{Kind: ServiceRemoved, Path: path, File: from.ThriftPath,
+				Before: f, Compatibility: WireBreaking})
+		case f == nil:
+			d.add(Change{Kind: ServiceAdded, Path: path, File: to.ThriftPath,
+				After: t, Compatibility: WireSafe})
+		default:
+			d.service(path, to.ThriftPath, f, t)
+		}
+	}
+
+	for _, name := range unionKeys(from.Types, to.Types) {
+		f, t := from.Types[name], to.Types[name]
+		path := prefix + name
+		switch {
+		case t == nil:
+			d.add(Change{Kind: TypeRemoved, Path: path, File: from.ThriftPath,
+				Before: f, Compatibility: SourceBreaking})
+		case f == nil:
+			d.add(Change{Kind: TypeAdded, Path: path, File: to.ThriftPath,
+				After: t, Compatibility: WireSafe})
+		default:
+			d.typ(path, to.ThriftPath, f, t)
+		}
+	}
+
+	for _, name := range unionKeys(from.Constants, to.Constants) {
+		f, t := from.Constants[name], to.Constants[name]
+		path := prefix + name
+		switch {
+		case t == nil:
+			d.add(Change{Kind: ConstantRemoved, Path: path, File: from.ThriftPath,
+				Before: f, Compatibility: SourceBreaking})
+		case f == nil:
+			d.add(Change{Kind: ConstantAdded, Path: path, File: to.ThriftPath,
+				After: t, Compatibility: WireSafe})
+		case f.Type.ThriftName() != t.Type.ThriftName():
+			d.add(Change{Kind: ConstantTypeChanged, Path: path, File: to.ThriftPath,
+				Before: f, After: t, Compatibility: SourceBreaking})
+		}
+	}
+
+	for _, name := range unionKeys(from.Includes, to.Includes) {
+		f, t := from.Includes[name], to.Includes[name]
+		if f != nil && t != nil {
+			d.modules(prefix+name+".", f.Module, t.Module)
+		}
+	}
+}
+
+func (d *differ) service(path, file string, from, to *compile.ServiceSpec) {
+	if fromParent := spec.ParentName(from); fromParent != spec.ParentName(to) {
+		c := WireBreaking
+		if fromParent == "" {
+			// Adding a parent only adds methods to the service.
+			c = WireSafe
+		}
+		d.add(Change{Kind: ServiceExtendsChanged, Path: path, File: file,
+			Before: from, After: to, Compatibility: c})
+	}
+
+	for _, name := range unionKeys(from.Functions, to.Functions) {
+		f, t := from.Functions[name], to.Functions[name]
+		fnPath := path + "." + name
+		switch {
+		case t == nil:
+			d.add(Change{Kind: MethodRemoved, Path: fnPath, File: file,
+				Before: f, Compatibility: WireBreaking})
+		case f == nil:
+			d.add(Change{Kind: MethodAdded, Path: fnPath, File: file,
+				After: t, Compatibility: WireSafe})
+		default:
+			d.function(fnPath, file, f, t)
+		}
+	}
+}
+
+func (d *differ) function(path, file string, from, to *compile.FunctionSpec) {
+	if from.OneWay != to.OneWay {
+		d.add(Change{Kind: MethodOnewayChanged, Path: path, File: file,
+			Before: from, After: to, Compatibility: WireBreaking})
+	}
+
+	d.fields(path, file, compile.FieldGroup(from.ArgsSpec), compile.FieldGroup(to.ArgsSpec), false)
+
+	if from.ResultSpec == nil || to.ResultSpec == nil {
+		// oneway functions do not have results.
+		return
+	}
+
+	if c := typeCompatibility(from.ResultSpec.ReturnType, to.ResultSpec.ReturnType); c != WireSafe {
+		d.add(Change{Kind: MethodReturnTypeChanged, Path: path, File: file,
+			Before: from, After: to, Compatibility: c})
+	}
+
+	d.exceptions(path, file, from.ResultSpec.Exceptions, to.ResultSpec.Exceptions)
+}
+
+// exceptions compares the exceptions thrown by two versions of a method,
+// matching them by their field IDs.
+func (d *differ) exceptions(path, file string, from, to compile.FieldGroup) {
+	toByID := make(map[int16]*compile.FieldSpec, len(to))
+	for _, t := range to {
+		toByID[t.ID] = t
+	}
+	fromByID := make(map[int16]*compile.FieldSpec, len(from))
+	for _, f := range from {
+		fromByID[f.ID] = f
+		t, ok := toByID[f.ID]
+		if !ok {
+			d.add(Change{Kind: ExceptionRemoved, Path: path + "." + f.Name, File: file,
+				Before: f, Compatibility: WireBreaking})
+			continue
+		}
+
+		excPath := path + "." + t.Name
+		if f.Name != t.Name {
+			d.add(Change{Kind: FieldRenamed, Path: excPath, File: file,
+				Before: f, After: t, Compatibility: SourceBreaking})
+		}
+		if c := typeCompatibility(f.Type, t.Type); c != WireSafe {
+			d.add(Change{Kind: ExceptionTypeChanged, Path: excPath, File: file,
+				Before: f, After: t, Compatibility: c})
+		}
+	}
+	for _, t := range to {
+		if _, ok := fromByID[t.ID]; !ok {
+			d.add(Change{Kind: ExceptionAdded, Path: path + "." + t.Name, File: file,
+				After: t, Compatibility: WireSafe})
+		}
+	}
+}
+
+// fields compares two versions of the fields of a struct, union, or
+// exception, or the arguments of a method.
+//
+// Fields are matched by name and reported as renumbered if their IDs
+// differ. Fields that could not be matched by name are matched by ID and
+// reported as renamed, unless the field that previously held the ID still
+// exists under a different ID.
+func (d *differ) fields(path, file string, from, to compile.FieldGroup, union bool) {
+	toByID := make(map[int16]*compile.FieldSpec, len(to))
+	toByName := make(map[string]*compile.FieldSpec, len(to))
+	for _, t := range to {
+		toByID[t.ID] = t
+		toByName[t.Name] = t
+	}
+	fromByID := make(map[int16]*compile.FieldSpec, len(from))
+	fromByName := make(map[string]*compile.FieldSpec, len(from))
+	for _, f := range from {
+		fromByID[f.ID] = f
+		fromByName[f.Name] = f
+	}
+
+	for _, t := range to {
+		fieldPath := path + "." + t.Name
+		f, ok := fromByName[t.Name]
+		if ok {
+			if f.ID != t.ID {
+				d.add(Change{Kind: FieldRenumbered, Path: fieldPath, File: file,
+					Before: f, After: t, Compatibility: WireBreaking})
+			}
+		} else if f, ok = fromByID[t.ID]; ok {
+			if _, moved := toByName[f.Name]; moved {
+				// Values written with the previous field are read into
+				// this one.
+				d.add(Change{Kind: FieldAdded, Path: fieldPath, File: file,
+					After: t, Compatibility: WireBreaking})
+				continue
+			}
+			d.add(Change{Kind: FieldRenamed, Path: fieldPath, File: file,
+				Before: f, After: t, Compatibility: SourceBreaking})
+		} else {
+			c := WireSafe
+			if union || t.Required {
+				// Older readers reject unions with unknown arms and
+				// values that do not have required fields.
+				c = WireBreaking
+			}
+			d.add(Change{Kind: FieldAdded, Path: fieldPath, File: file,
+				After: t, Compatibility: c})
+			continue
+		}
+
+		if c := typeCompatibility(f.Type, t.Type); c != WireSafe {
+			d.add(Change{Kind: FieldTypeChanged, Path: fieldPath, File: file,
+				Before: f, After: t, Compatibility: c})
+		}
+
+		switch {
+		case !f.Required && t.Required:
+			d.add(Change{Kind: FieldMadeRequired, Path: fieldPath, File: file,
+				Before: f, After: t, Compatibility: WireBreaking})
+		case f.Required && !t.Required:
+			d.add(Change{Kind: FieldMadeOptional, Path: fieldPath, File: file,
+				Before: f, After: t, Compatibility: SourceBreaking})
+		}
+	}
+
+	for _, f := range from {
+		if _, ok := toByName[f.Name]; ok {
+			continue // unchanged or renumbered
+		}
+		if t, ok := toByID[f.ID]; ok {
+			if _, ok := fromByName[t.Name]; !ok {
+				continue // renamed
+			}
+		}
+
+		c := SourceBreaking
+		if union || f.Required {
+			// Newer readers reject values that use the removed arm or do
+			// not have the removed required field.
+			c = WireBreaking
+		}
+		d.add(Change{Kind: FieldRemoved, Path: path + "." + f.Name, File: file,
+			Before: f, Compatibility: c})
+	}
+}
+
+func (d *differ) typ(path, file string, from, to compile.TypeSpec) {
+	if fromKind, toKind := spec.Kind(from), spec.Kind(to); fromKind != toKind {
+		c := WireBreaking
+		if spec.IsStructOrException(fromKind) && spec.IsStructOrException(toKind) {
+			// Structs and exceptions have the same representation.
+			c = SourceBreaking
+		}
+		d.add(Change{Kind: TypeKindChanged, Path: path, File: file,
+			Before: from, After: to, Compatibility: c})
+		return
+	}
+
+	switch f := from.(type) {
+	case *compile.StructSpec:
+		d.fields(path, file, f.Fields, to.(*compile.StructSpec).Fields, f.Type == ast.UnionType)
+	case *compile.EnumSpec:
+		d.enumItems(path, file, f, to.(*compile.EnumSpec))
+	case *compile.TypedefSpec:
+		if c := typeCompatibility(f.Target, to.(*compile.TypedefSpec).Target); c != WireSafe {
+			d.add(Change{Kind: TypedefTargetChanged, Path: path, File: file,
+				Before: from, After: to, Compatibility: c})
+		}
+	}
+}
+
+// enumItems compares two versions of an enum. Items are matched by name.
+// Items that could not be matched by name are matched by value and
+// reported as renamed.
+func (d *differ) enumItems(path, file string, from, to *compile.EnumSpec) {
+	toByName := make(map[string]*compile.EnumItem, len(to.Items))
+	for i := range to.Items {
+		toByName[to.Items[i].Name] = &to.Items[i]
+	}
+	fromByName := make(map[string]*compile.EnumItem, len(from.Items))
+	for i := range from.Items {
+		fromByName[from.Items[i].Name] = &from.Items[i]
+	}
+
+	// Items whose names are not present in the other version.
+	removed := make(map[int32]*compile.EnumItem)
+	for i, f := range from.Items {
+		if _, ok := toByName[f.Name]; !ok {
+			removed[f.Value] = &from.Items[i]
+		}
+	}
+	added := make(map[int32]*compile.EnumItem)
+	for i, t := range to.Items {
+		if _, ok := fromByName[t.Name]; !ok {
+			added[t.Value] = &to.Items[i]
+		}
+	}
+
+	for i := range to.Items {
+		t := &to.Items[i]
+		itemPath := path + "." + t.Name
+		if f, ok := fromByName[t.Name]; ok {
+			if f.Value != t.Value {
+				d.add(Change{Kind: EnumItemRenumbered, Path: itemPath, File: file,
+					Before: f, After: t, Compatibility: WireBreaking})
+			}
+		} else if f, ok := removed[t.Value]; ok {
+			d.add(Change{Kind: EnumItemRenamed, Path: itemPath, File: file,
+				Before: f, After: t, Compatibility: SourceBreaking})
+		} else {
+			d.add(Change{Kind: EnumItemAdded, Path: itemPath, File: file,
+				After: t, Compatibility: WireSafe})
+		}
+	}
+
+	for i := range from.Items {
+		f := &from.Items[i]
+		if _, ok := toByName[f.Name]; ok {
+			continue
+		}
+		if _, ok := added[f.Value]; ok {
+			continue // renamed
+		}
+		d.add(Change{Kind: EnumItemRemoved, Path: path + "." + f.Name, File: file,
+			Before: f, Compatibility: WireBreaking})
+	}
+}
+
+// typeCompatibility classifies replacing one type with another. Types that
+// resolve to the same type after resolving typedefs keep the wire
+// representation but change the generated code. Nil types stand for the
+// return types of functions that return nothing.
+func typeCompatibility(from, to compile.TypeSpec) Compatibility {
+	switch {
+	case !spec.Same(from, to):
+		return WireBreaking
+	case spec.Name(from) != spec.Name(to):
+		return SourceBreaking
+	default:
+		return WireSafe
+	}
+}
+
+// unionKeys returns the keys present in either of the given maps in a
+// stable order.
+func unionKeys[V any](from, to map[string]V) []string {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,534 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compare
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/thriftrw/compile"
+	thriftbreak "go.uber.org/thriftrw/internal/compare"
+)
+
+// memFS is an in-memory filesystem for use with compile.Compile.
+type memFS map[string]string
+
+func (fs memFS) Abs(p string) (string, error) {
+	if strings.HasPrefix(p, "/") {
+		return p, nil
+	}
+	return "/" + p, nil
+}
+
+func (fs memFS) Read(path string) ([]byte, error) {
+	if contents, ok := fs[strings.TrimPrefix(path, "/")]; ok {
+		return []byte(contents), nil
+	}
+	return nil, fmt.Errorf("file not found: %v", path)
+}
+
+func compileFiles(t *testing.T, files memFS) *compile.Module {
+	t.Helper()
+
+	m, err := compile.Compile("/main.thrift", compile.Filesystem(files))
+	require.NoError(t, err)
+	return m
+}
+
+// change is a Change without the specs.
+type change struct {
+	Kind          Kind
+	Path          string
+	Compatibility Compatibility
+}
+
+func TestModules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc string
+		from string
+		to   string
+		want []change
+	}{
+		{
+			desc: "no changes",
+			from: "struct Foo { 1: optional string a }",
+			to:   "struct Foo { 1: optional string a }",
+		},
+		{
+			desc: "services and methods",
+			from: `
+				service Removed {}
+				service Kept {
+					void removed()
+					void kept(1: string a)
+					oneway void fire()
+				}`,
+			to: `
+				service Added {}
+				service Kept {
+					void kept(1: string a, 2: string b)
+					void added()
+					void fire()
+				}`,
+			want: []change{
+				{ServiceAdded, "Added", WireSafe},
+				{MethodAdded, "Kept.added", WireSafe},
+				{MethodOnewayChanged, "Kept.fire", WireBreaking},
+				{FieldAdded, "Kept.kept.b", WireSafe},
+				{MethodRemoved, "Kept.removed", WireBreaking},
+				{ServiceRemoved, "Removed", WireBreaking},
+			},
+		},
+		{
+			desc: "service extends added",
+			from: "service Base {}\nservice Foo {}",
+			to:   "service Base {}\nservice Foo extends Base {}",
+			want: []change{
+				{ServiceExtendsChanged, "Foo", WireSafe},
+			},
+		},
+		{
+			desc: "service extends removed",
+			from: "service Base {}\nservice Foo extends Base {}",
+			to:   "service Base {}\nservice Foo {}",
+			want: []change{
+				{ServiceExtendsChanged, "Foo", WireBreaking},
+			},
+		},
+		{
+			desc: "return types and exceptions",
+			from: `
+				typedef i64 Timestamp
+				exception A {}
+				exception B {}
+				exception C {}
+				service Foo {
+					i64 now() throws (1: A a, 2: B b)
+					i64 later()
+					void nothing() throws (1: A a)
+				}`,
+			to: `
+				typedef i64 Timestamp
+				exception A {}
+				exception B {}
+				exception C {}
+				service Foo {
+					Timestamp now() throws (1: A a, 3: C c)
+					string later()
+					void nothing() throws (1: B failure)
+				}`,
+			want: []change{
+				{MethodReturnTypeChanged, "Foo.later", WireBreaking},
+				{FieldRenamed, "Foo.nothing.failure", SourceBreaking},
+				{ExceptionTypeChanged, "Foo.nothing.failure", WireBreaking},
+				{MethodReturnTypeChanged, "Foo.now", SourceBreaking},
+				{ExceptionRemoved, "Foo.now.b", WireBreaking},
+				{ExceptionAdded, "Foo.now.c", WireSafe},
+			},
+		},
+		{
+			desc: "struct fields",
+			from: `
+				typedef string UUID
+				struct Foo {
+					1: required string a
+					2: optional string b
+					3: optional string c
+					4: optional i32 d
+					5: optional string e
+					6: required string f
+					7: optional string g
+					8: optional string h
+				}`,
+			to: `
+				typedef string UUID
+				struct Foo {
+					1: required string a
+					2: optional string renamed
+					3: optional UUID c
+					4: optional i64 d
+					6: optional string f
+					7: required string g
+					9: optional string h
+					10: optional string i
+					11: required string j
+				}`,
+			want: []change{
+				{FieldRenamed, "Foo.renamed", SourceBreaking},
+				{FieldTypeChanged, "Foo.c", SourceBreaking},
+				{FieldTypeChanged, "Foo.d", WireBreaking},
+				{FieldMadeOptional, "Foo.f", SourceBreaking},
+				{FieldMadeRequired, "Foo.g", WireBreaking},
+				{FieldRenumbered, "Foo.h", WireBreaking},
+				{FieldAdded, "Foo.i", WireSafe},
+				{FieldAdded, "Foo.j", WireBreaking},
+				{FieldRemoved, "Foo.e", SourceBreaking},
+			},
+		},
+		{
+			desc: "field moved to a new ID",
+			from: "struct Foo { 1: optional string a }",
+			to:   "struct Foo { 1: optional string b\n 2: optional string a }",
+			want: []change{
+				{FieldAdded, "Foo.b", WireBreaking},
+				{FieldRenumbered, "Foo.a", WireBreaking},
+			},
+		},
+		{
+			desc: "field IDs swapped",
+			from: "struct Foo { 1: optional string a\n 2: optional string b }",
+			to:   "struct Foo { 1: optional string b\n 2: optional string a }",
+			want: []change{
+				{FieldRenumbered, "Foo.b", WireBreaking},
+				{FieldRenumbered, "Foo.a", WireBreaking},
+			},
+		},
+		{
+			desc: "removed required field",
+			from: "struct Foo { 1: required string a }",
+			to:   "struct Foo {}",
+			want: []change{
+				{FieldRemoved, "Foo.a", WireBreaking},
+			},
+		},
+		{
+			desc: "union arms",
+			from: "union Foo { 1: string a\n 2: i32 b }",
+			to:   "union Foo { 1: string a\n 3: i64 c }",
+			want: []change{
+				{FieldAdded, "Foo.c", WireBreaking},
+				{FieldRemoved, "Foo.b", WireBreaking},
+			},
+		},
+		{
+			desc: "types",
+			from: `
+				struct Removed {}
+				struct Foo {}
+				struct Bar {}
+				exception Baz {}
+				typedef i32 A
+				typedef i32 B
+				typedef A C`,
+			to: `
+				struct Added {}
+				union Foo {}
+				exception Bar {}
+				exception Baz {}
+				typedef i64 A
+				typedef i32 B
+				typedef B C`,
+			want: []change{
+				{TypedefTargetChanged, "A", WireBreaking},
+				{TypeAdded, "Added", WireSafe},
+				{TypeKindChanged, "Bar", SourceBreaking},
+				{TypedefTargetChanged, "C", SourceBreaking},
+				{TypeKindChanged, "Foo", WireBreaking},
+				{TypeRemoved, "Removed", SourceBreaking},
+			},
+		},
+		{
+			desc: "enums",
+			from: "enum Color { RED = 1, GREEN = 2, BLUE = 3, BLACK = 4 }",
+			to:   "enum Color { RED = 1, GREEN = 5, AZURE = 3, WHITE = 6 }",
+			want: []change{
+				{EnumItemRenumbered, "Color.GREEN", WireBreaking},
+				{EnumItemRenamed, "Color.AZURE", SourceBreaking},
+				{EnumItemAdded, "Color.WHITE", WireSafe},
+				{EnumItemRemoved, "Color.BLACK", WireBreaking},
+			},
+		},
+		{
+			desc: "constants",
+			from: "const i32 A = 1\nconst i32 B = 2\nconst string C = 'c'",
+			to:   "const i64 A = 1\nconst string C = 'c'\nconst i32 D = 4",
+			want: []change{
+				{ConstantTypeChanged, "A", SourceBreaking},
+				{ConstantRemoved, "B", SourceBreaking},
+				{ConstantAdded, "D", WireSafe},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+
+			from := compileFiles(t, memFS{"main.thrift": tt.from})
+			to := compileFiles(t, memFS{"main.thrift": tt.to})
+
+			var got []change
+			for _, c := range Modules(from, to) {
+				got = append(got, change{c.Kind, c.Path, c.Compatibility})
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestModulesSpecs(t *testing.T) {
+	t.Parallel()
+
+	from := compileFiles(t, memFS{"main.thrift": `
+		struct Foo { 1: optional string a }
+		enum Color { RED }
+		service Svc { void removed() }`})
+	to := compileFiles(t, memFS{"main.thrift": `
+		struct Foo { 1: optional i32 a }
+		enum Color { RED, GREEN }
+		service Svc {}`})
+
+	changes := Modules(from, to)
+	require.Len(t, changes, 3)
+
+	assert.Equal(t, MethodRemoved, changes[0].Kind)
+	assert.Equal(t, from.Services["Svc"].Functions["removed"], changes[0].Before)
+	assert.Nil(t, changes[0].After, "After must be nil for removals")
+
+	color := to.Types["Color"].(*compile.EnumSpec)
+	assert.Equal(t, Change{
+		Kind:          EnumItemAdded,
+		Path:          "Color.GREEN",
+		File:          "/main.thrift",
+		After:         &color.Items[1],
+		Compatibility: WireSafe,
+	}, changes[1])
+
+	assert.Equal(t, Change{
+		Kind:          FieldTypeChanged,
+		Path:          "Foo.a",
+		File:          "/main.thrift",
+		Before:        from.Types["Foo"].(*compile.StructSpec).Fields[0],
+		After:         to.Types["Foo"].(*compile.StructSpec).Fields[0],
+		Compatibility: WireBreaking,
+	}, changes[2])
+
+}
+
+func TestModulesIncludes(t *testing.T) {
+	t.Parallel()
+
+	from := compileFiles(t, memFS{
+		"main.thrift":   "include \"./shared.thrift\"\nstruct Foo { 1: optional shared.Bar bar }",
+		"shared.thrift": "struct Bar { 1: optional string a }",
+	})
+	to := compileFiles(t, memFS{
+		"main.thrift":   "include \"./shared.thrift\"\nstruct Foo { 1: optional shared.Bar bar }",
+		"shared.thrift": "struct Bar { 1: optional i64 a }",
+	})
+
+	changes := Modules(from, to)
+	require.Len(t, changes, 1)
+	assert.Equal(t, FieldTypeChanged, changes[0].Kind)
+	assert.Equal(t, "shared.Bar.a", changes[0].Path)
+	assert.Equal(t, "/shared.thrift", changes[0].File)
+}
+
+func TestCompatibilityString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give Compatibility
+		want string
+	}{
+		{WireSafe, "wire-safe"},
+		{SourceBreaking, "source-breaking"},
+		{WireBreaking, "wire-breaking"},
+		{Compatibility(42), "Compatibility(42)"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.give.String())
+
+		text, err := tt.give.MarshalText()
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, string(text))
+	}
+}
+
+// thriftbreakKinds maps the rules of thriftbreak to the kinds of changes
+// that they correspond to.
+var thriftbreakKinds = map[thriftbreak.Rule][]Kind{
+	thriftbreak.RuleServiceRemoved:          {ServiceRemoved},
+	thriftbreak.RuleServiceExtendsChanged:   {ServiceExtendsChanged},
+	thriftbreak.RuleMethodRemoved:           {MethodRemoved},
+	thriftbreak.RuleMethodOnewayChanged:     {MethodOnewayChanged},
+	thriftbreak.RuleMethodReturnTypeChanged: {MethodReturnTypeChanged},
+	thriftbreak.RuleMethodExceptionRemoved:  {ExceptionRemoved},
+	thriftbreak.RuleMethodExceptionChanged:  {ExceptionTypeChanged},
+	thriftbreak.RuleFieldRequiredAdded:      {FieldAdded},
+	thriftbreak.RuleFieldRequiredRemoved:    {FieldRemoved},
+	thriftbreak.RuleFieldOptionalToRequired: {FieldMadeRequired},
+	thriftbreak.RuleFieldTypeChanged:        {FieldTypeChanged},
+	thriftbreak.RuleFieldRenumbered:         {FieldRenumbered},
+	thriftbreak.RuleFieldRenamed:            {FieldRenamed},
+	thriftbreak.RuleFieldIDReused:           {FieldTypeChanged, FieldRenumbered, FieldAdded},
+	thriftbreak.RuleUnionArmAdded:           {FieldAdded},
+	thriftbreak.RuleUnionArmRemoved:         {FieldRemoved},
+	thriftbreak.RuleTypeKindChanged:         {TypeKindChanged},
+	thriftbreak.RuleEnumItemRemoved:         {EnumItemRemoved},
+	thriftbreak.RuleEnumItemRenumbered:      {EnumItemRenumbered},
+	thriftbreak.RuleEnumItemRenamed:         {EnumItemRenamed},
+	thriftbreak.RuleTypedefTargetChanged:    {TypedefTargetChanged},
+}
+
+// TestModulesAgreesWithThriftbreak verifies that thriftbreak reports an
+// error for a change if and only if Modules classifies it as wire-breaking,
+// and that its warnings are source-breaking changes.
+func TestModulesAgreesWithThriftbreak(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc     string
+		from, to string
+	}{
+		{"service removed", "service S {}", ""},
+		{
+			"service extends added",
+			"service A {}\nservice S {}",
+			"service A {}\nservice S extends A {}",
+		},
+		{
+			"service extends removed",
+			"service A {}\nservice S extends A {}",
+			"service A {}\nservice S {}",
+		},
+		{
+			"service extends replaced",
+			"service A {}\nservice B {}\nservice S extends A {}",
+			"service A {}\nservice B {}\nservice S extends B {}",
+		},
+		{"method removed", "service S { void m() }", "service S {}"},
+		{"method oneway changed", "service S { void m() }", "service S { oneway void m() }"},
+		{"method return type changed", "service S { string m() }", "service S { i64 m() }"},
+		{
+			"method return type typedef",
+			"service S { string m() }",
+			"typedef string Str\nservice S { Str m() }",
+		},
+		{
+			"method exception removed",
+			"exception E {}\nservice S { void m() throws (1: E e) }",
+			"exception E {}\nservice S { void m() }",
+		},
+		{
+			"method exception replaced",
+			"exception E1 {}\nexception E2 {}\nservice S { void m() throws (1: E1 e) }",
+			"exception E1 {}\nexception E2 {}\nservice S { void m() throws (1: E2 e) }",
+		},
+		{
+			"method required argument added",
+			"service S { void m() }",
+			"service S { void m(1: required string a) }",
+		},
+		{
+			"required field added",
+			"struct S { 1: optional string a }",
+			"struct S { 1: optional string a\n 2: required string b }",
+		},
+		{
+			"optional field added",
+			"struct S { 1: optional string a }",
+			"struct S { 1: optional string a\n 2: optional string b }",
+		},
+		{"required field removed", "struct S { 1: required string a }", "struct S {}"},
+		{"optional field removed", "struct S { 1: optional string a }", "struct S {}"},
+		{"field made required", "struct S { 1: optional string a }", "struct S { 1: required string a }"},
+		{"field type changed", "struct S { 1: optional string a }", "struct S { 1: optional i64 a }"},
+		{
+			"field type typedef",
+			"struct S { 1: optional string a }",
+			"typedef string Str\nstruct S { 1: optional Str a }",
+		},
+		{"field renumbered", "struct S { 1: optional string a }", "struct S { 2: optional string a }"},
+		{"field renamed", "struct S { 1: optional string a }", "struct S { 1: optional string b }"},
+		{
+			"field ID reused with a different type",
+			"struct S { 1: optional string a }",
+			"struct S { 1: optional i64 b }",
+		},
+		{
+			"field ID reused after moving field",
+			"struct S { 1: optional string a }",
+			"struct S { 1: optional string b\n 2: optional string a }",
+		},
+		{
+			"field IDs swapped",
+			"struct S { 1: optional string a\n 2: optional string b }",
+			"struct S { 1: optional string b\n 2: optional string a }",
+		},
+		{"union arm added", "union U { 1: string a }", "union U { 1: string a\n 2: i64 b }"},
+		{"union arm removed", "union U { 1: string a\n 2: i64 b }", "union U { 1: string a }"},
+		{"struct changed to union", "struct S {}", "union S {}"},
+		{"struct changed to exception", "struct S {}", "exception S {}"},
+		{"enum item added", "enum E { A = 1 }", "enum E { A = 1, B = 2 }"},
+		{"enum item removed", "enum E { A = 1, B = 2 }", "enum E { A = 1 }"},
+		{"enum item renumbered", "enum E { A = 1 }", "enum E { A = 2 }"},
+		{"enum item renamed", "enum E { A = 1 }", "enum E { B = 1 }"},
+		{"typedef target changed", "typedef string T", "typedef i64 T"},
+		{"type removed", "struct S {}", ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+
+			from := compileFiles(t, memFS{"main.thrift": tt.from})
+			to := compileFiles(t, memFS{"main.thrift": tt.to})
+
+			changes := make(map[Kind]Compatibility)
+			var wireBreaking bool
+			for _, c := range Modules(from, to) {
+				changes[c.Kind] = max(changes[c.Kind], c.Compatibility)
+				wireBreaking = wireBreaking || c.Compatibility == WireBreaking
+			}
+
+			var pass thriftbreak.Pass
+			pass.CompareModules(from, to)
+
+			var hasError bool
+			for _, d := range pass.Lints() {
+				kinds, ok := thriftbreakKinds[d.Rule]
+				require.True(t, ok, "unknown rule %q", d.Rule)
+
+				want := SourceBreaking
+				if d.Severity == thriftbreak.SeverityError {
+					hasError = true
+					want = WireBreaking
+				}
+
+				var found bool
+				for _, k := range kinds {
+					found = found || changes[k] == want
+				}
+				assert.True(t, found, "%v: no %v change of kinds %v in %v", d.Rule, want, kinds, changes)
+			}
+			assert.Equal(t, wireBreaking, hasError,
+				"wire-breaking changes must be reported as errors: %v", changes)
+		})
+	}
+}
@@ -31,17 +31,43 @@ import (
 // Compile parses and compiles the Thrift file at the given path and any other
 // Thrift file it includes.
 func Compile(path string, opts ...Option) (*Module, error) {
+	ms, err := CompileFiles([]string{path}, opts...)
+	if len(ms) == 0 {
+		return nil, err
+	}
+	return ms[0], err
+}
+
+// CompileFiles parses and compiles the Thrift files at the given paths and
+// any other Thrift files they include. The returned modules are in the same
+// order as the paths.
+//
+// Thrift files are parsed and compiled at most once, even if they're
+// included by, or provided as, more than one of the given files. Modules
+// for the same Thrift file are therefore shared between the returned
+// modules.
+func CompileFiles(paths []string, opts ...Option) ([]*Module, error) {
 	c := newCompiler()
 	for _, opt := range opts {
 		opt(&c)
 	}
 
-	m, err := c.load(path)
-	if err != nil {
-		return nil, err
+	modules := make([]*Module, len(paths))
+	for i, path := range paths {
+		m, err := c.load(path)
+		if err != nil {
+			return nil, err
+		}
+		modules[i] = m
 	}
 
-	err = m.Walk(func(m *Module) error {
+	linked := make(map[string]struct{})
+	link := func(m *Module) error {
+		if _, ok := linked[m.ThriftPath]; ok {
+			return nil
+		}
+		linked[m.ThriftPath] = struct{}{}
+
 		if err := c.link(m); err != nil {
 			return compileError{
 				Target: m.ThriftPath,
@@ -49,8 +75,14 @@ func Compile(path string, opts ...Option) (*Module, error) {
 			}
 		}
 		return nil
-	})
-	return m, err
+	}
+
+	for _, m := range modules {
+		if err := m.Walk(link); err != nil {
+			return modules, err
+		}
+	}
+	return modules, nil
 }
 
 // compiler is responsible for compiling Thrift files.
@@ -174,6 +206,14 @@ func (c compiler) gather(m *Module, prog *ast.Program) error {
 
 	// Process all included modules first.
 	for _, h := range prog.Headers {
+		if ns, ok := h.(*ast.Namespace); ok {
+			if m.Namespaces == nil {
+				m.Namespaces = make(map[string]string)
+			}
+			m.Namespaces[ns.Scope] = ns.Name
+			continue
+		}
+
 		header, ok := h.(*ast.Include)
 		if !ok {
 			continue
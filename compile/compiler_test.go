@@ -86,3 +86,66 @@ func TestCompileNonStrict(t *testing.T) {
 	require.NoError(t, err, "Failed to find UUID field in struct")
 	assert.False(t, uuidField.Required, "Unspecified requiredness should be treated as optional")
 }
+
+func TestCompileNamespaces(t *testing.T) {
+	files := map[string]string{
+		"/some/prefix/main.thrift": `
+			namespace go foo.bar
+			namespace py foo_py
+			namespace * foo.all
+
+			include "./shared.thrift"
+
+			namespace go foo.baz
+		`,
+		"/some/prefix/shared.thrift": `
+			typedef string UUID
+		`,
+	}
+
+	fs := dummyFS{"/some/prefix/", files}
+
+	module, err := Compile("main.thrift", Filesystem(fs))
+	require.NoError(t, err, "Compile failed")
+
+	assert.Equal(t, map[string]string{
+		"go": "foo.baz",
+		"py": "foo_py",
+		"*":  "foo.all",
+	}, module.Namespaces)
+	assert.Nil(t, module.Includes["shared"].Module.Namespaces)
+}
+
+func TestCompileFiles(t *testing.T) {
+	files := map[string]string{
+		"/some/prefix/a.thrift": `
+			include "./shared.thrift"
+			struct A { 1: optional shared.UUID uuid }
+		`,
+		"/some/prefix/b.thrift": `
+			include "./shared.thrift"
+			struct B { 1: optional shared.UUID uuid }
+		`,
+		"/some/prefix/shared.thrift": `
+			typedef string UUID
+		`,
+	}
+
+	fs := dummyFS{"/some/prefix/", files}
+
+	modules, err := CompileFiles([]string{"a.thrift", "b.thrift", "shared.thrift"}, Filesystem(fs))
+	require.NoError(t, err, "Compile failed")
+	require.Len(t, modules, 3)
+
+	assert.Equal(t, "a", modules[0].Name)
+	assert.Equal(t, "b", modules[1].Name)
+	assert.Same(t, modules[2], modules[0].Includes["shared"].Module,
+		"included modules must be shared")
+	assert.Same(t, modules[2], modules[1].Includes["shared"].Module,
+		"included modules must be shared")
+
+	t.Run("error", func(t *testing.T) {
+		_, err := CompileFiles([]string{"a.thrift", "missing.thrift"}, Filesystem(fs))
+		assert.ErrorContains(t, err, "missing.thrift")
+	})
+}
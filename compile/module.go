@@ -41,6 +41,14 @@ type Module struct {
 	Types     map[string]TypeSpec
 	Services  map[string]*ServiceSpec
 
+	// Namespaces declared in the Thrift file, keyed by scope. This is nil if
+	// the file does not declare any namespaces.
+	//
+	//   namespace go foo.bar  // {"go": "foo.bar"}
+	//
+	// If a scope is declared more than once, the last declaration wins.
+	Namespaces map[string]string
+
 	Raw []byte // The raw IDL input.
 }
 
@@ -0,0 +1,549 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/gen"
+	"go.uber.org/thriftrw/internal/plugin"
+
+	flags "github.com/jessevdk/go-flags"
+	"go.uber.org/multierr"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigFile is the name of the configuration file that is used if
+// --config is not specified and no Thrift files were provided.
+const defaultConfigFile = "thriftrw.yaml"
+
+// config is the contents of a thriftrw.yaml file.
+//
+//	inputs: ["idl/*.thrift"]
+//	thrift_dirs: [idl/services]
+//	out: gen
+//	options:
+//	  no_zap: true
+//	plugins:
+//	  - name: yarpc
+//	    args: ["--context-import-path=context"]
+//	    options: {sanitize: "true"}
+//	overrides:
+//	  - paths: ["idl/legacy/"]
+//	    out: gen/legacy
+//	    options:
+//	      no_zap: false
+//
+// Relative paths are relative to the directory containing the file.
+type config struct {
+	// Inputs are the Thrift files to generate code for. These may be glob
+	// patterns.
+	Inputs []string `yaml:"inputs"`
+
+	// ThriftDirs are directories whose Thrift files should all be
+	// generated.
+	ThriftDirs []string `yaml:"thrift_dirs"`
+
+	configSettings `yaml:",inline"`
+
+	// Overrides changes settings for specific Thrift files. Later overrides
+	// take precedence over earlier ones. Files with different settings must
+	// be generated into different out directories.
+	Overrides []configOverride `yaml:"overrides"`
+}
+
+// configSettings are the settings that may be changed for specific Thrift
+// files.
+type configSettings struct {
+	Out        string `yaml:"out"`
+	PkgPrefix  string `yaml:"pkg_prefix"`
+	ThriftRoot string `yaml:"thrift_root"`
+	OutputFile string `yaml:"output_file"`
+
+	// Layout, MaxFileSize, and FileNameTemplate are the equivalents of
+	// --layout, --max-file-size, and --file-name-template.
+	Layout           string `yaml:"layout"`
+	MaxFileSize      int    `yaml:"max_file_size"`
+	FileNameTemplate string `yaml:"file_name_template"`
+
+	// CacheFile is the equivalent of --cache-file. Files that use
+	// different settings should use different cache files.
+	CacheFile string `yaml:"cache_file"`
+
+	// Manifest is the equivalent of --manifest. Files that use different
+	// settings must use different manifests.
+	Manifest string `yaml:"manifest"`
+
+	// DepFile is the equivalent of --depfile.
+	DepFile string `yaml:"depfile"`
+
+	// Options are boolean generator options, keyed by the name of their
+	// flag with "_" instead of "-". See configOptionSetters.
+	Options map[string]bool `yaml:"options"`
+
+	// Plugins replaces the list of plugins if non-nil. An empty list
+	// disables all plugins.
+	Plugins []configPlugin `yaml:"plugins"`
+}
+
+// configOverride changes settings for Thrift files matching any of the
+// given paths.
+type configOverride struct {
+	// Paths are slash-separated patterns relative to the directory
+	// containing the configuration, matched with path.Match. Patterns that
+	// end with "/" match all files inside that directory.
+	Paths []string `yaml:"paths"`
+
+	configSettings `yaml:",inline"`
+}
+
+// configPlugin specifies a plugin and its arguments.
+type configPlugin struct {
+	Name    string            `yaml:"name"`
+	Args    []string          `yaml:"args"`
+	Options map[string]string `yaml:"options"`
+}
+
+// configOptionSetters maps the names of options that may be used in a
+// configuration file to their effect on genOptions.
+var configOptionSetters = map[string]func(*genOptions, bool){
+	"no_recurse":               func(o *genOptions, v bool) { o.NoRecurse = v },
+	"go_namespace":             func(o *genOptions, v bool) { o.GoNamespace = v },
+	"no_version_check":         func(o *genOptions, v bool) { o.NoVersionCheck = v },
+	"no_types":                 func(o *genOptions, v bool) { o.NoTypes = v },
+	"no_constants":             func(o *genOptions, v bool) { o.NoConstants = v },
+	"no_service_helpers":       func(o *genOptions, v bool) { o.NoServiceHelpers = v },
+	"no_embed_idl":             func(o *genOptions, v bool) { o.NoEmbedIDL = v },
+	"no_zap":                   func(o *genOptions, v bool) { o.NoZap = v },
+	"enum_text_marshal_strict": func(o *genOptions, v bool) { o.EnumTextMarshalStrict = v },
+	"setters":                  func(o *genOptions, v bool) { o.Setters = v },
+	"generic_ptr":              func(o *genOptions, v bool) { o.GenericPtr = v },
+	"binary_marshaler":         func(o *genOptions, v bool) { o.BinaryMarshaler = v },
+	"reuse_decode":             func(o *genOptions, v bool) { o.ReuseDecode = v },
+	"iterators":                func(o *genOptions, v bool) { o.Iterators = v },
+}
+
+// loadConfig reads a config from the YAML file at the given path.
+func loadConfig(filename string) (*config, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var cfg config
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("parse %q: %w", filename, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %q: %w", filename, err)
+	}
+	return &cfg, nil
+}
+
+func (c *config) validate() error {
+	if len(c.Inputs) == 0 && len(c.ThriftDirs) == 0 {
+		return errors.New("at least one of inputs or thrift_dirs is required")
+	}
+
+	if err := c.configSettings.validate(); err != nil {
+		return err
+	}
+
+	for _, o := range c.Overrides {
+		if len(o.Paths) == 0 {
+			return errors.New("overrides must specify paths")
+		}
+		for _, pattern := range o.Paths {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("bad path pattern %q: %w", pattern, err)
+			}
+		}
+		if err := o.configSettings.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *configSettings) validate() error {
+	switch s.Layout {
+	case "", "single", "definition":
+	default:
+		return fmt.Errorf("unknown layout %q: must be single or definition", s.Layout)
+	}
+
+	for name := range s.Options {
+		if _, ok := configOptionSetters[name]; !ok {
+			return fmt.Errorf("unknown option %q: must be one of %v",
+				name, strings.Join(sortedKeys(configOptionSetters), ", "))
+		}
+	}
+	for _, p := range s.Plugins {
+		if p.Name == "" {
+			return errors.New("plugins must have a name")
+		}
+	}
+	return nil
+}
+
+// merge returns a copy of these settings with the settings that were
+// specified in the override applied.
+func (s configSettings) merge(o configSettings) configSettings {
+	if o.Out != "" {
+		s.Out = o.Out
+	}
+	if o.PkgPrefix != "" {
+		s.PkgPrefix = o.PkgPrefix
+	}
+	if o.ThriftRoot != "" {
+		s.ThriftRoot = o.ThriftRoot
+	}
+	if o.OutputFile != "" {
+		s.OutputFile = o.OutputFile
+	}
+	if o.Layout != "" {
+		s.Layout = o.Layout
+	}
+	if o.MaxFileSize != 0 {
+		s.MaxFileSize = o.MaxFileSize
+	}
+	if o.FileNameTemplate != "" {
+		s.FileNameTemplate = o.FileNameTemplate
+	}
+	if o.CacheFile != "" {
+		s.CacheFile = o.CacheFile
+	}
+	if o.Manifest != "" {
+		s.Manifest = o.Manifest
+	}
+	if o.DepFile != "" {
+		s.DepFile = o.DepFile
+	}
+	if len(o.Options) > 0 {
+		opts := make(map[string]bool, len(s.Options)+len(o.Options))
+		for k, v := range s.Options {
+			opts[k] = v
+		}
+		for k, v := range o.Options {
+			opts[k] = v
+		}
+		s.Options = opts
+	}
+	if o.Plugins != nil {
+		s.Plugins = o.Plugins
+	}
+	return s
+}
+
+// settingsFor returns the settings for the Thrift file at the given path,
+// relative to the directory containing the configuration.
+func (c *config) settingsFor(file string) configSettings {
+	s := c.configSettings
+	file = filepath.ToSlash(file)
+	for _, o := range c.Overrides {
+		if o.matches(file) {
+			s = s.merge(o.configSettings)
+		}
+	}
+	return s
+}
+
+func (o *configOverride) matches(file string) bool {
+	for _, pattern := range o.Paths {
+		if strings.HasSuffix(pattern, "/") {
+			if strings.HasPrefix(file, pattern) {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, file); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// genOptions builds the generator options for these settings. dir is the
+// directory containing the configuration.
+func (s *configSettings) genOptions(dir string) (genOptions, error) {
+	opts := genOptions{
+		OutputDirectory:  resolvePath(dir, s.Out),
+		PackagePrefix:    s.PkgPrefix,
+		OutputFile:       s.OutputFile,
+		Layout:           s.Layout,
+		MaxFileSize:      s.MaxFileSize,
+		FileNameTemplate: s.FileNameTemplate,
+	}
+	if s.ThriftRoot != "" {
+		opts.ThriftRoot = resolvePath(dir, s.ThriftRoot)
+	}
+	if s.CacheFile != "" {
+		opts.CacheFile = resolvePath(dir, s.CacheFile)
+	}
+	if s.Manifest != "" {
+		opts.Manifest = resolvePath(dir, s.Manifest)
+	}
+	if s.DepFile != "" {
+		opts.DepFile = resolvePath(dir, s.DepFile)
+	}
+
+	for name, v := range s.Options {
+		configOptionSetters[name](&opts, v)
+	}
+
+	for _, p := range s.Plugins {
+		f, err := plugin.NewFlag(p.Name, p.Args...)
+		if err != nil {
+			return opts, fmt.Errorf("invalid plugin %q: %v", p.Name, err)
+		}
+		if len(p.Options) > 0 {
+			f.Options = make(map[string]string, len(p.Options))
+			for k, v := range p.Options {
+				f.Options[k] = v
+			}
+		}
+		opts.Plugins = append(opts.Plugins, *f)
+	}
+	return opts, nil
+}
+
+// configTarget is a set of Thrift files that share the same settings.
+type configTarget struct {
+	Settings configSettings
+	Files    []string
+}
+
+// targets groups the Thrift files listed by the configuration by their
+// settings. dir is the directory containing the configuration.
+func (c *config) targets(dir string) ([]*configTarget, error) {
+	inputs := make([]string, len(c.Inputs))
+	for i, in := range c.Inputs {
+		inputs[i] = resolvePath(dir, in)
+	}
+	dirs := make([]string, len(c.ThriftDirs))
+	for i, d := range c.ThriftDirs {
+		dirs[i] = resolvePath(dir, d)
+	}
+
+	files, err := thriftFiles(inputs, dirs)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []*configTarget
+	byKey := make(map[string]*configTarget)
+	for _, f := range files {
+		rel, err := filepath.Rel(dir, f)
+		if err != nil {
+			return nil, err
+		}
+
+		s := c.settingsFor(rel)
+		b, err := json.Marshal(s)
+		if err != nil {
+			return nil, err
+		}
+
+		t, ok := byKey[string(b)]
+		if !ok {
+			t = &configTarget{Settings: s}
+			byKey[string(b)] = t
+			targets = append(targets, t)
+		}
+		t.Files = append(t.Files, f)
+	}
+
+	// Each target generates the Thrift files it includes as well, so
+	// targets that share an output directory would overwrite each other's
+	// code with different settings.
+	outs := make(map[string]struct{})
+	for _, t := range targets {
+		out := resolvePath(dir, t.Settings.Out)
+		if _, ok := outs[out]; ok {
+			return nil, fmt.Errorf("out %q is used by Thrift files with different settings: "+
+				"overrides must specify their own out", t.Settings.Out)
+		}
+		outs[out] = struct{}{}
+	}
+
+	// Targets remove the files listed in their manifest that they don't
+	// generate, so they can't share one.
+	manifests := make(map[string]struct{})
+	for _, t := range targets {
+		if t.Settings.Manifest == "" {
+			continue
+		}
+		m := resolvePath(dir, t.Settings.Manifest)
+		if _, ok := manifests[m]; ok {
+			return nil, fmt.Errorf("manifest %q is used by Thrift files with different settings: "+
+				"overrides must specify their own manifest", t.Settings.Manifest)
+		}
+		manifests[m] = struct{}{}
+	}
+	return targets, nil
+}
+
+// generateFromConfig generates code as described by the configuration file
+// at the given path. cli holds options provided over the command line that
+// apply to all targets.
+func generateFromConfig(filename string, cli genOptions) error {
+	filename, err := filepath.Abs(filename)
+	if err != nil {
+		return fmt.Errorf("Unable to resolve absolute path for %q: %v", filename, err)
+	}
+
+	cfg, err := loadConfig(filename)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(filename)
+	targets, err := cfg.targets(dir)
+	if err != nil {
+		return fmt.Errorf("invalid config %q: %v", filename, err)
+	}
+
+	// Packages are placed based on the paths of Thrift files relative to
+	// the Thrift root, so targets must agree on it for their imports of
+	// each other's files to match.
+	thriftRoot, err := configThriftRoot(targets)
+	if err != nil {
+		return err
+	}
+
+	var checkErrs error
+	for _, t := range targets {
+		gopts, err := t.Settings.genOptions(dir)
+		if err != nil {
+			return fmt.Errorf("invalid config %q: %v", filename, err)
+		}
+		if gopts.ThriftRoot == "" {
+			gopts.ThriftRoot = thriftRoot
+		}
+		gopts.PluginTimeout = cli.PluginTimeout
+		gopts.Check = cli.Check
+		gopts.Diff = cli.Diff
+
+		err = generate(gopts, t.Files)
+
+		// Report out of date code for all targets with --check.
+		var checkErr *gen.CheckError
+		if errors.As(err, &checkErr) {
+			checkErrs = multierr.Append(checkErrs,
+				fmt.Errorf("%v: %w", gopts.OutputDirectory, err))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return checkErrs
+}
+
+// configThriftRoot returns the closest common parent directory of the Thrift
+// files of all targets and the files they include, or an empty string if
+// all targets specify their own thrift_root.
+func configThriftRoot(targets []*configTarget) (string, error) {
+	var (
+		files     []string
+		needsRoot bool
+	)
+	for _, t := range targets {
+		files = append(files, t.Files...)
+		needsRoot = needsRoot || t.Settings.ThriftRoot == ""
+	}
+	if !needsRoot {
+		return "", nil
+	}
+
+	modules, err := compile.CompileFiles(files)
+	if err != nil {
+		return "", fmt.Errorf("Failed to compile Thrift files: %+v", err)
+	}
+	root, err := findCommonAncestor(modules...)
+	if err != nil {
+		return "", fmt.Errorf(
+			"Could not find a common parent directory for the Thrift files in the "+
+				"configuration and the Thrift files imported by them.\nUse thrift_root "+
+				"to provide this path.\n\t%v", err)
+	}
+	return root, nil
+}
+
+// findConfig returns the path to the closest thriftrw.yaml in the current
+// directory or its parents, or an empty string if there isn't one.
+func findConfig() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	return findUp(dir, defaultConfigFile)
+}
+
+// checkConfigFlags verifies that generator options that are configured in
+// the configuration file were not provided over the command line.
+func checkConfigFlags(parser *flags.Parser) error {
+	group := parser.Group.Find("Generator Options")
+	if group == nil {
+		return nil
+	}
+
+	for _, opt := range group.Options() {
+		if !opt.IsSet() {
+			continue
+		}
+		switch opt.LongName {
+		case "plugin-timeout", "check", "diff":
+			// Applies to all targets.
+		default:
+			return fmt.Errorf("--%v cannot be used with a configuration file", opt.LongName)
+		}
+	}
+	return nil
+}
+
+// resolvePath resolves p relative to dir unless it's absolute.
+func resolvePath(dir, p string) string {
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(dir, p)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
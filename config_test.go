@@ -0,0 +1,374 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, dir, body string) string {
+	t.Helper()
+	p := filepath.Join(dir, defaultConfigFile)
+	require.NoError(t, os.WriteFile(p, []byte(body), 0o644))
+	return p
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    string
+		wantErr string
+	}{
+		{
+			desc:    "unknown field",
+			give:    "inputs: [a.thrift]\nfoo: bar\n",
+			wantErr: "field foo not found",
+		},
+		{
+			desc:    "no inputs",
+			give:    "out: gen\n",
+			wantErr: "at least one of inputs or thrift_dirs is required",
+		},
+		{
+			desc:    "unknown option",
+			give:    "inputs: [a.thrift]\noptions:\n  no_foo: true\n",
+			wantErr: `unknown option "no_foo"`,
+		},
+		{
+			desc:    "unknown option in override",
+			give:    "inputs: [a.thrift]\noverrides:\n  - paths: [a.thrift]\n    options:\n      no_foo: true\n",
+			wantErr: `unknown option "no_foo"`,
+		},
+		{
+			desc:    "override without paths",
+			give:    "inputs: [a.thrift]\noverrides:\n  - out: gen\n",
+			wantErr: "overrides must specify paths",
+		},
+		{
+			desc:    "bad pattern",
+			give:    "inputs: [a.thrift]\noverrides:\n  - paths: ['[']\n",
+			wantErr: `bad path pattern "["`,
+		},
+		{
+			desc:    "unknown layout",
+			give:    "inputs: [a.thrift]\nlayout: files\n",
+			wantErr: `unknown layout "files"`,
+		},
+		{
+			desc:    "plugin without name",
+			give:    "inputs: [a.thrift]\nplugins:\n  - args: [--foo]\n",
+			wantErr: "plugins must have a name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := loadConfig(writeConfig(t, t.TempDir(), tt.give))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestConfigSettingsFor(t *testing.T) {
+	cfg := config{
+		Inputs: []string{"idl"},
+		configSettings: configSettings{
+			Out:       "gen",
+			PkgPrefix: "example.com/gen",
+			Options:   map[string]bool{"no_zap": true, "setters": true},
+		},
+		Overrides: []configOverride{
+			{
+				Paths: []string{"idl/legacy/"},
+				configSettings: configSettings{
+					Out:     "legacy",
+					Options: map[string]bool{"no_zap": false},
+					Plugins: []configPlugin{},
+				},
+			},
+			{
+				Paths:          []string{"idl/legacy/*_test.thrift", "idl/x.thrift"},
+				configSettings: configSettings{PkgPrefix: "example.com/other"},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc string
+		file string
+		want configSettings
+	}{
+		{
+			desc: "no overrides",
+			file: "idl/a.thrift",
+			want: cfg.configSettings,
+		},
+		{
+			desc: "directory override",
+			file: "idl/legacy/a.thrift",
+			want: configSettings{
+				Out:       "legacy",
+				PkgPrefix: "example.com/gen",
+				Options:   map[string]bool{"no_zap": false, "setters": true},
+				Plugins:   []configPlugin{},
+			},
+		},
+		{
+			desc: "multiple overrides",
+			file: "idl/legacy/a_test.thrift",
+			want: configSettings{
+				Out:       "legacy",
+				PkgPrefix: "example.com/other",
+				Options:   map[string]bool{"no_zap": false, "setters": true},
+				Plugins:   []configPlugin{},
+			},
+		},
+		{
+			desc: "glob does not match nested directories",
+			file: "idl/legacy/sub/a_test.thrift",
+			want: configSettings{
+				Out:       "legacy",
+				PkgPrefix: "example.com/gen",
+				Options:   map[string]bool{"no_zap": false, "setters": true},
+				Plugins:   []configPlugin{},
+			},
+		},
+		{
+			desc: "exact match",
+			file: "idl/x.thrift",
+			want: configSettings{
+				Out:       "gen",
+				PkgPrefix: "example.com/other",
+				Options:   map[string]bool{"no_zap": true, "setters": true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, cfg.settingsFor(filepath.FromSlash(tt.file)))
+		})
+	}
+
+	// Merging must not modify the base settings.
+	assert.Equal(t, map[string]bool{"no_zap": true, "setters": true}, cfg.Options)
+}
+
+func TestConfigGenOptions(t *testing.T) {
+	dir := t.TempDir()
+	s := configSettings{
+		Out:        "gen",
+		PkgPrefix:  "example.com/gen",
+		ThriftRoot: "idl",
+		CacheFile:  "gen/cache.json",
+		Layout:     "definition",
+		Options:    map[string]bool{"no_zap": true, "go_namespace": true},
+		Plugins: []configPlugin{
+			{Name: "foo", Args: []string{"--bar"}},
+		},
+	}
+
+	_, err := s.genOptions(dir)
+	require.Error(t, err, "plugin foo should not exist")
+	assert.Contains(t, err.Error(), `invalid plugin "foo"`)
+
+	s.Plugins = nil
+	opts, err := s.genOptions(dir)
+	require.NoError(t, err)
+	assert.Equal(t, genOptions{
+		OutputDirectory: filepath.Join(dir, "gen"),
+		PackagePrefix:   "example.com/gen",
+		ThriftRoot:      filepath.Join(dir, "idl"),
+		CacheFile:       filepath.Join(dir, "gen/cache.json"),
+		Layout:          "definition",
+		NoZap:           true,
+		GoNamespace:     true,
+	}, opts)
+}
+
+func TestConfigTargets(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"idl/a.thrift", "idl/b.thrift", "idl/legacy/c.thrift", "extra/d.thrift"} {
+		p := filepath.Join(dir, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, nil, 0o644))
+	}
+
+	cfg, err := loadConfig(writeConfig(t, dir, `
+inputs: [extra/*.thrift]
+thrift_dirs: [idl]
+out: gen
+overrides:
+  - paths: [idl/legacy/]
+    out: legacy
+  - paths: [extra/d.thrift]
+    out: gen
+`))
+	require.NoError(t, err)
+
+	targets, err := cfg.targets(dir)
+	require.NoError(t, err)
+	require.Len(t, targets, 2)
+
+	join := func(p string) string { return filepath.Join(dir, filepath.FromSlash(p)) }
+	assert.Equal(t, "gen", targets[0].Settings.Out)
+	assert.Equal(t, []string{join("extra/d.thrift"), join("idl/a.thrift"), join("idl/b.thrift")}, targets[0].Files)
+	assert.Equal(t, "legacy", targets[1].Settings.Out)
+	assert.Equal(t, []string{join("idl/legacy/c.thrift")}, targets[1].Files)
+}
+
+func TestCheckConfigFlags(t *testing.T) {
+	tests := []struct {
+		desc    string
+		args    []string
+		wantErr string
+	}{
+		{desc: "none"},
+		{desc: "plugin timeout", args: []string{"--plugin-timeout=1s"}},
+		{desc: "config only", args: []string{"--config=foo.yaml"}},
+		{desc: "check", args: []string{"--check"}},
+		{desc: "diff", args: []string{"--diff"}},
+		{
+			desc:    "generator option",
+			args:    []string{"--no-zap"},
+			wantErr: "--no-zap cannot be used with a configuration file",
+		},
+		{
+			desc:    "output directory",
+			args:    []string{"--out=gen"},
+			wantErr: "--out cannot be used with a configuration file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var opts options
+			parser := flags.NewParser(&opts, flags.Default&^flags.PrintErrors)
+			_, err := parser.ParseArgs(tt.args)
+			require.NoError(t, err)
+
+			err = checkConfigFlags(parser)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigTargetsSharedManifest(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"idl/a.thrift", "idl/legacy/b.thrift"} {
+		p := filepath.Join(dir, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, nil, 0o644))
+	}
+
+	cfg, err := loadConfig(writeConfig(t, dir, `
+thrift_dirs: [idl]
+out: gen
+manifest: gen/manifest.json
+overrides:
+  - paths: [idl/legacy/]
+    out: legacy
+`))
+	require.NoError(t, err)
+
+	_, err = cfg.targets(dir)
+	assert.EqualError(t, err, `manifest "gen/manifest.json" is used by Thrift files with different settings: `+
+		"overrides must specify their own manifest")
+
+	cfg.Overrides[0].Manifest = "legacy/manifest.json"
+	targets, err := cfg.targets(dir)
+	require.NoError(t, err)
+	assert.Len(t, targets, 2)
+}
+
+func TestConfigTargetsSharedOut(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"idl/a.thrift", "idl/legacy/b.thrift"} {
+		p := filepath.Join(dir, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, nil, 0o644))
+	}
+
+	cfg, err := loadConfig(writeConfig(t, dir, `
+thrift_dirs: [idl]
+out: gen
+overrides:
+  - paths: [idl/legacy/]
+    options: {no_zap: true}
+`))
+	require.NoError(t, err)
+
+	_, err = cfg.targets(dir)
+	assert.EqualError(t, err, `out "gen" is used by Thrift files with different settings: `+
+		"overrides must specify their own out")
+}
+
+func TestGenerateFromConfigIncludedOverride(t *testing.T) {
+	dir := t.TempDir()
+	for f, body := range map[string]string{
+		"idl/a.thrift":        "include \"./legacy/b.thrift\"\nstruct A { 1: optional b.B b }\n",
+		"idl/legacy/b.thrift": "struct B { 1: optional string s }\n",
+	} {
+		p := filepath.Join(dir, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(body), 0o644))
+	}
+
+	cfgFile := writeConfig(t, dir, `
+thrift_dirs: [idl]
+out: gen
+pkg_prefix: example.com/gen
+options: {no_embed_idl: true}
+overrides:
+  - paths: [idl/legacy/]
+    out: gen/legacy
+    pkg_prefix: example.com/gen/legacy
+    options: {no_zap: true}
+`)
+	require.NoError(t, generateFromConfig(cfgFile, genOptions{}))
+
+	read := func(p string) string {
+		b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(p)))
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	// Both targets place packages relative to idl, rather than the
+	// legacy target using idl/legacy.
+	assert.Contains(t, read("gen/a/a.go"), `"example.com/gen/legacy/b"`)
+	assert.Contains(t, read("gen/legacy/b/b.go"), "MarshalLogObject",
+		"b must be generated with the settings of a as its dependency")
+	assert.NotContains(t, read("gen/legacy/legacy/b/b.go"), "MarshalLogObject",
+		"b must be generated with the override's settings")
+	assert.NoDirExists(t, filepath.Join(dir, "gen/b"))
+	assert.NoDirExists(t, filepath.Join(dir, "gen/legacy/b/b"))
+}
@@ -24,7 +24,7 @@ import (
 	"fmt"
 	"io"
 
-	"go.uber.org/thriftrw/internal/envelope/exception"
+	"go.uber.org/thriftrw/envelope/exception"
 	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
 )
@@ -52,6 +52,9 @@ func Write(p protocol.Protocol, w io.Writer, seqID int32, e Enveloper) error {
 }
 
 // ReadReply reads enveloped responses from the given reader.
+//
+// If the server responded with an exception envelope, the returned error is
+// an *exception.TApplicationException.
 func ReadReply(p protocol.Protocol, r io.ReaderAt) (_ wire.Value, seqID int32, _ error) {
 	envelope, err := p.DecodeEnveloped(r)
 	if err != nil {
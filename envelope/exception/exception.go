@@ -672,7 +672,7 @@ func (v *TApplicationException) Error() string {
 // ThriftModule represents the IDL file used to generate this package.
 var ThriftModule = &thriftreflect.ThriftModule{
 	Name:     "exception",
-	Package:  "go.uber.org/thriftrw/internal/envelope/exception",
+	Package:  "go.uber.org/thriftrw/envelope/exception",
 	FilePath: "exception.thrift",
 	SHA1:     "88105bcd404d4aee06542af9452f7cf76647ae98",
 	Raw:      rawIDL,
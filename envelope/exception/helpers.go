@@ -0,0 +1,123 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package exception provides TApplicationException, the exception sent in
+// place of a response when a server fails to process a request, and its
+// standard exception types.
+//
+// Clients receive these exceptions as errors from envelope.ReadReply.
+// Servers build them with the constructors in this package and send them
+// with Response.
+package exception
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/thriftrw/protocol/stream"
+	"go.uber.org/thriftrw/wire"
+)
+
+// New builds a TApplicationException with the given type and message.
+func New(typ ExceptionType, message string) *TApplicationException {
+	return &TApplicationException{
+		Message: &message,
+		Type:    &typ,
+	}
+}
+
+// NewUnknownMethod builds an exception for a request to a method that the
+// server does not implement.
+func NewUnknownMethod(method string) *TApplicationException {
+	return New(ExceptionTypeUnknownMethod, fmt.Sprintf("unknown method %q", method))
+}
+
+// NewInvalidMessageType builds an exception for a request with an envelope
+// type other than Call or OneWay.
+func NewInvalidMessageType(got wire.EnvelopeType) *TApplicationException {
+	return New(ExceptionTypeInvalidMessageType, fmt.Sprintf("invalid message type %v", got))
+}
+
+// NewWrongMethodName builds an exception for a response to a method other
+// than the one that was called.
+func NewWrongMethodName(want, got string) *TApplicationException {
+	return New(ExceptionTypeWrongMethodName,
+		fmt.Sprintf("wrong method name: expected %q, got %q", want, got))
+}
+
+// NewBadSequenceID builds an exception for a response whose sequence ID does
+// not match that of the request.
+func NewBadSequenceID(want, got int32) *TApplicationException {
+	return New(ExceptionTypeBadSequenceID,
+		fmt.Sprintf("bad sequence ID: expected %d, got %d", want, got))
+}
+
+// NewMissingResult builds an exception for a response to the given method
+// that holds neither a result nor an exception.
+func NewMissingResult(method string) *TApplicationException {
+	return New(ExceptionTypeMissingResult, fmt.Sprintf("%v failed: unknown result", method))
+}
+
+// NewInternalError builds an exception for a server that failed to process a
+// request with the given error.
+func NewInternalError(err error) *TApplicationException {
+	return New(ExceptionTypeInternalError, err.Error())
+}
+
+// NewProtocolError builds an exception for a request that could not be
+// decoded because of the given error.
+func NewProtocolError(err error) *TApplicationException {
+	return New(ExceptionTypeProtocolError, err.Error())
+}
+
+// TypeOf returns the type of the TApplicationException in the given error's
+// chain. It returns false if the error does not wrap a TApplicationException.
+func TypeOf(err error) (ExceptionType, bool) {
+	var ex *TApplicationException
+	if !errors.As(err, &ex) {
+		return 0, false
+	}
+	return ex.GetType(), true
+}
+
+// Response is a TApplicationException sent in response to a call to the
+// given method.
+//
+// It implements envelope.Enveloper and stream.Enveloper so that it can be
+// written with envelope.Write, or with the WriteResponse method of a
+// stream.ResponseWriter.
+type Response struct {
+	Method    string
+	Exception *TApplicationException
+}
+
+var _ stream.Enveloper = Response{}
+
+// MethodName is the name of the method that failed.
+func (r Response) MethodName() string { return r.Method }
+
+// EnvelopeType is always wire.Exception.
+func (Response) EnvelopeType() wire.EnvelopeType { return wire.Exception }
+
+// ToWire serializes the exception into a wire.Value.
+func (r Response) ToWire() (wire.Value, error) { return r.Exception.ToWire() }
+
+// Encode writes the exception into the given stream.Writer.
+func (r Response) Encode(sw stream.Writer) error { return r.Exception.Encode(sw) }
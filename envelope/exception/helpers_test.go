@@ -0,0 +1,141 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exception_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/thriftrw/envelope"
+	"go.uber.org/thriftrw/envelope/exception"
+	"go.uber.org/thriftrw/protocol/binary"
+	"go.uber.org/thriftrw/wire"
+)
+
+func TestConstructors(t *testing.T) {
+	tests := []struct {
+		desc     string
+		give     *exception.TApplicationException
+		wantType exception.ExceptionType
+		wantMsg  string
+	}{
+		{
+			desc:     "New",
+			give:     exception.New(exception.ExceptionTypeInvalidTransform, "great sadness"),
+			wantType: exception.ExceptionTypeInvalidTransform,
+			wantMsg:  "great sadness",
+		},
+		{
+			desc:     "UnknownMethod",
+			give:     exception.NewUnknownMethod("getValue"),
+			wantType: exception.ExceptionTypeUnknownMethod,
+			wantMsg:  `unknown method "getValue"`,
+		},
+		{
+			desc:     "InvalidMessageType",
+			give:     exception.NewInvalidMessageType(wire.Reply),
+			wantType: exception.ExceptionTypeInvalidMessageType,
+			wantMsg:  "invalid message type Reply",
+		},
+		{
+			desc:     "WrongMethodName",
+			give:     exception.NewWrongMethodName("getValue", "setValue"),
+			wantType: exception.ExceptionTypeWrongMethodName,
+			wantMsg:  `wrong method name: expected "getValue", got "setValue"`,
+		},
+		{
+			desc:     "BadSequenceID",
+			give:     exception.NewBadSequenceID(1, 2),
+			wantType: exception.ExceptionTypeBadSequenceID,
+			wantMsg:  "bad sequence ID: expected 1, got 2",
+		},
+		{
+			desc:     "MissingResult",
+			give:     exception.NewMissingResult("getValue"),
+			wantType: exception.ExceptionTypeMissingResult,
+			wantMsg:  "getValue failed: unknown result",
+		},
+		{
+			desc:     "InternalError",
+			give:     exception.NewInternalError(errors.New("great sadness")),
+			wantType: exception.ExceptionTypeInternalError,
+			wantMsg:  "great sadness",
+		},
+		{
+			desc:     "ProtocolError",
+			give:     exception.NewProtocolError(errors.New("unexpected EOF")),
+			wantType: exception.ExceptionTypeProtocolError,
+			wantMsg:  "unexpected EOF",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.wantType, tt.give.GetType())
+			assert.Equal(t, tt.wantMsg, tt.give.GetMessage())
+		})
+	}
+}
+
+func TestTypeOf(t *testing.T) {
+	typ, ok := exception.TypeOf(fmt.Errorf("call failed: %w", exception.NewUnknownMethod("foo")))
+	assert.True(t, ok)
+	assert.Equal(t, exception.ExceptionTypeUnknownMethod, typ)
+
+	_, ok = exception.TypeOf(errors.New("great sadness"))
+	assert.False(t, ok)
+
+	_, ok = exception.TypeOf(nil)
+	assert.False(t, ok)
+}
+
+func TestResponse(t *testing.T) {
+	res := exception.Response{
+		Method:    "getValue",
+		Exception: exception.NewUnknownMethod("getValue"),
+	}
+
+	check := func(t *testing.T, bs []byte) {
+		_, seqID, err := envelope.ReadReply(binary.Default, bytes.NewReader(bs))
+		assert.Equal(t, int32(42), seqID)
+
+		var ex *exception.TApplicationException
+		require.ErrorAs(t, err, &ex)
+		assert.True(t, res.Exception.Equals(ex), "exception mismatch: %v", ex)
+	}
+
+	t.Run("Write", func(t *testing.T) {
+		var buff bytes.Buffer
+		require.NoError(t, envelope.Write(binary.Default, &buff, 42, res))
+		check(t, buff.Bytes())
+	})
+
+	t.Run("WriteResponse", func(t *testing.T) {
+		var buff bytes.Buffer
+		responder := binary.EnvelopeV1Responder{Name: res.MethodName(), SeqID: 42}
+		require.NoError(t, responder.WriteResponse(res.EnvelopeType(), &buff, res))
+		check(t, buff.Bytes())
+	})
+}
@@ -18,4 +18,67 @@
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
+// Package stream provides streaming equivalents of the helpers in the
+// envelope package. Values are written to a stream.Writer and read from a
+// stream.Reader directly, without building intermediate wire.Value trees.
 package stream
+
+import (
+	"fmt"
+
+	"go.uber.org/thriftrw/envelope/exception"
+	"go.uber.org/thriftrw/protocol/stream"
+	"go.uber.org/thriftrw/wire"
+)
+
+// Write writes an Envelope to the given stream.Writer.
+func Write(sw stream.Writer, seqID int32, e stream.Enveloper) error {
+	err := sw.WriteEnvelopeBegin(stream.EnvelopeHeader{
+		Name:  e.MethodName(),
+		Type:  e.EnvelopeType(),
+		SeqID: seqID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := e.Encode(sw); err != nil {
+		return err
+	}
+
+	return sw.WriteEnvelopeEnd()
+}
+
+// ReadReply reads an enveloped response from the given stream.Reader,
+// decoding its body into the given BodyReader.
+//
+// If the response is an exception envelope, the body is left untouched and
+// the *exception.TApplicationException sent by the server is returned as the
+// error.
+func ReadReply(sr stream.Reader, body stream.BodyReader) (seqID int32, _ error) {
+	eh, err := sr.ReadEnvelopeBegin()
+	if err != nil {
+		return 0, err
+	}
+
+	switch eh.Type {
+	case wire.Reply:
+		if err := body.Decode(sr); err != nil {
+			return eh.SeqID, err
+		}
+	case wire.Exception:
+		// Decode the exception payload.
+		ex := &exception.TApplicationException{}
+		if err := ex.Decode(sr); err != nil {
+			return eh.SeqID, fmt.Errorf("failed to decode exception: %v", err)
+		}
+		if err := sr.ReadEnvelopeEnd(); err != nil {
+			return eh.SeqID, err
+		}
+		return eh.SeqID, ex
+	default:
+		return eh.SeqID, fmt.Errorf("unknown envelope type for reply, got %v", eh.Type)
+	}
+
+	return eh.SeqID, sr.ReadEnvelopeEnd()
+}
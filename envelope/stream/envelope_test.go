@@ -0,0 +1,247 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package stream_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	. "go.uber.org/thriftrw/envelope/stream"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/thriftrw/protocol/binary"
+	"go.uber.org/thriftrw/protocol/stream"
+	"go.uber.org/thriftrw/wire"
+)
+
+// getValueArgs represents the arguments of a method,
+//
+//	getValue(1: string key)
+type getValueArgs struct {
+	Key string
+	Err error
+}
+
+var (
+	_ stream.Enveloper  = getValueArgs{}
+	_ stream.BodyReader = (*getValueArgs)(nil)
+)
+
+func (getValueArgs) MethodName() string { return "getValue" }
+
+func (getValueArgs) EnvelopeType() wire.EnvelopeType { return wire.Call }
+
+func (a getValueArgs) Encode(sw stream.Writer) error {
+	if a.Err != nil {
+		return a.Err
+	}
+	if err := sw.WriteStructBegin(); err != nil {
+		return err
+	}
+	if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 1, Type: wire.TBinary}); err != nil {
+		return err
+	}
+	if err := sw.WriteString(a.Key); err != nil {
+		return err
+	}
+	if err := sw.WriteFieldEnd(); err != nil {
+		return err
+	}
+	return sw.WriteStructEnd()
+}
+
+func (a *getValueArgs) Decode(sr stream.Reader) error {
+	if err := sr.ReadStructBegin(); err != nil {
+		return err
+	}
+	for {
+		fh, ok, err := sr.ReadFieldBegin()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		if fh.ID == 1 && fh.Type == wire.TBinary {
+			a.Key, err = sr.ReadString()
+		} else {
+			err = sr.Skip(fh.Type)
+		}
+		if err != nil {
+			return err
+		}
+		if err := sr.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	return sr.ReadStructEnd()
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var buff bytes.Buffer
+		sw := binary.NewStreamWriter(&buff)
+		require.NoError(t, Write(sw, 1234, getValueArgs{Key: "foo"}))
+		require.NoError(t, sw.Close())
+
+		assert.Equal(t,
+			[]byte{
+				0x80, 0x01, 0x00, 0x01, // version|type:4 = 1 | call
+				0x00, 0x00, 0x00, 0x08, // name length = 8
+				'g', 'e', 't', 'V', 'a', 'l', 'u', 'e', // "getValue"
+				0x00, 0x00, 0x04, 0xd2, // seqID:4 = 1234
+
+				// <struct>
+				0x0b,       // type:1 = string
+				0x00, 0x01, // id:2 = 1
+				0x00, 0x00, 0x00, 0x03, // length = 3
+				'f', 'o', 'o', // "foo"
+				0x00, // stop
+			}, buff.Bytes())
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		var buff bytes.Buffer
+		sw := binary.NewStreamWriter(&buff)
+		defer sw.Close()
+
+		err := Write(sw, 1234, getValueArgs{Err: errors.New("great sadness")})
+		assert.EqualError(t, err, "great sadness")
+	})
+}
+
+func TestReadReply(t *testing.T) {
+	tests := []struct {
+		desc      string
+		bs        []byte
+		want      getValueArgs
+		wantSeqID int32
+		wantErr   string
+	}{
+		{
+			desc:    "Invalid envelope",
+			bs:      []byte{0},
+			wantErr: "unexpected EOF",
+		},
+		{
+			desc: "Unexpected envelope type",
+			bs: []byte{
+				0x80, 0x01, 0x00, 0x01, // version|type:4 = 1 | call
+				0x00, 0x00, 0x00, 0x03, // name length = 3
+				'a', 'b', 'c', // "abc"
+				0x00, 0x00, 0x04, 0xd2, // seqID:4 = 1234
+
+				// <struct>
+				0x00, // stop
+			},
+			wantSeqID: 1234,
+			wantErr:   "unknown envelope type for reply, got Call",
+		},
+		{
+			desc: "Valid reply",
+			bs: []byte{
+				0x80, 0x01, 0x00, 0x02, // version|type:4 = 2 | reply
+				0x00, 0x00, 0x00, 0x03, // name length = 3
+				'a', 'b', 'c', // "abc"
+				0x00, 0x00, 0x04, 0xd2, // seqID:4 = 1234
+
+				// <struct>
+				0x0b,       // type:1 = string
+				0x00, 0x01, // id:2 = 1
+				0x00, 0x00, 0x00, 0x03, // length = 3
+				'f', 'o', 'o', // "foo"
+				0x00, // stop
+			},
+			want:      getValueArgs{Key: "foo"},
+			wantSeqID: 1234,
+		},
+		{
+			desc: "Invalid reply",
+			bs: []byte{
+				0x80, 0x01, 0x00, 0x02, // version|type:4 = 2 | reply
+				0x00, 0x00, 0x00, 0x03, // name length = 3
+				'a', 'b', 'c', // "abc"
+				0x00, 0x00, 0x04, 0xd2, // seqID:4 = 1234
+
+				// <struct> (truncated)
+				0x0b,       // type:1 = string
+				0x00, 0x01, // id:2 = 1
+			},
+			wantSeqID: 1234,
+			wantErr:   "unexpected EOF",
+		},
+		{
+			desc: "Invalid exception",
+			bs: []byte{
+				0x80, 0x01, 0x00, 0x03, // version|type:4 = 3 | exception
+				0x00, 0x00, 0x00, 0x03, // name length = 3
+				'a', 'b', 'c', // "abc"
+				0x00, 0x00, 0x04, 0xd2, // seqID:4 = 1234
+
+				// <struct> (truncated)
+				0x0b,       // type:1 = string
+				0x00, 0x01, // id:2 = 1
+			},
+			wantSeqID: 1234,
+			wantErr:   "failed to decode exception: unexpected EOF",
+		},
+		{
+			desc: "Valid exception",
+			bs: []byte{
+				0x80, 0x01, 0x00, 0x03, // version|type:4 = 3 | exception
+				0x00, 0x00, 0x00, 0x03, // name length = 3
+				'a', 'b', 'c', // "abc"
+				0x00, 0x00, 0x04, 0xd2, // seqID:4 = 1234
+
+				// <struct>
+				0x0b,       // type:1 = string
+				0x00, 0x01, // id:2 = 1
+				0x00, 0x00, 0x00, 0x06, // length = 6
+				'e', 'r', 'r', 'M', 's', 'g', // "errMsg"
+				0x08,       // type:1 = i32
+				0x00, 0x02, // id:2 = 2
+				0x00, 0x00, 0x00, 0x01, // value = 1 (unknown method)
+				0x00, // stop
+			},
+			wantSeqID: 1234,
+			wantErr:   "TApplicationException{Message: errMsg, Type: UNKNOWN_METHOD}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			sr := binary.NewStreamReader(bytes.NewReader(tt.bs))
+			defer sr.Close()
+
+			var got getValueArgs
+			seqID, err := ReadReply(sr, &got)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantSeqID, seqID)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	tc "go.uber.org/thriftrw/gen/internal/tests/containers"
+	trd "go.uber.org/thriftrw/gen/internal/tests/reusedecode"
 	ts "go.uber.org/thriftrw/gen/internal/tests/structs"
 	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/protocol/binary"
@@ -196,6 +197,47 @@ func (*benchSuite) BenchmarkStreamDecode(b *testing.B, give thriftType) {
 	}
 }
 
+func BenchmarkReuseDecode(b *testing.B) {
+	give := &trd.Batch{
+		Items: []*trd.Item{
+			{Name: "foo", Tags: []string{"a", "b"}},
+			{Name: "bar"},
+		},
+		Counts:  map[string]int64{"x": 1, "y": 2},
+		Labels:  map[string]struct{}{"l": {}},
+		Primary: &trd.Item{Name: "primary"},
+		Origin:  &ts.Point{X: 1, Y: 2},
+		Blobs:   [][]byte{[]byte("hello")},
+		History: trd.Items{{Name: "old"}},
+	}
+
+	var buf bytes.Buffer
+	sw := binary.Default.Writer(&buf)
+	require.NoError(b, give.Encode(sw))
+	require.NoError(b, sw.Close())
+	bs := buf.Bytes()
+
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sr := binary.Default.Reader(bytes.NewReader(bs))
+			var v trd.Batch
+			require.NoError(b, v.Decode(sr))
+			require.NoError(b, sr.Close())
+		}
+	})
+
+	b.Run("Reuse", func(b *testing.B) {
+		var v trd.Batch
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sr := binary.NewAliasingStreamReader(bs)
+			require.NoError(b, v.Decode(sr))
+			require.NoError(b, sr.Close())
+		}
+	})
+}
+
 // Generates a slice representing the range [from, to).
 func int32range(from, to int32) []int32 {
 	if from > to {
@@ -0,0 +1,143 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"bytes"
+	"encoding"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	tbm "go.uber.org/thriftrw/gen/internal/tests/binarymarshaler"
+	"go.uber.org/thriftrw/protocol/binary"
+	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*tbm.User)(nil)
+	_ encoding.BinaryUnmarshaler = (*tbm.User)(nil)
+	_ encoding.BinaryMarshaler   = (*tbm.Contact)(nil)
+	_ encoding.BinaryUnmarshaler = (*tbm.NotFoundError)(nil)
+)
+
+func TestBinaryMarshalerRoundTrip(t *testing.T) {
+	tests := []struct {
+		desc string
+		give interface {
+			encoding.BinaryMarshaler
+			ToWire() (wire.Value, error)
+		}
+		newValue func() encoding.BinaryUnmarshaler
+	}{
+		{
+			desc: "struct",
+			give: &tbm.User{
+				Name:    "foo",
+				Address: &tbm.Address{Street: "1 Main St", City: ptr.String("SF")},
+				Tags:    []string{"a", "b"},
+				Scores:  map[string]int64{"x": 1},
+			},
+			newValue: func() encoding.BinaryUnmarshaler { return new(tbm.User) },
+		},
+		{
+			desc:     "union",
+			give:     &tbm.Contact{Email: ptr.String("foo@example.com")},
+			newValue: func() encoding.BinaryUnmarshaler { return new(tbm.Contact) },
+		},
+		{
+			desc:     "exception",
+			give:     &tbm.NotFoundError{Message: ptr.String("not found")},
+			newValue: func() encoding.BinaryUnmarshaler { return new(tbm.NotFoundError) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := tt.give.MarshalBinary()
+			require.NoError(t, err)
+
+			// The output must match what the non-streaming protocol would
+			// produce.
+			w, err := tt.give.ToWire()
+			require.NoError(t, err)
+			var buf bytes.Buffer
+			require.NoError(t, binary.Default.Encode(w, &buf))
+			assert.Equal(t, buf.Bytes(), b)
+
+			got := tt.newValue()
+			require.NoError(t, got.UnmarshalBinary(b))
+			assert.Equal(t, tt.give, got)
+		})
+	}
+}
+
+func TestBinaryMarshalerThrift(t *testing.T) {
+	give := &tbm.Address{Street: "1 Main St"}
+	b, err := give.MarshalThrift(binary.Default)
+	require.NoError(t, err)
+
+	var got tbm.Address
+	require.NoError(t, got.UnmarshalThrift(binary.Default, b))
+	assert.Equal(t, give, &got)
+}
+
+func TestBinaryMarshalerErrors(t *testing.T) {
+	t.Run("encode", func(t *testing.T) {
+		_, err := (&tbm.Contact{}).MarshalBinary()
+		assert.ErrorContains(t, err, "Contact should have exactly one field")
+	})
+
+	t.Run("decode", func(t *testing.T) {
+		var u tbm.User
+		err := u.UnmarshalBinary([]byte{0x0b, 0x00})
+		assert.Error(t, err)
+	})
+}
+
+func TestBinaryMarshalerReservedFieldName(t *testing.T) {
+	spec := &compile.StructSpec{
+		Name: "Foo",
+		Fields: compile.FieldGroup{
+			{ID: 1, Name: "marshalBinary", Type: &compile.StringSpec{}, Required: true},
+		},
+	}
+	g := NewGenerator(&GeneratorOptions{
+		Importer:        thriftPackageImporter{},
+		ImportPath:      "go.uber.org/thriftrw/gen",
+		PackageName:     "gen",
+		BinaryMarshaler: true,
+	})
+	err := TypeDefinition(g, spec)
+	assert.ErrorContains(t, err, `could not declare field "MarshalBinary" (from "marshalBinary")`)
+	assert.ErrorContains(t, err, `"MarshalBinary" is a reserved ThriftRW identifier`)
+
+	g = NewGenerator(&GeneratorOptions{
+		Importer:    thriftPackageImporter{},
+		ImportPath:  "go.uber.org/thriftrw/gen",
+		PackageName: "gen",
+	})
+	assert.NoError(t, TypeDefinition(g, spec),
+		"MarshalBinary is only reserved with BinaryMarshaler")
+}
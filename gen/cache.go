@@ -0,0 +1,230 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/version"
+)
+
+// generateCache records the outputs of a previous call to GenerateModules
+// so that code is not regenerated for Thrift files that haven't changed.
+type generateCache struct {
+	// Modules holds the code generated for each Thrift file, keyed by the
+	// path of the Thrift file relative to ThriftRoot.
+	Modules map[string]*cacheEntry `json:"modules,omitempty"`
+
+	// Plugins holds the code generated by plugins for all root modules.
+	Plugins *cacheEntry `json:"plugins,omitempty"`
+}
+
+// cacheEntry records the files generated from a specific set of inputs.
+type cacheEntry struct {
+	// Key identifies the inputs that the files were generated from.
+	Key string `json:"key"`
+
+	// Files maps paths relative to OutputDir to the SHA1 of their contents.
+	Files map[string]string `json:"files"`
+}
+
+func newCacheEntry(key string, files map[string][]byte) *cacheEntry {
+	e := &cacheEntry{Key: key, Files: make(map[string]string, len(files))}
+	for path, contents := range files {
+		e.Files[path] = hashContents(contents)
+	}
+	return e
+}
+
+// upToDate reports whether all files recorded in this entry exist inside
+// dir with the recorded contents.
+func (e *cacheEntry) upToDate(dir string) bool {
+	for path, sum := range e.Files {
+		contents, err := os.ReadFile(filepath.Join(dir, path))
+		if err != nil || hashContents(contents) != sum {
+			return false
+		}
+	}
+	return true
+}
+
+// pluginEntry returns the entry for code generated by plugins if it has
+// the given key and is up to date inside dir.
+func (c *generateCache) pluginEntry(key, dir string) *cacheEntry {
+	if c == nil || key == "" {
+		return nil
+	}
+	if e := c.Plugins; e != nil && e.Key == key && e.upToDate(dir) {
+		return e
+	}
+	return nil
+}
+
+// readCache reads the cache at the given path. An empty cache is returned
+// if the file does not exist or is not a valid cache.
+func readCache(path string) (*generateCache, error) {
+	var c generateCache
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &c, nil
+		}
+		return nil, fmt.Errorf("could not read cache %q: %v", path, err)
+	}
+
+	if err := json.Unmarshal(b, &c); err != nil {
+		// The cache will be replaced once code has been generated.
+		return &generateCache{}, nil
+	}
+	return &c, nil
+}
+
+// write writes the cache to the given path.
+func (c *generateCache) write(path string) error {
+	return writeJSONFile(path, c)
+}
+
+// writeJSONFile writes the given value to a file as JSON. The file is not
+// rewritten if its contents haven't changed.
+func writeJSONFile(path string, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("could not create directory %q: %v", filepath.Dir(path), err)
+	}
+	if _, same, err := compareFile(path, b); err != nil || same {
+		return err
+	}
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		return fmt.Errorf("failed to write %q: %v", path, err)
+	}
+	return nil
+}
+
+// cacheKeys builds the keys under which generated code is cached.
+//
+// Keys include the ThriftRW version, the generator options, and the
+// contents and import paths of all Thrift files that the generated code
+// depends on.
+type cacheKeys struct {
+	options  []byte
+	importer thriftPackageImporter
+}
+
+func newCacheKeys(o *Options, importer thriftPackageImporter) (*cacheKeys, error) {
+	// Plugins are identified separately, and the remaining options don't
+	// affect the generated code.
+	opts := *o
+	opts.Plugin = CodeGenerator{}
+	opts.Plugins = nil
+	opts.PluginOptions = nil
+	opts.CacheFile = ""
+	opts.Check = false
+	opts.Diff = false
+	opts.ManifestFile = ""
+	opts.DepFile = ""
+
+	b, err := json.Marshal(opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not build cache key: %v", err)
+	}
+	return &cacheKeys{options: b, importer: importer}, nil
+}
+
+// Module returns the key for the code generated for the given Thrift file.
+func (k *cacheKeys) Module(m *compile.Module) (string, error) {
+	return k.sum("module", nil, m)
+}
+
+// Plugins returns the key for the code generated by the plugins identified
+// by id for the given root modules.
+func (k *cacheKeys) Plugins(id string, ms []*compile.Module) (string, error) {
+	header := []string{fmt.Sprintf("%q", id)}
+	for _, m := range ms {
+		header = append(header, fmt.Sprintf("root %q", m.ThriftPath))
+	}
+	return k.sum("plugins", header, ms...)
+}
+
+// sum hashes the given header lines with the path, import path, and SHA1
+// of the given modules and all modules included by them.
+func (k *cacheKeys) sum(kind string, header []string, ms ...*compile.Module) (string, error) {
+	seen := make(map[string]struct{})
+	var lines []string
+	for _, m := range ms {
+		err := m.Walk(func(m *compile.Module) error {
+			if _, ok := seen[m.ThriftPath]; ok {
+				return nil
+			}
+			seen[m.ThriftPath] = struct{}{}
+
+			pkg, err := k.importer.Package(m.ThriftPath)
+			if err != nil {
+				return err
+			}
+			lines = append(lines, fmt.Sprintf("%q %q %v", m.ThriftPath, pkg, hashContents(m.Raw)))
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	sort.Strings(lines)
+
+	h := sha1.New()
+	fmt.Fprintf(h, "%v %v\n%s\n", kind, version.Version, k.options)
+	for _, l := range append(header, lines...) {
+		fmt.Fprintln(h, l)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// compareFile reports whether the file at the given path exists, and if
+// so, whether it has the given contents.
+func compareFile(path string, contents []byte) (exists, same bool, err error) {
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, false, nil
+		}
+		return false, false, fmt.Errorf("could not read %q: %v", path, err)
+	}
+	return true, bytes.Equal(existing, contents), nil
+}
+
+func hashContents(b []byte) string {
+	sum := sha1.Sum(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/plugin/api"
+	"go.uber.org/thriftrw/plugin/plugintest"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cacheTestFiles writes Thrift files for cache tests to a new directory
+// and returns it.
+func cacheTestFiles(t *testing.T) string {
+	thriftRoot := t.TempDir()
+	for name, body := range map[string]string{
+		"a.thrift":      "include \"./shared.thrift\"\nservice A { shared.S get() }\n",
+		"shared.thrift": "struct S {}\n",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(thriftRoot, name), []byte(body), 0o644))
+	}
+	return thriftRoot
+}
+
+func compileCacheTestFiles(t *testing.T, thriftRoot string) *compile.Module {
+	m, err := compile.Compile(filepath.Join(thriftRoot, "a.thrift"))
+	require.NoError(t, err)
+	return m
+}
+
+// ageFiles sets the modification time of all files inside dir to a time in
+// the past and returns it.
+func ageFiles(t *testing.T, dir string) time.Time {
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		return os.Chtimes(path, old, old)
+	})
+	require.NoError(t, err)
+	return old
+}
+
+func modTime(t *testing.T, path string) time.Time {
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	return info.ModTime()
+}
+
+func TestGenerateCache(t *testing.T) {
+	thriftRoot := cacheTestFiles(t)
+	outputDir := t.TempDir()
+
+	mockCtrl := gomock.NewController(t)
+	sg := plugintest.NewMockServiceGenerator(mockCtrl)
+	sg.EXPECT().Generate(gomock.Any()).
+		Return(&api.GenerateServiceResponse{
+			Files: map[string][]byte{"a/plugin.go": []byte("package a\n")},
+		}, nil).
+		Times(3)
+
+	generate := func() {
+		t.Helper()
+		err := Generate(compileCacheTestFiles(t, thriftRoot), &Options{
+			OutputDir:     outputDir,
+			PackagePrefix: "example.com/gen",
+			ThriftRoot:    thriftRoot,
+			Plugin:        CodeGenerator{ServiceGenerator: sg, ID: "test"},
+			CacheFile:     ".thriftrw-cache.json",
+		})
+		require.NoError(t, err)
+	}
+
+	var (
+		aPath      = filepath.Join(outputDir, "a/a.go")
+		sharedPath = filepath.Join(outputDir, "shared/shared.go")
+		pluginPath = filepath.Join(outputDir, "a/plugin.go")
+	)
+
+	generate()
+	_, err := os.Stat(filepath.Join(outputDir, ".thriftrw-cache.json"))
+	require.NoError(t, err, "cache must be written")
+
+	t.Run("unchanged", func(t *testing.T) {
+		old := ageFiles(t, outputDir)
+		generate() // plugin is not called
+		for _, path := range []string{aPath, sharedPath, pluginPath} {
+			assert.Equal(t, old, modTime(t, path), "%v must not be rewritten", path)
+		}
+	})
+
+	t.Run("included file changed", func(t *testing.T) {
+		old := ageFiles(t, outputDir)
+		require.NoError(t, os.WriteFile(
+			filepath.Join(thriftRoot, "shared.thrift"),
+			[]byte("struct S { 1: optional string name }\n"), 0o644))
+
+		generate() // plugin is called
+		assert.NotEqual(t, old, modTime(t, sharedPath), "shared.go must be regenerated")
+		assert.Equal(t, old, modTime(t, aPath), "a.go has the same contents")
+		assert.Equal(t, old, modTime(t, pluginPath), "plugin.go has the same contents")
+	})
+
+	t.Run("generated file changed", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(pluginPath, []byte("modified"), 0o644))
+		generate() // plugin is called
+
+		contents, err := os.ReadFile(pluginPath)
+		require.NoError(t, err)
+		assert.Equal(t, "package a\n", string(contents))
+	})
+}
+
+func TestGenerateCachePluginsWithoutID(t *testing.T) {
+	thriftRoot := cacheTestFiles(t)
+	outputDir := t.TempDir()
+
+	mockCtrl := gomock.NewController(t)
+	sg := plugintest.NewMockServiceGenerator(mockCtrl)
+	sg.EXPECT().Generate(gomock.Any()).
+		Return(&api.GenerateServiceResponse{}, nil).
+		Times(2)
+
+	for i := 0; i < 2; i++ {
+		err := Generate(compileCacheTestFiles(t, thriftRoot), &Options{
+			OutputDir:     outputDir,
+			PackagePrefix: "example.com/gen",
+			ThriftRoot:    thriftRoot,
+			Plugin:        CodeGenerator{ServiceGenerator: sg},
+			CacheFile:     filepath.Join(t.TempDir(), "cache.json"),
+		})
+		require.NoError(t, err)
+	}
+}
+
+func TestGenerateCacheInvalid(t *testing.T) {
+	thriftRoot := cacheTestFiles(t)
+	outputDir := t.TempDir()
+	cacheFile := filepath.Join(outputDir, "cache.json")
+	require.NoError(t, os.WriteFile(cacheFile, []byte("not json"), 0o644))
+
+	err := Generate(compileCacheTestFiles(t, thriftRoot), &Options{
+		OutputDir:     outputDir,
+		PackagePrefix: "example.com/gen",
+		ThriftRoot:    thriftRoot,
+		CacheFile:     cacheFile,
+	})
+	require.NoError(t, err)
+
+	cache, err := readCache(cacheFile)
+	require.NoError(t, err)
+	assert.Contains(t, cache.Modules, "a.thrift")
+	assert.Contains(t, cache.Modules, "shared.thrift")
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// generatedPrefix is the start of the first line of files generated by
+// ThriftRW and by plugins that follow its convention.
+const generatedPrefix = "// Code generated by thriftrw"
+
+// CheckError is returned by Generate and GenerateModules with
+// Options.Check if the generated code does not match the files inside
+// OutputDir.
+type CheckError struct {
+	// Stale lists files whose contents differ from the generated code.
+	Stale []string
+
+	// Missing lists generated files that do not exist.
+	Missing []string
+
+	// Extra lists files that were generated by ThriftRW or a plugin and are
+	// no longer generated. Only the directories that code is generated
+	// into are searched for these files.
+	Extra []string
+
+	// Diff is a unified diff from the contents of OutputDir to the
+	// generated code if Options.Diff was set. Paths in the diff are
+	// relative to OutputDir, prefixed with "a/" and "b/".
+	Diff string
+}
+
+func (e *CheckError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("generated code is out of date:")
+	for _, kind := range []struct {
+		name  string
+		paths []string
+	}{
+		{"stale", e.Stale},
+		{"missing", e.Missing},
+		{"extra", e.Extra},
+	} {
+		for _, path := range kind.paths {
+			fmt.Fprintf(&sb, "\n\t%v: %v", kind.name, path)
+		}
+	}
+	return sb.String()
+}
+
+// checkFiles compares the given files with the contents of dir, returning
+// a *CheckError if they don't match. cached lists other files that are
+// known to be up to date, and stale lists files that are known to be no
+// longer generated.
+func checkFiles(dir string, files map[string][]byte, cached map[string]string, stale []string, diff bool) error {
+	var (
+		e     CheckError
+		diffs = make(map[string]string) // path -> diff
+	)
+	addDiff := func(path string, from, to []byte) error {
+		if !diff {
+			return nil
+		}
+		d, err := unifiedDiff(path, from, to)
+		diffs[path] = d
+		return err
+	}
+
+	for relPath, contents := range files {
+		fullPath := filepath.Join(dir, relPath)
+		exists, same, err := compareFile(fullPath, contents)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !exists:
+			e.Missing = append(e.Missing, relPath)
+			err = addDiff(relPath, nil, contents)
+		case !same:
+			e.Stale = append(e.Stale, relPath)
+			var existing []byte
+			if existing, err = os.ReadFile(fullPath); err == nil {
+				err = addDiff(relPath, existing, contents)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	extra, err := extraFiles(dir, files, cached)
+	if err != nil {
+		return err
+	}
+	for _, relPath := range stale {
+		if !containsString(extra, relPath) {
+			extra = append(extra, relPath)
+		}
+	}
+	for _, relPath := range extra {
+		e.Extra = append(e.Extra, relPath)
+		existing, err := os.ReadFile(filepath.Join(dir, relPath))
+		if err != nil {
+			return err
+		}
+		if err := addDiff(relPath, existing, nil); err != nil {
+			return err
+		}
+	}
+
+	if len(e.Stale) == 0 && len(e.Missing) == 0 && len(e.Extra) == 0 {
+		return nil
+	}
+	sort.Strings(e.Stale)
+	sort.Strings(e.Missing)
+	sort.Strings(e.Extra)
+
+	var sb strings.Builder
+	for _, path := range sortStringKeys(diffs) {
+		sb.WriteString(diffs[path])
+	}
+	e.Diff = sb.String()
+	return &e
+}
+
+// extraFiles returns files inside the directories of the given files that
+// were generated by ThriftRW or a plugin but are not among these files.
+func extraFiles(dir string, files map[string][]byte, cached map[string]string) ([]string, error) {
+	generated := make(map[string]struct{}, len(files)+len(cached))
+	dirs := make(map[string]struct{})
+	for path := range files {
+		generated[path] = struct{}{}
+		dirs[filepath.Dir(path)] = struct{}{}
+	}
+	for path := range cached {
+		generated[path] = struct{}{}
+		dirs[filepath.Dir(path)] = struct{}{}
+	}
+
+	var extra []string
+	for _, d := range sortStringKeys(dirs) {
+		entries, err := os.ReadDir(filepath.Join(dir, d))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			relPath := filepath.Join(d, entry.Name())
+			if _, ok := generated[relPath]; ok || !entry.Type().IsRegular() {
+				continue
+			}
+			if filepath.Ext(relPath) != ".go" {
+				continue
+			}
+
+			ok, err := isGeneratedFile(filepath.Join(dir, relPath))
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				extra = append(extra, relPath)
+			}
+		}
+	}
+	return extra, nil
+}
+
+// isGeneratedFile reports whether the file at the given path was generated
+// by ThriftRW or a plugin.
+func isGeneratedFile(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && line == "" {
+		return false, nil // empty file
+	}
+	return strings.HasPrefix(line, generatedPrefix), nil
+}
+
+// unifiedDiff returns a unified diff between the two versions of the file
+// at the given path. A nil slice indicates that the file does not exist.
+func unifiedDiff(path string, from, to []byte) (string, error) {
+	path = filepath.ToSlash(path)
+	d := difflib.UnifiedDiff{
+		A:        diffLines(from),
+		B:        diffLines(to),
+		FromFile: "a/" + path,
+		ToFile:   "b/" + path,
+		Context:  3,
+	}
+	if from == nil {
+		d.FromFile = "/dev/null"
+	}
+	if to == nil {
+		d.ToFile = "/dev/null"
+	}
+	return difflib.GetUnifiedDiffString(d)
+}
+
+func diffLines(b []byte) []string {
+	if len(b) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(b), "\n")
+	if last := lines[len(lines)-1]; last == "" {
+		lines = lines[:len(lines)-1]
+	} else {
+		lines[len(lines)-1] = last + "\n\\ No newline at end of file\n"
+	}
+	return lines
+}
+
+func containsString(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/plugin/api"
+	"go.uber.org/thriftrw/plugin/plugintest"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateCheck(t *testing.T) {
+	thriftRoot := cacheTestFiles(t)
+	outputDir := t.TempDir()
+
+	check := func() error {
+		return Generate(compileCacheTestFiles(t, thriftRoot), &Options{
+			OutputDir:     outputDir,
+			PackagePrefix: "example.com/gen",
+			ThriftRoot:    thriftRoot,
+			Check:         true,
+		})
+	}
+
+	err := check()
+	var checkErr *CheckError
+	require.ErrorAs(t, err, &checkErr)
+	assert.Empty(t, checkErr.Stale)
+	assert.Equal(t, []string{"a/a.go", "shared/shared.go"}, checkErr.Missing)
+
+	entries, err := os.ReadDir(outputDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "nothing must be written")
+
+	require.NoError(t, Generate(compileCacheTestFiles(t, thriftRoot), &Options{
+		OutputDir:     outputDir,
+		PackagePrefix: "example.com/gen",
+		ThriftRoot:    thriftRoot,
+	}))
+	assert.NoError(t, check())
+
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "a/a.go"), []byte("modified"), 0o644))
+	require.NoError(t, os.Remove(filepath.Join(outputDir, "shared/shared.go")))
+
+	err = check()
+	require.ErrorAs(t, err, &checkErr)
+	assert.Equal(t, []string{"a/a.go"}, checkErr.Stale)
+	assert.Equal(t, []string{"shared/shared.go"}, checkErr.Missing)
+	assert.EqualError(t, err, "generated code is out of date:\n"+
+		"\tstale: a/a.go\n"+
+		"\tmissing: shared/shared.go")
+
+	contents, err := os.ReadFile(filepath.Join(outputDir, "a/a.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "modified", string(contents), "nothing must be written")
+}
+
+func TestGenerateCheckExtraFiles(t *testing.T) {
+	thriftRoot := cacheTestFiles(t)
+	outputDir := t.TempDir()
+	opts := Options{
+		OutputDir:     outputDir,
+		PackagePrefix: "example.com/gen",
+		ThriftRoot:    thriftRoot,
+	}
+	require.NoError(t, Generate(compileCacheTestFiles(t, thriftRoot), &opts))
+
+	// A file that used to be generated, a hand-written file, and a file in
+	// a directory that code is not generated into.
+	old, err := os.ReadFile(filepath.Join(outputDir, "a/a.go"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "a/old.go"), old, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "a/hand.go"), []byte("package a\n"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(outputDir, "other"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "other/other.go"), old, 0o644))
+
+	opts.Check = true
+	err = Generate(compileCacheTestFiles(t, thriftRoot), &opts)
+	var checkErr *CheckError
+	require.ErrorAs(t, err, &checkErr)
+	assert.Empty(t, checkErr.Stale)
+	assert.Empty(t, checkErr.Missing)
+	assert.Equal(t, []string{"a/old.go"}, checkErr.Extra)
+	assert.Empty(t, checkErr.Diff, "diff must not be computed without Diff")
+
+	_, err = os.Stat(filepath.Join(outputDir, "a/old.go"))
+	assert.NoError(t, err, "nothing must be deleted")
+}
+
+func TestGenerateCheckDiff(t *testing.T) {
+	thriftRoot := t.TempDir()
+	outputDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(thriftRoot, "a.thrift"), []byte("struct A {}\n"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(outputDir, "a"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "a/old.go"),
+		[]byte(generatedPrefix+" v1.0.0. DO NOT EDIT.\npackage a"), 0o644))
+
+	m, err := compile.Compile(filepath.Join(thriftRoot, "a.thrift"))
+	require.NoError(t, err)
+
+	sg := plugintest.NewMockServiceGenerator(gomock.NewController(t))
+	sg.EXPECT().Generate(gomock.Any()).Return(&api.GenerateServiceResponse{
+		Files: map[string][]byte{"a/plugin.go": []byte("package a\n\nvar x = 1\n")},
+	}, nil)
+
+	err = Generate(m, &Options{
+		OutputDir:     outputDir,
+		PackagePrefix: "example.com/gen",
+		ThriftRoot:    thriftRoot,
+		NoEmbedIDL:    true,
+		Plugin:        CodeGenerator{ServiceGenerator: sg},
+		Diff:          true,
+		Check:         true,
+	})
+	var checkErr *CheckError
+	require.ErrorAs(t, err, &checkErr)
+	assert.Equal(t, []string{"a/a.go", "a/plugin.go"}, checkErr.Missing)
+	assert.Equal(t, []string{"a/old.go"}, checkErr.Extra)
+
+	assert.Contains(t, checkErr.Diff, "--- /dev/null\n+++ b/a/a.go\n")
+	assert.Contains(t, checkErr.Diff, "--- /dev/null\n+++ b/a/plugin.go\n"+
+		"@@ -0,0 +1,3 @@\n"+
+		"+package a\n"+
+		"+\n"+
+		"+var x = 1\n")
+	assert.Contains(t, checkErr.Diff, "--- a/a/old.go\n+++ /dev/null\n"+
+		"@@ -1,2 +0,0 @@\n"+
+		"-"+generatedPrefix+" v1.0.0. DO NOT EDIT.\n"+
+		"-package a\n"+
+		"\\ No newline at end of file\n")
+}
@@ -219,6 +219,10 @@ func enumItemReference(g Generator, v compile.EnumItemReference, t compile.TypeS
 // ConstantValuePtr generates an expression which is a pointer to a value of
 // type $t.
 func ConstantValuePtr(g Generator, c compile.ConstantValue, t compile.TypeSpec) (string, error) {
+	if checkGenericPtr(g) {
+		return constantValueGenericPtr(g, c, t)
+	}
+
 	var ptrFunc string
 
 	switch t.(type) {
@@ -256,3 +260,25 @@ func ConstantValuePtr(g Generator, c compile.ConstantValue, t compile.TypeSpec)
 	s = fmt.Sprintf("%v(%v)", ptrFunc, s)
 	return s, err
 }
+
+// constantValueGenericPtr is the same as ConstantValuePtr except that it
+// uses ptr.Of for all primitives, enums, and typedefs.
+func constantValueGenericPtr(g Generator, c compile.ConstantValue, t compile.TypeSpec) (string, error) {
+	switch t.(type) {
+	case *compile.BoolSpec, *compile.I8Spec, *compile.I16Spec, *compile.I32Spec,
+		*compile.I64Spec, *compile.DoubleSpec, *compile.StringSpec,
+		*compile.EnumSpec, *compile.TypedefSpec:
+		// Supply the type parameter explicitly because untyped constants
+		// would otherwise be inferred as int, float64, or string.
+		return g.TextTemplate(
+			`<import "go.uber.org/thriftrw/ptr">.Of[<typeReference .Spec>](<constantValue .Value .Spec>)`,
+			struct {
+				Spec  compile.TypeSpec
+				Value compile.ConstantValue
+			}{Spec: t, Value: c},
+			TemplateFunc("constantValue", ConstantValue),
+		)
+	default:
+		return ConstantValue(g, c, t) // not a primitive
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// writeDepFile writes a Makefile rule to the given path that makes the
+// given outputs depend on the given inputs, in the format used by
+// compilers for "-MD". The file is empty if there are no outputs.
+func writeDepFile(path string, outputs, inputs []string) error {
+	outputs = append([]string(nil), outputs...)
+	sort.Strings(outputs)
+
+	var sb strings.Builder
+	if len(outputs) > 0 {
+		writeDepRule(&sb, outputs, inputs)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("could not create directory %q: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write %q: %v", path, err)
+	}
+	return nil
+}
+
+func writeDepRule(sb *strings.Builder, outputs, inputs []string) {
+	for i, out := range outputs {
+		if i > 0 {
+			sb.WriteString(" \\\n")
+		}
+		sb.WriteString(escapeDepPath(out))
+	}
+	sb.WriteString(":")
+	for _, in := range inputs {
+		sb.WriteString(" \\\n  ")
+		sb.WriteString(escapeDepPath(in))
+	}
+	sb.WriteString("\n")
+}
+
+var _depPathReplacer = strings.NewReplacer(
+	" ", `\ `,
+	"#", `\#`,
+	"$", "$$",
+)
+
+// escapeDepPath escapes characters that have special meaning in Makefile
+// rules.
+func escapeDepPath(p string) string {
+	return _depPathReplacer.Replace(filepath.ToSlash(p))
+}
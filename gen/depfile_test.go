@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDepFile(t *testing.T) {
+	tests := []struct {
+		desc    string
+		outputs []string
+		inputs  []string
+		want    string
+	}{
+		{
+			desc: "empty",
+			want: "",
+		},
+		{
+			desc:    "single",
+			outputs: []string{"/out/a/a.go"},
+			inputs:  []string{"/idl/a.thrift"},
+			want:    "/out/a/a.go: \\\n  /idl/a.thrift\n",
+		},
+		{
+			desc:    "multiple",
+			outputs: []string{"/out/b/b.go", "/out/a/a.go"},
+			inputs:  []string{"/idl/a.thrift", "/idl/b.thrift"},
+			want: "/out/a/a.go \\\n/out/b/b.go: \\\n" +
+				"  /idl/a.thrift \\\n" +
+				"  /idl/b.thrift\n",
+		},
+		{
+			desc:    "escaped",
+			outputs: []string{"/out/a/a.go"},
+			inputs:  []string{"/my idl/$a#.thrift"},
+			want:    "/out/a/a.go: \\\n  /my\\ idl/$$a\\#.thrift\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "gen.d")
+			require.NoError(t, writeDepFile(path, tt.outputs, tt.inputs))
+
+			got, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestGenerateDepFile(t *testing.T) {
+	thriftRoot := cacheTestFiles(t)
+	outputDir := t.TempDir()
+
+	m, err := compile.Compile(filepath.Join(thriftRoot, "a.thrift"))
+	require.NoError(t, err)
+	require.NoError(t, Generate(m, &Options{
+		OutputDir:     outputDir,
+		PackagePrefix: "example.com/gen",
+		ThriftRoot:    thriftRoot,
+		DepFile:       "gen.d",
+	}))
+
+	got, err := os.ReadFile(filepath.Join(outputDir, "gen.d"))
+	require.NoError(t, err)
+	assert.Equal(t,
+		filepath.Join(outputDir, "a/a.go")+" \\\n"+
+			filepath.Join(outputDir, "shared/shared.go")+": \\\n"+
+			"  "+filepath.Join(thriftRoot, "a.thrift")+" \\\n"+
+			"  "+filepath.Join(thriftRoot, "shared.thrift")+"\n",
+		string(got))
+}
@@ -48,6 +48,21 @@ var reservedIdentifiers = map[string]struct{}{
 	"Equals":   {},
 }
 
+// binaryMarshalerIdentifiers are reserved in addition to reservedIdentifiers
+// when BinaryMarshaler methods are generated.
+var binaryMarshalerIdentifiers = map[string]struct{}{
+	"MarshalBinary":   {},
+	"UnmarshalBinary": {},
+	"MarshalThrift":   {},
+	"UnmarshalThrift": {},
+}
+
+// reuseDecodeIdentifiers are reserved in addition to reservedIdentifiers
+// when Decode methods reuse storage.
+var reuseDecodeIdentifiers = map[string]struct{}{
+	"Reset": {},
+}
+
 // fieldGroupGenerator is responsible for generating code for FieldGroups.
 type fieldGroupGenerator struct {
 	Namespace
@@ -67,9 +82,15 @@ type fieldGroupGenerator struct {
 	Doc string
 }
 
-func (f fieldGroupGenerator) checkReservedIdentifier(name string) error {
+func (f fieldGroupGenerator) checkReservedIdentifier(g Generator, name string) error {
 	_, match := reservedIdentifiers[name]
 	match = match || (f.IsException && name == "Error")
+	if _, ok := binaryMarshalerIdentifiers[name]; ok && checkBinaryMarshaler(g) {
+		match = true
+	}
+	if _, ok := reuseDecodeIdentifiers[name]; ok && checkReuseDecode(g) {
+		match = true
+	}
 	if match {
 		return fmt.Errorf("%q is a reserved ThriftRW identifier", name)
 	}
@@ -125,6 +146,18 @@ func (f fieldGroupGenerator) Generate(g Generator) error {
 		}
 	}
 
+	if checkBinaryMarshaler(g) {
+		if err := f.BinaryMarshaler(g); err != nil {
+			return err
+		}
+	}
+
+	if checkReuseDecode(g) {
+		if err := f.Reset(g); err != nil {
+			return err
+		}
+	}
+
 	return f.Accessors(g)
 }
 
@@ -141,7 +174,9 @@ func (f fieldGroupGenerator) DefineStruct(g Generator) error {
 		}`,
 		f,
 		TemplateFunc("tag", generateTags),
-		TemplateFunc("declFieldName", f.declFieldName),
+		TemplateFunc("declFieldName", func(fs *compile.FieldSpec) (string, error) {
+			return f.declFieldName(g, fs)
+		}),
 	)
 }
 
@@ -232,13 +267,13 @@ func compileJSONTag(f *compile.FieldSpec, name string, opts ...string) *structta
 // It replicates goName but also register all field names in the
 // fieldGroupGenerator namespace, enforcing single field definition when
 // generating Go code. TL;DR: will fail during generation, before compilation.
-func (f *fieldGroupGenerator) declFieldName(fs *compile.FieldSpec) (string, error) {
+func (f *fieldGroupGenerator) declFieldName(g Generator, fs *compile.FieldSpec) (string, error) {
 	name, fromAnnotation, err := goNameForNamedEntity(fs)
 	if err != nil {
 		return "", err
 	}
 
-	if err = f.checkReservedIdentifier(name); err == nil {
+	if err = f.checkReservedIdentifier(g, name); err == nil {
 		err = f.Reserve(name)
 	}
 
@@ -581,6 +616,13 @@ func (f fieldGroupGenerator) Decode(g Generator) error {
 		//
 		// An error is returned if a <.Name> struct could not be generated from the wire
 		// representation.
+		<- $reuse := reuseDecode>
+		<- if $reuse>
+		//
+		// Lists, sets, maps, and structs already referenced by this <.Name>
+		// are reused to hold the decoded values, so they must not be in use
+		// elsewhere.
+		<- end>
 		func (<$v> *<.Name>) Decode(<$sr> <$stream>.Reader) error {
 			<$isSet := newNamespace>
 			<range .Fields>
@@ -589,6 +631,15 @@ func (f fieldGroupGenerator) Decode(g Generator) error {
 				<- end>
 			<end>
 
+			<if $reuse ->
+				<range .Fields>
+					<- if isReusable .Type ->
+						<$isSet.NewName (printf "%sPrev" .Name)> := <$v>.<goName .>
+					<- end>
+				<end>
+				*<$v> = <.Name>{}
+			<- end>
+
 			if err := <$sr>.ReadStructBegin(); err != nil {
 				return err
 			}
@@ -605,7 +656,9 @@ func (f fieldGroupGenerator) Decode(g Generator) error {
 				<range .Fields ->
 				case <$fh>.ID == <.ID> && <$fh>.Type == <typeCode .Type>:
 						<- $lhs := printf "%s.%s" $v (goName .) ->
-						<- if .Required ->
+						<- if and $reuse (isReusable .Type) ->
+							<$lhs>, err = <decodeReuse .Type $sr ($isSet.Rotate (printf "%sPrev" .Name))>
+						<- else if .Required ->
 							<$lhs>, err = <decode .Type $sr>
 						<- else ->
 							<decodePtr .Type $lhs $sr>
@@ -674,7 +727,26 @@ func (f fieldGroupGenerator) Decode(g Generator) error {
 			<end>
 			return nil
 		}
-		`, f, TemplateFunc("constantValuePtr", ConstantValuePtr))
+		`, f,
+		TemplateFunc("constantValuePtr", ConstantValuePtr),
+		TemplateFunc("reuseDecode", checkReuseDecode),
+	)
+}
+
+func (f fieldGroupGenerator) Reset(g Generator) error {
+	return g.DeclareFromTemplate(
+		`
+		<$v := newVar "v">
+		// Reset sets all fields of this <.Name> to their zero values.
+		//
+		// Decode reuses the lists, sets, maps, and structs already referenced
+		// by a <.Name>, so Reset is only needed to release those references,
+		// for example, before returning a value to a pool that is kept for a
+		// long time.
+		func (<$v> *<.Name>) Reset() {
+			*<$v> = <.Name>{}
+		}
+		`, f)
 }
 
 func (f fieldGroupGenerator) String(g Generator) error {
@@ -828,6 +900,55 @@ func (f fieldGroupGenerator) Zap(g Generator) error {
 	)
 }
 
+func (f fieldGroupGenerator) BinaryMarshaler(g Generator) error {
+	return g.DeclareFromTemplate(
+		`
+		<$binary := import "go.uber.org/thriftrw/protocol/binary">
+		<$bytes := import "bytes">
+		<$stream := import "go.uber.org/thriftrw/protocol/stream">
+		<$multierr := import "go.uber.org/multierr">
+
+		<$v := newVar "v">
+		<$b := newVar "b">
+		<$p := newVar "p">
+		<$buf := newVar "buf">
+		<$sw := newVar "sw">
+		<$sr := newVar "sr">
+		// MarshalBinary implements encoding.BinaryMarshaler, serializing a
+		// <.Name> struct into bytes with the Thrift Binary protocol.
+		func (<$v> *<.Name>) MarshalBinary() ([]byte, error) {
+			return <$v>.MarshalThrift(<$binary>.Default)
+		}
+
+		// UnmarshalBinary implements encoding.BinaryUnmarshaler, deserializing
+		// a <.Name> struct from bytes encoded with the Thrift Binary protocol.
+		func (<$v> *<.Name>) UnmarshalBinary(<$b> []byte) error {
+			return <$v>.UnmarshalThrift(<$binary>.Default, <$b>)
+		}
+
+		// MarshalThrift serializes a <.Name> struct into bytes with the
+		// given protocol.
+		func (<$v> *<.Name>) MarshalThrift(<$p> <$stream>.Protocol) ([]byte, error) {
+			var <$buf> <$bytes>.Buffer
+			<$sw> := <$p>.Writer(&<$buf>)
+			if err := <$v>.Encode(<$sw>); err != nil {
+				return nil, <$multierr>.Append(err, <$sw>.Close())
+			}
+			if err := <$sw>.Close(); err != nil {
+				return nil, err
+			}
+			return <$buf>.Bytes(), nil
+		}
+
+		// UnmarshalThrift deserializes a <.Name> struct from bytes encoded
+		// with the given protocol.
+		func (<$v> *<.Name>) UnmarshalThrift(<$p> <$stream>.Protocol, <$b> []byte) error {
+			<$sr> := <$p>.Reader(<$bytes>.NewReader(<$b>))
+			return <$multierr>.Append(<$v>.Decode(<$sr>), <$sr>.Close())
+		}
+		`, f)
+}
+
 func (f fieldGroupGenerator) Accessors(g Generator) error {
 	// Namespace to ensure that field names don't conflict with method names.
 	fieldsAndMethods := NewNamespace()
@@ -836,6 +957,9 @@ func (f fieldGroupGenerator) Accessors(g Generator) error {
 		`
 		<$v := newVar "v">
 		<$o := newVar "o">
+		<$x := newVar "x">
+		<$yield := newVar "yield">
+		<$item := newVar "item">
 		<$name := .Name>
 
 		<range .Fields>
@@ -846,7 +970,13 @@ func (f fieldGroupGenerator) Accessors(g Generator) error {
 			// Get<$fname> returns the value of <$fname> if it is set or its
 			// <if isNotNil .Default>default<else>zero<end> value if it is unset.
 			func (<$v> *<$name>) Get<$fname>() (<$o> <typeReference .Type>) {
-				<- if .Required ->
+				<- if and (not .Required) (isPrimitiveType .Type) useGenericPtr ->
+				  <if isNotNil .Default><$o> = <constantValue .Default .Type><end>
+				  if <$v> != nil {
+				    <$o> = <import "go.uber.org/thriftrw/ptr">.Deref(<$v>.<$fname>, <$o>)
+				  }
+				  return
+				<- else if .Required ->
 				  if <$v> != nil {
 				    <$o> = <$v>.<$fname>
 				  }
@@ -871,9 +1001,94 @@ func (f fieldGroupGenerator) Accessors(g Generator) error {
 					return <$v> != nil && <$v>.<$fname> != nil
 				}
 			<end>
+
+			<if generateSetters>
+				<reserveFieldOrMethod (printf "Set%v" $fname)>
+				// Set<$fname> sets <$fname> to the given value and returns
+				// the same <$name> so that calls may be chained.
+				<- if $.IsUnion>
+				//
+				// All other fields of the union are cleared.
+				<- end>
+				func (<$v> *<$name>) Set<$fname>(<$x> <typeReference .Type>) *<$name> {
+					<- if $.IsUnion ->
+						<- $id := .ID ->
+						<- range $.Fields ->
+							<- if ne .ID $id>
+								<$v>.<goName .> = nil
+							<- end ->
+						<- end>
+					<- end>
+					<$v>.<$fname> = <if and (not .Required) (isPrimitiveType .Type)>&<end><$x>
+					return <$v>
+				}
+			<end>
+
+			<$kind := containerKind .Type>
+			<if and generateIterators $kind>
+				<reserveFieldOrMethod (printf "All%v" $fname)>
+				<$iter := import "iter">
+				<$spec := rootTypeSpec .Type>
+				<if eq $kind "list">
+					// All<$fname> returns an iterator over the indexes and values
+					// of <$fname>.
+					func (<$v> *<$name>) All<$fname>() <$iter>.Seq2[int, <typeReference $spec.ValueSpec>] {
+						return <import "slices">.All(<$v>.Get<$fname>())
+					}
+				<else if eq $kind "set">
+					// All<$fname> returns an iterator over the values of <$fname>.
+					<- if setUsesMap $spec>
+					//
+					// The order of iteration is not specified.
+					<- end>
+					func (<$v> *<$name>) All<$fname>() <$iter>.Seq[<typeReference $spec.ValueSpec>] {
+						<- if setUsesMap $spec>
+						return <import "maps">.Keys(<$v>.Get<$fname>())
+						<- else>
+						return <import "slices">.Values(<$v>.Get<$fname>())
+						<- end>
+					}
+				<else>
+					// All<$fname> returns an iterator over the keys and values
+					// of <$fname>.
+					<- if isHashable $spec.KeySpec>
+					//
+					// The order of iteration is not specified.
+					<- end>
+					func (<$v> *<$name>) All<$fname>() <$iter>.Seq2[<typeReference $spec.KeySpec>, <typeReference $spec.ValueSpec>] {
+						<- if isHashable $spec.KeySpec>
+						return <import "maps">.All(<$v>.Get<$fname>())
+						<- else>
+						return func(<$yield> func(<typeReference $spec.KeySpec>, <typeReference $spec.ValueSpec>) bool) {
+							for _, <$item> := range <$v>.Get<$fname>() {
+								if !<$yield>(<$item>.Key, <$item>.Value) {
+									return
+								}
+							}
+						}
+						<- end>
+					}
+				<end>
+			<end>
 		<end>
 		`, f,
 		TemplateFunc("constantValue", ConstantValue),
+		TemplateFunc("generateSetters", checkSetters),
+		TemplateFunc("generateIterators", checkIterators),
+		TemplateFunc("rootTypeSpec", compile.RootTypeSpec),
+		TemplateFunc("containerKind", func(spec compile.TypeSpec) string {
+			switch compile.RootTypeSpec(spec).(type) {
+			case *compile.ListSpec:
+				return "list"
+			case *compile.SetSpec:
+				return "set"
+			case *compile.MapSpec:
+				return "map"
+			default:
+				return ""
+			}
+		}),
+		TemplateFunc("useGenericPtr", checkGenericPtr),
 		TemplateFunc("shouldGenerateIsSet", func(f *compile.FieldSpec) bool {
 			// Generate IsSet functions for a field only if the field is
 			// optional or the field value itself is nillable.
@@ -21,14 +21,16 @@
 package gen
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
+	"go/token"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"go.uber.org/thriftrw/compile"
 	"go.uber.org/thriftrw/internal/plugin"
+	thriftrwplugin "go.uber.org/thriftrw/plugin"
 	"go.uber.org/thriftrw/plugin/api"
 
 	"go.uber.org/multierr"
@@ -37,6 +39,12 @@ import (
 // CodeGenerator lists possible code generators for a plugin.
 type CodeGenerator struct {
 	ServiceGenerator api.ServiceGenerator
+
+	// ID identifies ServiceGenerator and its configuration, for example
+	// by the names, arguments, and checksums of plugin executables. Code
+	// generated by ServiceGenerator is reused from CacheFile only if this
+	// is non-empty and has not changed since the code was recorded.
+	ID string
 }
 
 // Options controls how code gets generated.
@@ -67,6 +75,20 @@ type Options struct {
 	// Code generation plugin
 	Plugin CodeGenerator
 
+	// Plugins are code generation plugins that run inside this process,
+	// in addition to Plugin. Their ServiceGenerators are called directly
+	// instead of starting thriftrw-plugin-$name executables.
+	//
+	// Only the Name, ServiceGenerator, and Options of these plugins are
+	// used. Names are required and are used to report errors and conflicts
+	// between plugins.
+	Plugins []*thriftrwplugin.Plugin
+
+	// PluginOptions holds values for the options declared by Plugins, keyed
+	// by plugin name and then by option name. These are validated against
+	// the Options of the corresponding plugin.
+	PluginOptions map[string]map[string]string
+
 	// Do not generate types.go
 	NoTypes bool
 
@@ -88,10 +110,119 @@ type Options struct {
 	// Generates an error on MarshalText and MarshalJSON if the enum value is
 	// unrecognized.
 	EnumTextMarshalStrict bool
+
+	// Generates chainable Set* methods for all struct fields.
+	Setters bool
+
+	// Uses the generic helpers from the ptr package in generated getters
+	// and default values instead of per-type helpers.
+	GenericPtr bool
+
+	// Generates MarshalBinary, UnmarshalBinary, MarshalThrift, and
+	// UnmarshalThrift methods for structs, unions, and exceptions.
+	BinaryMarshaler bool
+
+	// Generates Decode methods that reuse the lists, sets, maps, and nested
+	// structs of the value being decoded into, and Reset methods for
+	// structs.
+	ReuseDecode bool
+
+	// Generates All* methods that return iterators over list, set, and map
+	// fields of structs.
+	Iterators bool
+
+	// Uses the "namespace go" headers of Thrift files to determine the
+	// paths of their packages relative to PackagePrefix and OutputDir. For
+	// example, "namespace go foo.bar" places the package for that file at
+	// $PackagePrefix/foo/bar. Files without such a header are placed based
+	// on their location relative to ThriftRoot.
+	GoNamespace bool
+
+	// CacheFile is the path to a file that records the code generated by
+	// previous calls, relative to OutputDir if it isn't absolute. If set,
+	// code is not regenerated for Thrift files whose contents, includes,
+	// and options haven't changed if the files generated for them are
+	// unchanged on disk. Code generated by Plugin is cached only if
+	// Plugin.ID is set and there are no in-process Plugins.
+	//
+	// The cache should not be shared between calls with different
+	// options or Thrift files, or code will be regenerated every time.
+	CacheFile string
+
+	// Check compares the generated code with the files inside OutputDir
+	// instead of writing it. If any of these files are missing or
+	// different, or if files previously generated into the same
+	// directories or listed in ManifestFile are no longer generated, a
+	// *CheckError listing them is returned.
+	//
+	// Nothing is written in this mode, including CacheFile.
+	Check bool
+
+	// Diff includes a unified diff of the changes in the *CheckError
+	// returned in Check mode.
+	Diff bool
+
+	// ManifestFile is the path to a file listing the generated files,
+	// relative to OutputDir if it isn't absolute. If set, files listed by
+	// a manifest from a previous call that are no longer generated are
+	// removed, along with directories left empty. Files that were changed
+	// since they were generated and files not listed in the manifest are
+	// never removed.
+	//
+	// The manifest also lists the Thrift files that were read and the
+	// import paths and names of the generated packages, for use by build
+	// systems.
+	//
+	// The manifest should not be shared between calls with different
+	// Thrift files, or they will remove each other's files.
+	ManifestFile string
+
+	// Layout controls how the code generated for each Thrift file is split
+	// into files. By default, each Thrift file gets a single file.
+	Layout Layout
+
+	// MaxFileSize starts a new file once a generated file reaches this many
+	// bytes if it's positive. Constants, types, and service functions are
+	// never split across files, so files may be larger than this.
+	MaxFileSize int
+
+	// FileNameTemplate is a text/template that determines the names of the
+	// generated files, relative to the package directory. It's executed
+	// with a FileNameData, and may use the "lower" function. Names must end
+	// with ".go" and must be unique within each package.
+	//
+	// With SingleFileLayout, this defaults to
+	//
+	//	{{.Dir}}{{if .Index}}_{{.Index}}{{end}}.go
+	//
+	// With DefinitionLayout, this defaults to
+	//
+	//	{{if .Name}}{{lower .Name}}_{{end}}{{.Kind}}{{if .Index}}_{{.Index}}{{end}}.go
+	//
+	// OutputFile may not be used with this, MaxFileSize, or
+	// DefinitionLayout.
+	FileNameTemplate string
+
+	// DepFile is the path to a file to which a Makefile rule is written,
+	// relative to OutputDir if it isn't absolute. The rule lists the
+	// generated files as targets and all Thrift files that were read as
+	// their prerequisites, with absolute paths.
+	DepFile string
 }
 
 // Generate generates code based on the given options.
 func Generate(m *compile.Module, o *Options) error {
+	return GenerateModules([]*compile.Module{m}, o)
+}
+
+// GenerateModules generates code for multiple root modules based on the
+// given options. Modules should be compiled together with
+// compile.CompileFiles.
+//
+// Code for each Thrift file is generated only once, even if it's included
+// by more than one of the given modules. Plugins receive a single request
+// with the services of all root modules.
+func GenerateModules(ms []*compile.Module, o *Options) error {
 	if !filepath.IsAbs(o.ThriftRoot) {
 		return fmt.Errorf(
 			"ThriftRoot must be an absolute path: %q is not absolute",
@@ -104,33 +235,121 @@ func Generate(m *compile.Module, o *Options) error {
 			o.OutputDir)
 	}
 
+	if _, err := validateLayout(o); err != nil {
+		return err
+	}
+
+	plug, err := serviceGenerator(o)
+	if err != nil {
+		return err
+	}
+
 	importer := thriftPackageImporter{
 		ImportPrefix: o.PackagePrefix,
 		ThriftRoot:   o.ThriftRoot,
 	}
+	if o.GoNamespace {
+		importer.Packages, err = goNamespacePackages(o.ThriftRoot, ms)
+		if err != nil {
+			return err
+		}
+	}
+
+	var (
+		cache, newCache *generateCache
+		keys            *cacheKeys
+		cacheFile       string
+	)
+	if o.CacheFile != "" {
+		cacheFile = resolveOutputPath(o.OutputDir, o.CacheFile)
+		cache, err = readCache(cacheFile)
+		if err != nil {
+			return err
+		}
+		keys, err = newCacheKeys(o, importer)
+		if err != nil {
+			return err
+		}
+		newCache = &generateCache{Modules: make(map[string]*cacheEntry)}
+	}
+
+	var (
+		oldManifest  *manifest
+		manifestFile string
+	)
+	if o.ManifestFile != "" {
+		manifestFile = resolveOutputPath(o.OutputDir, o.ManifestFile)
+		oldManifest, err = readManifest(manifestFile)
+		if err != nil {
+			return err
+		}
+	}
 
 	// Mapping of filenames relative to OutputDir to their contents.
 	files := make(map[string][]byte)
+	// Files that are up to date according to the cache, mapped to the
+	// SHA1 of their contents.
+	cachedFiles := make(map[string]string)
 	genBuilder := newGenerateServiceBuilder(importer)
 
+	generated := make(map[string]struct{})
 	generate := func(m *compile.Module) error {
-		path, contents, err := generateModule(m, importer, genBuilder, o)
+		if _, ok := generated[m.ThriftPath]; ok {
+			return nil
+		}
+		generated[m.ThriftPath] = struct{}{}
+
+		var key, cacheName string
+		if keys != nil {
+			var err error
+			if key, err = keys.Module(m); err != nil {
+				return generateError{Name: m.ThriftPath, Reason: err}
+			}
+			if cacheName, err = importer.RelativeThriftFilePath(m.ThriftPath); err != nil {
+				return generateError{Name: m.ThriftPath, Reason: err}
+			}
+			cacheName = filepath.ToSlash(cacheName)
+
+			if e := cache.Modules[cacheName]; e != nil && e.Key == key && e.upToDate(o.OutputDir) {
+				if err := addToPluginRequest(m, genBuilder); err != nil {
+					return generateError{Name: m.ThriftPath, Reason: err}
+				}
+				for path, sum := range e.Files {
+					if err := addCachedFile(files, cachedFiles, path, sum); err != nil {
+						return generateError{Name: m.ThriftPath, Reason: err}
+					}
+				}
+				newCache.Modules[cacheName] = e
+				return nil
+			}
+		}
+
+		moduleFiles, err := generateModule(m, importer, genBuilder, o)
 		if err != nil {
 			return generateError{Name: m.ThriftPath, Reason: err}
 		}
 
-		if err := addFile(files, path, contents); err != nil {
-			return generateError{Name: m.ThriftPath, Reason: err}
+		for path, contents := range moduleFiles {
+			if _, ok := cachedFiles[path]; ok {
+				return generateError{Name: m.ThriftPath, Reason: conflictError(path)}
+			}
+			if err := addFile(files, path, contents); err != nil {
+				return generateError{Name: m.ThriftPath, Reason: err}
+			}
 		}
 
+		if newCache != nil {
+			newCache.Modules[cacheName] = newCacheEntry(key, moduleFiles)
+		}
 		return nil
 	}
 
 	// Root Modules correspond to the Thrift files that ThriftRW is
-	// called with. Currently, ThriftRW can only be called with one
-	// Thrift file at a time.
-	if _, err := genBuilder.AddRootModule(m.ThriftPath); err != nil {
-		return err
+	// called with.
+	for _, m := range ms {
+		if _, err := genBuilder.AddRootModule(m.ThriftPath); err != nil {
+			return err
+		}
 	}
 
 	// Note that we call generate directly on only those modules that we need
@@ -139,34 +358,130 @@ func Generate(m *compile.Module, o *Options) error {
 	// Specifying an OutputFile file also means that code for included modules
 	// should not be generated, since code for multiple modules cannot
 	// be compiled into a single file.
-	if o.NoRecurse || len(o.OutputFile) > 0 {
-		if err := generate(m); err != nil {
+	for _, m := range ms {
+		var err error
+		if o.NoRecurse || len(o.OutputFile) > 0 {
+			err = generate(m)
+		} else {
+			err = m.Walk(generate)
+		}
+		if err != nil {
 			return err
 		}
+	}
+
+	var pluginKey string
+	if keys != nil && pluginsCacheable(o) {
+		if pluginKey, err = keys.Plugins(o.Plugin.ID, ms); err != nil {
+			return err
+		}
+	}
+
+	if e := cache.pluginEntry(pluginKey, o.OutputDir); e != nil {
+		for path, sum := range e.Files {
+			if err := addCachedFile(files, cachedFiles, path, sum); err != nil {
+				return err
+			}
+		}
+		newCache.Plugins = e
 	} else {
-		if err := m.Walk(generate); err != nil {
+		res, err := plug.Generate(genBuilder.Build())
+		if err != nil {
+			return err
+		}
+
+		for path := range res.Files {
+			if _, ok := cachedFiles[path]; ok {
+				return conflictError(path)
+			}
+		}
+		if err := mergeFiles(files, res.Files); err != nil {
 			return err
 		}
+
+		if pluginKey != "" {
+			newCache.Plugins = newCacheEntry(pluginKey, res.Files)
+		}
 	}
 
-	plug := o.Plugin.ServiceGenerator
-	if plug == nil {
-		plug = plugin.EmptyServiceGenerator
+	var stale []string
+	if oldManifest != nil {
+		stale = oldManifest.staleFiles(o.OutputDir, files, cachedFiles)
 	}
 
-	res, err := plug.Generate(genBuilder.Build())
-	if err != nil {
+	if o.Check {
+		return checkFiles(o.OutputDir, files, cachedFiles, stale, o.Diff)
+	}
+
+	if err := writeFiles(o.OutputDir, files); err != nil {
 		return err
 	}
 
-	if err := mergeFiles(files, res.Files); err != nil {
+	if err := removeFiles(o.OutputDir, stale); err != nil {
 		return err
 	}
 
+	if manifestFile != "" {
+		m, err := newManifest(ms, importer, generated, o.OutputDir, files, cachedFiles)
+		if err != nil {
+			return err
+		}
+		if err := m.write(manifestFile); err != nil {
+			return err
+		}
+	}
+
+	if o.DepFile != "" {
+		var outputs []string
+		for path := range files {
+			outputs = append(outputs, filepath.Join(o.OutputDir, path))
+		}
+		for path := range cachedFiles {
+			outputs = append(outputs, filepath.Join(o.OutputDir, path))
+		}
+		depFile := resolveOutputPath(o.OutputDir, o.DepFile)
+		if err := writeDepFile(depFile, outputs, inputFiles(ms)); err != nil {
+			return err
+		}
+	}
+
+	if newCache != nil {
+		return newCache.write(cacheFile)
+	}
+	return nil
+}
+
+// resolveOutputPath resolves p relative to the output directory unless it's
+// absolute.
+func resolveOutputPath(outputDir, p string) string {
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(outputDir, p)
+}
+
+// pluginsCacheable reports whether the code generated by plugins may be
+// reused from the cache.
+func pluginsCacheable(o *Options) bool {
+	if len(o.Plugins) > 0 || len(o.PluginOptions) > 0 {
+		// We can't tell whether in-process plugins have changed.
+		return false
+	}
+	return o.Plugin.ServiceGenerator == nil || o.Plugin.ID != ""
+}
+
+// writeFiles writes the given files to the directory. Files that already
+// exist with the same contents are not rewritten.
+func writeFiles(dir string, files map[string][]byte) error {
 	for relPath, contents := range files {
-		fullPath := filepath.Join(o.OutputDir, relPath)
-		directory := filepath.Dir(fullPath)
+		fullPath := filepath.Join(dir, relPath)
+		if _, same, err := compareFile(fullPath, contents); err != nil {
+			return err
+		} else if same {
+			continue
+		}
 
+		directory := filepath.Dir(fullPath)
 		if err := os.MkdirAll(directory, 0755); err != nil {
 			return fmt.Errorf("could not create directory %q: %v", directory, err)
 		}
@@ -175,10 +490,58 @@ func Generate(m *compile.Module, o *Options) error {
 			return fmt.Errorf("failed to write %q: %v", fullPath, err)
 		}
 	}
-
 	return nil
 }
 
+// serviceGenerator returns a ServiceGenerator that calls Options.Plugin and
+// all in-process plugins.
+func serviceGenerator(o *Options) (api.ServiceGenerator, error) {
+	plug := o.Plugin.ServiceGenerator
+	if len(o.Plugins) == 0 && len(o.PluginOptions) == 0 {
+		if plug == nil {
+			plug = plugin.EmptyServiceGenerator
+		}
+		return plug, nil
+	}
+
+	var msg plugin.MultiServiceGenerator
+	switch sg := plug.(type) {
+	case nil:
+		// Nothing to do.
+	case plugin.ServiceGenerator:
+		msg = append(msg, sg)
+	default:
+		msg = append(msg, plugin.NewInProcessHandle("plugin", sg, nil /* options */).ServiceGenerator())
+	}
+
+	names := make(map[string]struct{}, len(o.Plugins))
+	for _, p := range o.Plugins {
+		if p.Name == "" {
+			return nil, errors.New("in-process plugins must have a name")
+		}
+		if _, ok := names[p.Name]; ok {
+			return nil, fmt.Errorf("in-process plugin %q was provided more than once", p.Name)
+		}
+		names[p.Name] = struct{}{}
+
+		opts, err := plugin.ResolveOptions(p.Options, o.PluginOptions[p.Name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid options for in-process plugin %q: %v", p.Name, err)
+		}
+
+		if sg := plugin.NewInProcessHandle(p.Name, p.ServiceGenerator, opts).ServiceGenerator(); sg != nil {
+			msg = append(msg, sg)
+		}
+	}
+
+	for name := range o.PluginOptions {
+		if _, ok := names[name]; !ok {
+			return nil, fmt.Errorf("options were provided for unknown in-process plugin %q", name)
+		}
+	}
+	return msg, nil
+}
+
 // normalizePackageName replaces hyphens in the file name with underscores.
 func normalizePackageName(p string) string {
 	return strings.Replace(filepath.Base(p), "-", "_", -1)
@@ -202,12 +565,90 @@ type ThriftPackageImporter interface {
 type thriftPackageImporter struct {
 	ImportPrefix string
 	ThriftRoot   string
+
+	// Packages overrides the package paths of some Thrift files, relative
+	// to ImportPrefix. This is keyed by absolute path to the Thrift file.
+	Packages map[string]string
 }
 
 func (i thriftPackageImporter) RelativePackage(file string) (string, error) {
+	if pkg, ok := i.Packages[file]; ok {
+		return pkg, nil
+	}
 	return filepath.Rel(i.ThriftRoot, strings.TrimSuffix(file, ".thrift"))
 }
 
+// goNamespacePackages returns the package paths requested by the
+// "namespace go" headers of the given modules and their includes, keyed by
+// Thrift file path.
+//
+// It fails if two Thrift files would be generated into the same package,
+// including files without a namespace whose package is derived from their
+// path relative to thriftRoot.
+func goNamespacePackages(thriftRoot string, ms []*compile.Module) (map[string]string, error) {
+	packages := make(map[string]string)
+	files := make(map[string]string) // package -> Thrift file
+	add := func(m *compile.Module) error {
+		ns, ok := m.Namespaces["go"]
+		if !ok {
+			return nil
+		}
+		if _, ok := packages[m.ThriftPath]; ok {
+			return nil // shared by multiple root modules
+		}
+
+		segments := strings.Split(ns, ".")
+		for _, seg := range segments {
+			if !token.IsIdentifier(seg) {
+				return fmt.Errorf(
+					"%q has an invalid namespace go %q: %q is not a valid Go identifier",
+					m.ThriftPath, ns, seg)
+			}
+		}
+
+		pkg := filepath.Join(segments...)
+		if other, ok := files[pkg]; ok {
+			return fmt.Errorf(
+				"%q and %q cannot both use namespace go %q", other, m.ThriftPath, ns)
+		}
+
+		files[pkg] = m.ThriftPath
+		packages[m.ThriftPath] = pkg
+		return nil
+	}
+
+	for _, m := range ms {
+		if err := m.Walk(add); err != nil {
+			return nil, err
+		}
+	}
+
+	importer := thriftPackageImporter{ThriftRoot: thriftRoot, Packages: packages}
+	check := func(m *compile.Module) error {
+		if _, ok := packages[m.ThriftPath]; ok {
+			return nil // already checked above
+		}
+
+		pkg, err := importer.RelativePackage(m.ThriftPath)
+		if err != nil {
+			return err
+		}
+		if other, ok := files[pkg]; ok && other != m.ThriftPath {
+			return fmt.Errorf(
+				"%q and %q cannot both be generated into package %q", other, m.ThriftPath, pkg)
+		}
+		files[pkg] = m.ThriftPath
+		return nil
+	}
+
+	for _, m := range ms {
+		if err := m.Walk(check); err != nil {
+			return nil, err
+		}
+	}
+	return packages, nil
+}
+
 func (i thriftPackageImporter) RelativeThriftFilePath(file string) (string, error) {
 	return filepath.Rel(i.ThriftRoot, file)
 }
@@ -230,21 +671,42 @@ func mergeFiles(dest, src map[string][]byte) error {
 
 func addFile(dest map[string][]byte, path string, contents []byte) error {
 	if _, ok := dest[path]; ok {
-		return fmt.Errorf("file generation conflict: "+
-			"multiple sources are trying to write to %q", path)
+		return conflictError(path)
 	}
 	dest[path] = contents
 	return nil
 }
 
-// generateModule generates the code for the given Thrift file and returns the
-// path to the output file relative to OutputDir and the contents of the file.
+// addCachedFile records that a file generated by a previous call is up to
+// date.
+func addCachedFile(files map[string][]byte, cached map[string]string, path, sum string) error {
+	_, generated := files[path]
+	_, ok := cached[path]
+	if generated || ok {
+		return conflictError(path)
+	}
+	cached[path] = sum
+	return nil
+}
+
+func conflictError(path string) error {
+	return fmt.Errorf("file generation conflict: "+
+		"multiple sources are trying to write to %q", path)
+}
+
+// generateModule generates the code for the given Thrift file and returns
+// the generated files, keyed by path relative to OutputDir.
 func generateModule(
 	m *compile.Module,
 	i thriftPackageImporter,
 	builder *generateServiceBuilder,
 	o *Options,
-) (outputFilepath string, contents []byte, err error) {
+) (map[string][]byte, error) {
+	tmpl, err := validateLayout(o)
+	if err != nil {
+		return nil, err
+	}
+
 	// packageRelPath is the path relative to outputDir into which we'll be
 	// writing the package for this Thrift file. For $thriftRoot/foo/bar.thrift,
 	// packageRelPath is foo/bar, and packageDir is $outputDir/foo/bar. All
@@ -252,23 +714,14 @@ func generateModule(
 	// package will be importable via $importPrefix/foo/bar.
 	packageRelPath, err := i.RelativePackage(m.ThriftPath)
 	if err != nil {
-		return "", nil, err
-	}
-	// TODO(abg): Prefer top-level package name from `namespace go` directive.
-	outputFilename := filepath.Base(packageRelPath)
-
-	// Output file name defaults to the package name.
-	outputFilename = outputFilename + ".go"
-	if len(o.OutputFile) > 0 {
-		outputFilename = o.OutputFile
+		return nil, err
 	}
-	outputFilepath = filepath.Join(packageRelPath, outputFilename)
 
 	// importPath is the full import path for the top-level package generated
 	// for this Thrift file.
 	importPath, err := i.Package(m.ThriftPath)
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
 
 	// converts package name from ab-def to ab_def for golang code generation
@@ -279,66 +732,113 @@ func generateModule(
 		PackageName:           normalizedPackageName,
 		NoZap:                 o.NoZap,
 		EnumTextMarshalStrict: o.EnumTextMarshalStrict,
-	})
+		Setters:               o.Setters,
+		GenericPtr:            o.GenericPtr,
+		BinaryMarshaler:       o.BinaryMarshaler,
+		ReuseDecode:           o.ReuseDecode,
+		Iterators:             o.Iterators,
+	}).(*generator)
+
+	mf := newModuleFiles(g, o, tmpl, filepath.Base(packageRelPath))
 
 	if len(m.Constants) > 0 {
+		if err := mf.Start(ConstantsFileKind, ""); err != nil {
+			return nil, err
+		}
 		for _, constantName := range sortStringKeys(m.Constants) {
 			if err := Constant(g, m.Constants[constantName]); err != nil {
-				return "", nil, err
+				return nil, err
+			}
+			if err := mf.EndUnit(); err != nil {
+				return nil, err
 			}
 		}
 	}
 
 	if len(m.Types) > 0 {
 		for _, typeName := range sortStringKeys(m.Types) {
-			if err := TypeDefinition(g, m.Types[typeName]); err != nil {
-				return "", nil, err
+			spec := m.Types[typeName]
+			if err := mf.Start(fileKind(spec), typeName); err != nil {
+				return nil, err
+			}
+			if err := TypeDefinition(g, spec); err != nil {
+				return nil, err
+			}
+			if err := mf.EndUnit(); err != nil {
+				return nil, err
 			}
 		}
 	}
 
 	if !o.NoEmbedIDL {
+		if err := mf.Start(IDLFileKind, ""); err != nil {
+			return nil, err
+		}
 		if err := embedIDL(g, i, m); err != nil {
-			return "", nil, err
+			return nil, err
+		}
+		if err := mf.EndUnit(); err != nil {
+			return nil, err
 		}
 	}
 
-	addModules := func(m *compile.Module) error {
-		_, err := builder.AddModule(m.ThriftPath)
-		return err
-	}
-
-	if err := m.Walk(addModules); err != nil {
-		return "", nil, err
+	if err := addToPluginRequest(m, builder); err != nil {
+		return nil, err
 	}
 
 	// Services must be generated last because names of user-defined types take
 	// precedence over the names we pick for the service types.
-	if len(m.Services) > 0 {
-		for _, serviceName := range sortStringKeys(m.Services) {
-			service := m.Services[serviceName]
-
-			// generateModule gets called only for those modules for which we
-			// need to generate code. With --no-recurse, generateModule is
-			// called only on the root file specified by the user and not its
-			// included modules. Only services defined in these files are
-			// considered root services; plugins will generate code only for
-			// root services, even though they have information about the
-			// whole service tree.
-			if _, err := builder.AddRootService(service); err != nil {
-				return "", nil, err
+	for _, serviceName := range sortStringKeys(m.Services) {
+		s := m.Services[serviceName]
+		if err := mf.Start(ServiceFileKind, serviceName); err != nil {
+			return nil, err
+		}
+		for _, functionName := range sortStringKeys(s.Functions) {
+			if err := ServiceFunction(g, s, s.Functions[functionName]); err != nil {
+				err = fmt.Errorf("could not generate types for %s.%s: %v", s.Name, functionName, err)
+				return nil, fmt.Errorf("could not generate code for services %v", err)
+			}
+			if err := mf.EndUnit(); err != nil {
+				return nil, err
 			}
 		}
+	}
 
-		if err = Services(g, m.Services); err != nil {
-			return "", nil, fmt.Errorf("could not generate code for services %v", err)
-		}
+	if err := mf.Close(); err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte, len(mf.files))
+	for name, contents := range mf.files {
+		files[filepath.Join(packageRelPath, name)] = contents
+	}
+	return files, nil
+}
+
+// addToPluginRequest adds the given module, the modules it includes, and its
+// services to the request for plugins.
+func addToPluginRequest(m *compile.Module, builder *generateServiceBuilder) error {
+	addModules := func(m *compile.Module) error {
+		_, err := builder.AddModule(m.ThriftPath)
+		return err
 	}
 
-	buff := new(bytes.Buffer)
-	if err := g.Write(buff, nil); err != nil {
-		return "", nil, fmt.Errorf("could not write output for file %q: %v", outputFilename, err)
+	if err := m.Walk(addModules); err != nil {
+		return err
 	}
 
-	return outputFilepath, buff.Bytes(), nil
+	for _, serviceName := range sortStringKeys(m.Services) {
+		service := m.Services[serviceName]
+
+		// addToPluginRequest gets called only for those modules for which
+		// we need to generate code. With --no-recurse, it's called only on
+		// the root file specified by the user and not its included modules.
+		// Only services defined in these files are considered root
+		// services; plugins will generate code only for root services,
+		// even though they have information about the whole service tree.
+		if _, err := builder.AddRootService(service); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -32,7 +32,9 @@ import (
 	"go.uber.org/thriftrw/compile"
 	"go.uber.org/thriftrw/internal/plugin"
 	"go.uber.org/thriftrw/internal/plugin/handletest"
+	thriftrwplugin "go.uber.org/thriftrw/plugin"
 	"go.uber.org/thriftrw/plugin/api"
+	"go.uber.org/thriftrw/plugin/plugintest"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -137,6 +139,164 @@ func TestGenerateWithHyphenPaths(t *testing.T) {
 	}
 }
 
+func TestGenerateGoNamespace(t *testing.T) {
+	writeThrift := func(t *testing.T, dir string, files map[string]string) {
+		for name, body := range files {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644))
+		}
+	}
+
+	tests := []struct {
+		desc        string
+		files       map[string]string
+		goNamespace bool
+
+		wantFiles map[string]string // file -> substring it must contain
+		wantError string
+	}{
+		{
+			desc: "disabled",
+			files: map[string]string{
+				"a.thrift": "namespace go foo.bar\ninclude \"./b.thrift\"\nstruct A { 1: optional b.B b }\n",
+				"b.thrift": "struct B {}\n",
+			},
+			wantFiles: map[string]string{
+				"a/a.go": "package a",
+				"b/b.go": "package b",
+			},
+		},
+		{
+			desc: "enabled",
+			files: map[string]string{
+				"a.thrift": "namespace go foo.bar\ninclude \"./b.thrift\"\nstruct A { 1: optional b.B b }\n",
+				"b.thrift": "namespace go foo.baz_b\nstruct B {}\n",
+				"c.thrift": "include \"./a.thrift\"\nstruct C { 1: optional a.A a }\n",
+			},
+			goNamespace: true,
+			wantFiles: map[string]string{
+				"foo/bar/bar.go":     `"example.com/gen/foo/baz_b"`,
+				"foo/baz_b/baz_b.go": "package baz_b",
+				"c/c.go":             `"example.com/gen/foo/bar"`,
+			},
+		},
+		{
+			desc: "conflict",
+			files: map[string]string{
+				"c.thrift": "include \"./a.thrift\"\ninclude \"./b.thrift\"\n",
+				"a.thrift": "namespace go foo.bar\n",
+				"b.thrift": "namespace go foo.bar\n",
+			},
+			goNamespace: true,
+			wantError:   `cannot both use namespace go "foo.bar"`,
+		},
+		{
+			desc: "conflict with file path",
+			files: map[string]string{
+				"a.thrift": "namespace go b\ninclude \"./b.thrift\"\n",
+				"b.thrift": "struct B {}\n",
+			},
+			goNamespace: true,
+			wantError:   `cannot both be generated into package "b"`,
+		},
+		{
+			desc: "invalid identifier",
+			files: map[string]string{
+				"a.thrift": "namespace go foo.1pkg\n",
+			},
+			goNamespace: true,
+			wantError:   `"1pkg" is not a valid Go identifier`,
+		},
+		{
+			desc: "keyword",
+			files: map[string]string{
+				"a.thrift": "namespace go foo.type\n",
+			},
+			goNamespace: true,
+			wantError:   `"type" is not a valid Go identifier`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			thriftRoot := t.TempDir()
+			outputDir := t.TempDir()
+			writeThrift(t, thriftRoot, tt.files)
+
+			root := "a.thrift"
+			if _, ok := tt.files["c.thrift"]; ok {
+				root = "c.thrift"
+			}
+			module, err := compile.Compile(filepath.Join(thriftRoot, root))
+			require.NoError(t, err)
+
+			err = Generate(module, &Options{
+				OutputDir:     outputDir,
+				PackagePrefix: "example.com/gen",
+				ThriftRoot:    thriftRoot,
+				GoNamespace:   tt.goNamespace,
+				NoEmbedIDL:    true,
+			})
+			if tt.wantError != "" {
+				assert.ErrorContains(t, err, tt.wantError)
+				return
+			}
+			require.NoError(t, err)
+
+			for f, want := range tt.wantFiles {
+				got, err := os.ReadFile(filepath.Join(outputDir, f))
+				if assert.NoError(t, err, f) {
+					assert.Contains(t, string(got), want, f)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateModules(t *testing.T) {
+	thriftRoot := t.TempDir()
+	outputDir := t.TempDir()
+	for name, body := range map[string]string{
+		"a.thrift":      "include \"./shared.thrift\"\nservice A { shared.S get() }\n",
+		"b.thrift":      "include \"./shared.thrift\"\nservice B { void put(1: shared.S s) }\n",
+		"shared.thrift": "struct S {}\n",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(thriftRoot, name), []byte(body), 0o644))
+	}
+
+	modules, err := compile.CompileFiles([]string{
+		filepath.Join(thriftRoot, "a.thrift"),
+		filepath.Join(thriftRoot, "b.thrift"),
+	})
+	require.NoError(t, err)
+
+	mockCtrl := gomock.NewController(t)
+	sg := plugintest.NewMockServiceGenerator(mockCtrl)
+	sg.EXPECT().Generate(gomock.Any()).
+		DoAndReturn(func(req *api.GenerateServiceRequest) (*api.GenerateServiceResponse, error) {
+			assert.Len(t, req.RootModules, 2, "expected one request for both root modules")
+
+			var services []string
+			for _, id := range req.RootServices {
+				services = append(services, req.Services[id].Name)
+			}
+			assert.ElementsMatch(t, []string{"A", "B"}, services)
+			return &api.GenerateServiceResponse{}, nil
+		})
+
+	err = GenerateModules(modules, &Options{
+		OutputDir:     outputDir,
+		PackagePrefix: "example.com/gen",
+		ThriftRoot:    thriftRoot,
+		Plugins:       []*thriftrwplugin.Plugin{{Name: "test", ServiceGenerator: sg}},
+	})
+	require.NoError(t, err)
+
+	for _, f := range []string{"a/a.go", "b/b.go", "shared/shared.go"} {
+		_, err := os.Stat(filepath.Join(outputDir, f))
+		assert.NoError(t, err, f)
+	}
+}
+
 func TestGenerate(t *testing.T) {
 	var (
 		ts compile.TypeSpec = &compile.TypedefSpec{
@@ -346,6 +506,146 @@ func TestGenerate(t *testing.T) {
 	}
 }
 
+func TestGenerateInProcessPlugins(t *testing.T) {
+	module, err := compile.Compile("internal/tests/thrift/services.thrift")
+	require.NoError(t, err)
+
+	// newPlugin builds an in-process plugin that writes the given files.
+	newPlugin := func(mockCtrl *gomock.Controller, name string, files ...string) *thriftrwplugin.Plugin {
+		res := &api.GenerateServiceResponse{Files: make(map[string][]byte)}
+		for _, f := range files {
+			res.Files[f] = []byte(name + "\n")
+		}
+
+		sg := plugintest.NewMockServiceGenerator(mockCtrl)
+		sg.EXPECT().Generate(newRootModulesMatcher([]api.ModuleID{1})).Return(res, nil).AnyTimes()
+		return &thriftrwplugin.Plugin{Name: name, ServiceGenerator: sg}
+	}
+
+	tests := []struct {
+		desc    string
+		plugin  func(*gomock.Controller) api.ServiceGenerator
+		plugins func(*gomock.Controller) []*thriftrwplugin.Plugin
+		options map[string]map[string]string
+
+		wantFiles map[string]string
+		wantError string
+	}{
+		{
+			desc: "single plugin",
+			plugins: func(mockCtrl *gomock.Controller) []*thriftrwplugin.Plugin {
+				return []*thriftrwplugin.Plugin{newPlugin(mockCtrl, "foo", "foo.txt")}
+			},
+			wantFiles: map[string]string{"foo.txt": "foo\n"},
+		},
+		{
+			desc: "with out-of-process plugins",
+			plugin: func(mockCtrl *gomock.Controller) api.ServiceGenerator {
+				return plugin.NewInProcessHandle("external", newPlugin(mockCtrl, "external", "external.txt").ServiceGenerator, nil).
+					ServiceGenerator()
+			},
+			plugins: func(mockCtrl *gomock.Controller) []*thriftrwplugin.Plugin {
+				return []*thriftrwplugin.Plugin{
+					newPlugin(mockCtrl, "foo", "foo.txt"),
+					newPlugin(mockCtrl, "bar", "bar/bar.txt"),
+					{Name: "no-services"},
+				}
+			},
+			wantFiles: map[string]string{
+				"external.txt": "external\n",
+				"foo.txt":      "foo\n",
+				"bar/bar.txt":  "bar\n",
+			},
+		},
+		{
+			desc: "conflict",
+			plugins: func(mockCtrl *gomock.Controller) []*thriftrwplugin.Plugin {
+				return []*thriftrwplugin.Plugin{
+					newPlugin(mockCtrl, "foo", "foo.txt"),
+					newPlugin(mockCtrl, "bar", "foo.txt"),
+				}
+			},
+			wantError: `plugin conflict: cannot write file "foo.txt" for plugin`,
+		},
+		{
+			desc: "parent directory",
+			plugins: func(mockCtrl *gomock.Controller) []*thriftrwplugin.Plugin {
+				return []*thriftrwplugin.Plugin{newPlugin(mockCtrl, "foo", "../foo.txt")}
+			},
+			wantError: `plugin "foo" is attempting to write to a parent directory`,
+		},
+		{
+			desc: "missing name",
+			plugins: func(mockCtrl *gomock.Controller) []*thriftrwplugin.Plugin {
+				return []*thriftrwplugin.Plugin{newPlugin(mockCtrl, "")}
+			},
+			wantError: "in-process plugins must have a name",
+		},
+		{
+			desc: "duplicate name",
+			plugins: func(mockCtrl *gomock.Controller) []*thriftrwplugin.Plugin {
+				return []*thriftrwplugin.Plugin{
+					newPlugin(mockCtrl, "foo"),
+					newPlugin(mockCtrl, "foo"),
+				}
+			},
+			wantError: `in-process plugin "foo" was provided more than once`,
+		},
+		{
+			desc: "invalid option",
+			plugins: func(mockCtrl *gomock.Controller) []*thriftrwplugin.Plugin {
+				p := newPlugin(mockCtrl, "foo")
+				p.Options = []*api.Option{{Name: "count", Type: api.OptionTypeInt}}
+				return []*thriftrwplugin.Plugin{p}
+			},
+			options:   map[string]map[string]string{"foo": {"count": "many"}},
+			wantError: `invalid options for in-process plugin "foo": invalid value for option "count"`,
+		},
+		{
+			desc: "options for unknown plugin",
+			plugins: func(mockCtrl *gomock.Controller) []*thriftrwplugin.Plugin {
+				return []*thriftrwplugin.Plugin{newPlugin(mockCtrl, "foo")}
+			},
+			options:   map[string]map[string]string{"bar": {"count": "1"}},
+			wantError: `options were provided for unknown in-process plugin "bar"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			outputDir := t.TempDir()
+
+			var p CodeGenerator
+			if tt.plugin != nil {
+				p.ServiceGenerator = tt.plugin(mockCtrl)
+			}
+
+			err := Generate(module, &Options{
+				OutputDir:     outputDir,
+				PackagePrefix: "go.uber.org/thriftrw/gen/internal/tests",
+				ThriftRoot:    testdata(t, "thrift"),
+				NoRecurse:     true,
+				Plugin:        p,
+				Plugins:       tt.plugins(mockCtrl),
+				PluginOptions: tt.options,
+			})
+			if tt.wantError != "" {
+				assert.ErrorContains(t, err, tt.wantError)
+				return
+			}
+
+			require.NoError(t, err)
+			for f, want := range tt.wantFiles {
+				got, err := os.ReadFile(filepath.Join(outputDir, f))
+				if assert.NoError(t, err, f) {
+					assert.Equal(t, want, string(got), f)
+				}
+			}
+		})
+	}
+}
+
 func TestGenerateModule(t *testing.T) {
 	t.Run("module data should be added to the GenerateServiceBuilder even if the Thrift module contains no service data", func(t *testing.T) {
 		thriftRoot := testdata(t, "thrift")
@@ -367,7 +667,7 @@ func TestGenerateModule(t *testing.T) {
 			ThriftRoot:    thriftRoot,
 		}
 
-		_, _, err = generateModule(module, importer, genBuilder, opt)
+		_, err = generateModule(module, importer, genBuilder, opt)
 		require.NoError(t, err)
 
 		gen := genBuilder.Build()
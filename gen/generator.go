@@ -137,6 +137,11 @@ type generator struct {
 
 	fset                  *token.FileSet
 	enumTextMarshalStrict bool
+	setters               bool
+	genericPtr            bool
+	binaryMarshaler       bool
+	reuseDecode           bool
+	iterators             bool
 
 	// TODO use something to group related decls together
 }
@@ -149,6 +154,11 @@ type GeneratorOptions struct {
 
 	NoZap                 bool
 	EnumTextMarshalStrict bool
+	Setters               bool
+	GenericPtr            bool
+	BinaryMarshaler       bool
+	ReuseDecode           bool
+	Iterators             bool
 }
 
 // NewGenerator sets up a new generator for Go code.
@@ -165,6 +175,11 @@ func NewGenerator(o *GeneratorOptions) Generator {
 		fset:                  token.NewFileSet(),
 		noZap:                 o.NoZap,
 		enumTextMarshalStrict: o.EnumTextMarshalStrict,
+		setters:               o.Setters,
+		genericPtr:            o.GenericPtr,
+		binaryMarshaler:       o.BinaryMarshaler,
+		reuseDecode:           o.ReuseDecode,
+		iterators:             o.Iterators,
 	}
 }
 
@@ -183,6 +198,61 @@ func checkEnumTextMarshalStrict(g Generator) bool {
 	return false
 }
 
+// checkSetters returns whether setters should be generated for struct fields.
+func checkSetters(g Generator) bool {
+	if gen, ok := g.(*generator); ok {
+		return gen.setters
+	}
+	return false
+}
+
+// checkGenericPtr returns whether the generic helpers from the ptr package
+// should be used in generated code.
+func checkGenericPtr(g Generator) bool {
+	if gen, ok := g.(*generator); ok {
+		return gen.genericPtr
+	}
+	return false
+}
+
+// checkBinaryMarshaler returns whether binary marshaling methods should be
+// generated for structs.
+func checkBinaryMarshaler(g Generator) bool {
+	if gen, ok := g.(*generator); ok {
+		return gen.binaryMarshaler
+	}
+	return false
+}
+
+// checkReuseDecode returns whether Decode methods should reuse the storage
+// of previously decoded values.
+func checkReuseDecode(g Generator) bool {
+	if gen, ok := g.(*generator); ok {
+		return gen.reuseDecode
+	}
+	return false
+}
+
+// checkIterators returns whether iterator methods should be generated for
+// list, set, and map fields.
+func checkIterators(g Generator) bool {
+	if gen, ok := g.(*generator); ok {
+		return gen.iterators
+	}
+	return false
+}
+
+// isLocalType returns true if the given user-defined type is declared in the
+// package being generated.
+func isLocalType(g Generator, spec compile.TypeSpec) bool {
+	gen, ok := g.(*generator)
+	if !ok {
+		return false
+	}
+	importPath, err := gen.thriftImporter.Package(spec.ThriftFile())
+	return err == nil && importPath == gen.ImportPath
+}
+
 func (g *generator) MangleType(t compile.TypeSpec) string {
 	return g.mangler.MangleType(t)
 }
@@ -238,6 +308,7 @@ func (g *generator) TextTemplate(s string, data interface{}, opts ...TemplateOpt
 		"isPrimitiveType":  isPrimitiveType,
 		"isStringType":     isStringType,
 		"isStructType":     isStructType,
+		"isReusable":       isReusable,
 		"newNamespace":     g.Namespace.Child,
 		"newVar":           g.Namespace.Child().NewName,
 		"typeName":         curryGenerator(typeName, g),
@@ -251,6 +322,7 @@ func (g *generator) TextTemplate(s string, data interface{}, opts ...TemplateOpt
 		"toWirePtr":        curryGenerator(g.w.ToWirePtr, g),
 		"decode":           curryGenerator(g.s.Decode, g),
 		"decodePtr":        curryGenerator(g.s.DecodePtr, g),
+		"decodeReuse":      curryGenerator(g.s.DecodeReuse, g),
 		"typeCode":         curryGenerator(TypeCode, g),
 		"equals":           curryGenerator(g.e.Equals, g),
 		"equalsPtr":        curryGenerator(g.e.EqualsPtr, g),
@@ -504,7 +576,7 @@ func (g *generator) Write(w io.Writer, _ *token.FileSet) error {
 		Tabwidth: 8,
 	}
 
-	if importDecl := g.importDecl(); importDecl != nil {
+	if importDecl := g.importDecl(usedPackageNames(g.decls)); importDecl != nil {
 		if err := cfg.Fprint(w, g.fset, importDecl); err != nil {
 			return err
 		}
@@ -537,6 +609,48 @@ func (g *generator) Write(w io.Writer, _ *token.FileSet) error {
 	return nil
 }
 
+// declsSize returns the size of the pending declarations starting at the
+// given index, as printed by Write.
+func (g *generator) declsSize(from int) (int, error) {
+	cfg := printer.Config{
+		Mode:     printer.UseSpaces | printer.TabIndent,
+		Tabwidth: 8,
+	}
+
+	var w countingWriter
+	for _, decl := range g.decls[from:] {
+		if err := cfg.Fprint(&w, g.fset, decl); err != nil {
+			return 0, err
+		}
+		w.n += 2 // surrounding newlines
+	}
+	return w.n, nil
+}
+
+type countingWriter struct{ n int }
+
+func (w *countingWriter) Write(b []byte) (int, error) {
+	w.n += len(b)
+	return len(b), nil
+}
+
+// usedPackageNames returns the names of packages referenced by the given
+// declarations.
+func usedPackageNames(decls []ast.Decl) map[string]struct{} {
+	names := make(map[string]struct{})
+	for _, decl := range decls {
+		ast.Inspect(decl, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if id, ok := sel.X.(*ast.Ident); ok {
+					names[id.Name] = struct{}{}
+				}
+			}
+			return true
+		})
+	}
+	return names
+}
+
 // appendDecl appends a new declaration to the generator.
 func (g *generator) appendDecl(decl ast.Decl) {
 	g.decls = append(g.decls, decl)
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"testing"
+
+	tgp "go.uber.org/thriftrw/gen/internal/tests/genericptr"
+	"go.uber.org/thriftrw/ptr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenericPtrGetters(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		var s *tgp.Settings
+		assert.True(t, s.GetEnabled())
+		assert.Equal(t, int32(100), s.GetTimeout())
+		assert.Equal(t, tgp.LevelMedium, s.GetLevel())
+		assert.Equal(t, tgp.Name("root"), s.GetOwner())
+		assert.Equal(t, "", s.GetComment())
+		assert.Equal(t, tgp.LevelLow, s.GetFallback())
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		s := &tgp.Settings{}
+		assert.Equal(t, int16(8080), s.GetPort())
+		assert.Equal(t, "localhost", s.GetHost())
+		assert.Equal(t, tgp.Timestamp(1), s.GetCreatedAt())
+		assert.Equal(t, "", s.GetComment())
+	})
+
+	t.Run("set", func(t *testing.T) {
+		s := &tgp.Settings{
+			Enabled:  ptr.Of(false),
+			Port:     ptr.Of[int16](0),
+			Host:     ptr.Of("example.com"),
+			Owner:    ptr.Of[tgp.Name]("admin"),
+			Comment:  ptr.Of("hello"),
+			Fallback: tgp.LevelHigh.Ptr(),
+		}
+		assert.False(t, s.GetEnabled())
+		assert.Equal(t, int16(0), s.GetPort())
+		assert.Equal(t, "example.com", s.GetHost())
+		assert.Equal(t, tgp.Name("admin"), s.GetOwner())
+		assert.Equal(t, "hello", s.GetComment())
+		assert.Equal(t, tgp.LevelHigh, s.GetFallback())
+	})
+}
+
+func TestGenericPtrDefaults(t *testing.T) {
+	s := tgp.Default_Settings()
+	assert.Equal(t, &tgp.Settings{
+		Enabled:   ptr.Of(true),
+		Retries:   ptr.Of[int8](3),
+		Port:      ptr.Of[int16](8080),
+		Timeout:   ptr.Of[int32](100),
+		Limit:     ptr.Of[int64](1000),
+		Ratio:     ptr.Of(0.5),
+		Host:      ptr.Of("localhost"),
+		Level:     tgp.LevelMedium.Ptr(),
+		Owner:     tgp.Name("root").Ptr(),
+		CreatedAt: tgp.Timestamp(1).Ptr(),
+	}, s)
+
+	assert.Equal(t, "example.com", tgp.DefaultSettings.GetHost())
+	assert.Equal(t, tgp.LevelHigh, tgp.DefaultSettings.GetLevel())
+	assert.Equal(t, int32(100), tgp.DefaultSettings.GetTimeout())
+}
+
+func TestGenericPtrRoundTrip(t *testing.T) {
+	s := &tgp.Settings{
+		Comment: ptr.Of("foo"),
+		Region:  "us-west-1",
+	}
+
+	v, err := s.ToWire()
+	if assert.NoError(t, err) {
+		var got tgp.Settings
+		assert.NoError(t, got.FromWire(v))
+		assert.Equal(t, "foo", got.GetComment())
+		assert.Equal(t, "us-west-1", got.GetRegion())
+		assert.Equal(t, "localhost", got.GetHost())
+		assert.True(t, got.IsSetHost(), "defaults must be filled on decode")
+	}
+}
@@ -45,6 +45,31 @@ var enumTextMarshalStrictFiles = map[string]struct{}{
 	"enum-text-marshal-strict": {},
 }
 
+// Set of files that are passed a --setters flag in code generation
+var settersFiles = map[string]struct{}{
+	"setters": {},
+}
+
+// Set of files that are passed a --generic-ptr flag in code generation
+var genericPtrFiles = map[string]struct{}{
+	"genericptr": {},
+}
+
+// Set of files that are passed a --binary-marshaler flag in code generation
+var binaryMarshalerFiles = map[string]struct{}{
+	"binarymarshaler": {},
+}
+
+// Set of files that are passed a --reuse-decode flag in code generation
+var reuseDecodeFiles = map[string]struct{}{
+	"reusedecode": {},
+}
+
+// Set of files that are passed a --iterators flag in code generation
+var iteratorsFiles = map[string]struct{}{
+	"iterators": {},
+}
+
 func TestCodeIsUpToDate(t *testing.T) {
 	// This test just verifies that the generated code in internal/tests/ is up to
 	// date. If this test failed, run 'make' in the internal/tests/ directory and
@@ -73,6 +98,11 @@ func TestCodeIsUpToDate(t *testing.T) {
 
 		_, nozap := noZapFiles[pkgRelPath]
 		_, enumTextMarshalStrict := enumTextMarshalStrictFiles[pkgRelPath]
+		_, setters := settersFiles[pkgRelPath]
+		_, genericPtr := genericPtrFiles[pkgRelPath]
+		_, binaryMarshaler := binaryMarshalerFiles[pkgRelPath]
+		_, reuseDecode := reuseDecodeFiles[pkgRelPath]
+		_, iterators := iteratorsFiles[pkgRelPath]
 		err = Generate(module, &Options{
 			OutputDir:             outputDir,
 			PackagePrefix:         "go.uber.org/thriftrw/gen/internal/tests",
@@ -80,6 +110,11 @@ func TestCodeIsUpToDate(t *testing.T) {
 			NoRecurse:             true,
 			NoZap:                 nozap,
 			EnumTextMarshalStrict: enumTextMarshalStrict,
+			Setters:               setters,
+			GenericPtr:            genericPtr,
+			BinaryMarshaler:       binaryMarshaler,
+			ReuseDecode:           reuseDecode,
+			Iterators:             iterators,
 		})
 		require.NoError(t, err, "failed to generate code for %q", thriftFile)
 
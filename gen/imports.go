@@ -83,7 +83,11 @@ func (i importer) Import(path string) string {
 }
 
 // importDecl builds an import declation from the given list of imports.
-func (i importer) importDecl() ast.Decl {
+//
+// Imports whose names are not in used are left out. These may be imported
+// by declarations that were skipped because they were already declared in
+// another file.
+func (i importer) importDecl(used map[string]struct{}) ast.Decl {
 	imports := i.imports
 	if len(imports) == 0 {
 		return nil
@@ -92,8 +96,16 @@ func (i importer) importDecl() ast.Decl {
 	specs := make([]ast.Spec, 0, len(imports))
 	for _, iname := range sortStringKeys(imports) {
 		imp := imports[iname]
+		if imp.Name != nil {
+			if _, ok := used[imp.Name.Name]; !ok {
+				continue
+			}
+		}
 		specs = append(specs, imp)
 	}
+	if len(specs) == 0 {
+		return nil
+	}
 
 	decl := &ast.GenDecl{Tok: token.IMPORT, Specs: specs}
 	if len(specs) > 1 {
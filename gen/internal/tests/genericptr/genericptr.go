@@ -0,0 +1,1638 @@
+// Code generated by thriftrw v1.34.0. DO NOT EDIT.
+// @generated
+
+package genericptr
+
+import (
+	bytes "bytes"
+	json "encoding/json"
+	errors "errors"
+	fmt "fmt"
+	multierr "go.uber.org/multierr"
+	stream "go.uber.org/thriftrw/protocol/stream"
+	ptr "go.uber.org/thriftrw/ptr"
+	thriftreflect "go.uber.org/thriftrw/thriftreflect"
+	wire "go.uber.org/thriftrw/wire"
+	zapcore "go.uber.org/zap/zapcore"
+	math "math"
+	strconv "strconv"
+	strings "strings"
+)
+
+var DefaultSettings *Settings = &Settings{
+	CreatedAt: ptr.Of[Timestamp](Timestamp(1)),
+	Enabled:   ptr.Of[bool](false),
+	Host:      ptr.Of[string]("example.com"),
+	Level:     ptr.Of[Level](LevelHigh),
+	Limit:     ptr.Of[int64](1000),
+	Owner:     ptr.Of[Name]("admin"),
+	Port:      ptr.Of[int16](8080),
+	Ratio:     ptr.Of[float64](0.5),
+	Region:    "us-east-1",
+	Retries:   ptr.Of[int8](3),
+	Timeout:   ptr.Of[int32](100),
+}
+
+type Level int32
+
+const (
+	LevelLow    Level = 0
+	LevelMedium Level = 1
+	LevelHigh   Level = 2
+)
+
+// Level_Values returns all recognized values of Level.
+func Level_Values() []Level {
+	return []Level{
+		LevelLow,
+		LevelMedium,
+		LevelHigh,
+	}
+}
+
+// UnmarshalText tries to decode Level from a byte slice
+// containing its name.
+//
+//	var v Level
+//	err := v.UnmarshalText([]byte("Low"))
+func (v *Level) UnmarshalText(value []byte) error {
+	switch s := string(value); s {
+	case "Low":
+		*v = LevelLow
+		return nil
+	case "Medium":
+		*v = LevelMedium
+		return nil
+	case "High":
+		*v = LevelHigh
+		return nil
+	default:
+		val, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", s, "Level", err)
+		}
+		*v = Level(val)
+		return nil
+	}
+}
+
+// MarshalText encodes Level to text.
+//
+// If the enum value is recognized, its name is returned.
+// Otherwise, its integer value is returned.
+//
+// This implements the TextMarshaler interface.
+func (v Level) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("Low"), nil
+	case 1:
+		return []byte("Medium"), nil
+	case 2:
+		return []byte("High"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of Level.
+// Enums are logged as objects, where the value is logged with key "value", and
+// if this value's name is known, the name is logged with key "name".
+func (v Level) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt32("value", int32(v))
+	switch int32(v) {
+	case 0:
+		enc.AddString("name", "Low")
+	case 1:
+		enc.AddString("name", "Medium")
+	case 2:
+		enc.AddString("name", "High")
+	}
+	return nil
+}
+
+// Ptr returns a pointer to this enum value.
+func (v Level) Ptr() *Level {
+	return &v
+}
+
+// Encode encodes Level directly to bytes.
+//
+//	sWriter := BinaryStreamer.Writer(writer)
+//
+//	var v Level
+//	return v.Encode(sWriter)
+func (v Level) Encode(sw stream.Writer) error {
+	return sw.WriteInt32(int32(v))
+}
+
+// ToWire translates Level into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// Enums are represented as 32-bit integers over the wire.
+func (v Level) ToWire() (wire.Value, error) {
+	return wire.NewValueI32(int32(v)), nil
+}
+
+// FromWire deserializes Level from its Thrift-level
+// representation.
+//
+//	x, err := binaryProtocol.Decode(reader, wire.TI32)
+//	if err != nil {
+//	    return Level(0), err
+//	}
+//
+//	var v Level
+//	if err := v.FromWire(x); err != nil {
+//	    return Level(0), err
+//	}
+//	return v, nil
+func (v *Level) FromWire(w wire.Value) error {
+	*v = (Level)(w.GetI32())
+	return nil
+}
+
+// Decode reads off the encoded Level directly off of the wire.
+//
+//	sReader := BinaryStreamer.Reader(reader)
+//
+//	var v Level
+//	if err := v.Decode(sReader); err != nil {
+//	    return Level(0), err
+//	}
+//	return v, nil
+func (v *Level) Decode(sr stream.Reader) error {
+	i, err := sr.ReadInt32()
+	if err != nil {
+		return err
+	}
+	*v = (Level)(i)
+	return nil
+}
+
+// String returns a readable string representation of Level.
+func (v Level) String() string {
+	w := int32(v)
+	switch w {
+	case 0:
+		return "Low"
+	case 1:
+		return "Medium"
+	case 2:
+		return "High"
+	}
+	return fmt.Sprintf("Level(%d)", w)
+}
+
+// Equals returns true if this Level value matches the provided
+// value.
+func (v Level) Equals(rhs Level) bool {
+	return v == rhs
+}
+
+// MarshalJSON serializes Level into JSON.
+//
+// If the enum value is recognized, its name is returned.
+// Otherwise, its integer value is returned.
+//
+// This implements json.Marshaler.
+func (v Level) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return ([]byte)("\"Low\""), nil
+	case 1:
+		return ([]byte)("\"Medium\""), nil
+	case 2:
+		return ([]byte)("\"High\""), nil
+	}
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// UnmarshalJSON attempts to decode Level from its JSON
+// representation.
+//
+// This implementation supports both, numeric and string inputs. If a
+// string is provided, it must be a known enum name.
+//
+// This implements json.Unmarshaler.
+func (v *Level) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	switch w := t.(type) {
+	case json.Number:
+		x, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "Level")
+		}
+		if x < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "Level")
+		}
+		*v = (Level)(x)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "Level")
+	}
+}
+
+type Name string
+
+// NamePtr returns a pointer to a Name
+func (v Name) Ptr() *Name {
+	return &v
+}
+
+// ToWire translates Name into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+func (v Name) ToWire() (wire.Value, error) {
+	x := (string)(v)
+	return wire.NewValueString(x), error(nil)
+}
+
+// String returns a readable string representation of Name.
+func (v Name) String() string {
+	x := (string)(v)
+	return (string)(x)
+}
+
+func (v Name) Encode(sw stream.Writer) error {
+	x := (string)(v)
+	return sw.WriteString(x)
+}
+
+// FromWire deserializes Name from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+func (v *Name) FromWire(w wire.Value) error {
+	x, err := w.GetString(), error(nil)
+	*v = (Name)(x)
+	return err
+}
+
+// Decode deserializes Name directly off the wire.
+func (v *Name) Decode(sr stream.Reader) error {
+	x, err := sr.ReadString()
+	*v = (Name)(x)
+	return err
+}
+
+// Equals returns true if this Name is equal to the provided
+// Name.
+func (lhs Name) Equals(rhs Name) bool {
+	return ((string)(lhs) == (string)(rhs))
+}
+
+type Settings struct {
+	Enabled   *bool      `json:"enabled,omitempty"`
+	Retries   *int8      `json:"retries,omitempty"`
+	Port      *int16     `json:"port,omitempty"`
+	Timeout   *int32     `json:"timeout,omitempty"`
+	Limit     *int64     `json:"limit,omitempty"`
+	Ratio     *float64   `json:"ratio,omitempty"`
+	Host      *string    `json:"host,omitempty"`
+	Level     *Level     `json:"level,omitempty"`
+	Owner     *Name      `json:"owner,omitempty"`
+	CreatedAt *Timestamp `json:"createdAt,omitempty"`
+	Comment   *string    `json:"comment,omitempty"`
+	Fallback  *Level     `json:"fallback,omitempty"`
+	Region    string     `json:"region,required"`
+}
+
+// Default_Settings constructs a new Settings struct,
+// pre-populating any fields with defined default values.
+func Default_Settings() *Settings {
+	var v Settings
+	v.Enabled = ptr.Of[bool](true)
+	v.Retries = ptr.Of[int8](3)
+	v.Port = ptr.Of[int16](8080)
+	v.Timeout = ptr.Of[int32](100)
+	v.Limit = ptr.Of[int64](1000)
+	v.Ratio = ptr.Of[float64](0.5)
+	v.Host = ptr.Of[string]("localhost")
+	v.Level = ptr.Of[Level](LevelMedium)
+	v.Owner = ptr.Of[Name]("root")
+	v.CreatedAt = ptr.Of[Timestamp](Timestamp(1))
+	return &v
+}
+
+// ToWire translates a Settings struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//	x, err := v.ToWire()
+//	if err != nil {
+//		return err
+//	}
+//
+//	if err := binaryProtocol.Encode(x, writer); err != nil {
+//		return err
+//	}
+func (v *Settings) ToWire() (wire.Value, error) {
+	var (
+		fields [13]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	vEnabled := v.Enabled
+	if vEnabled == nil {
+		vEnabled = ptr.Of[bool](true)
+	}
+	{
+		w, err = wire.NewValueBool(*(vEnabled)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	vRetries := v.Retries
+	if vRetries == nil {
+		vRetries = ptr.Of[int8](3)
+	}
+	{
+		w, err = wire.NewValueI8(*(vRetries)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	vPort := v.Port
+	if vPort == nil {
+		vPort = ptr.Of[int16](8080)
+	}
+	{
+		w, err = wire.NewValueI16(*(vPort)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	vTimeout := v.Timeout
+	if vTimeout == nil {
+		vTimeout = ptr.Of[int32](100)
+	}
+	{
+		w, err = wire.NewValueI32(*(vTimeout)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	vLimit := v.Limit
+	if vLimit == nil {
+		vLimit = ptr.Of[int64](1000)
+	}
+	{
+		w, err = wire.NewValueI64(*(vLimit)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+	vRatio := v.Ratio
+	if vRatio == nil {
+		vRatio = ptr.Of[float64](0.5)
+	}
+	{
+		w, err = wire.NewValueDouble(*(vRatio)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 6, Value: w}
+		i++
+	}
+	vHost := v.Host
+	if vHost == nil {
+		vHost = ptr.Of[string]("localhost")
+	}
+	{
+		w, err = wire.NewValueString(*(vHost)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 7, Value: w}
+		i++
+	}
+	vLevel := v.Level
+	if vLevel == nil {
+		vLevel = ptr.Of[Level](LevelMedium)
+	}
+	{
+		w, err = vLevel.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 8, Value: w}
+		i++
+	}
+	vOwner := v.Owner
+	if vOwner == nil {
+		vOwner = ptr.Of[Name]("root")
+	}
+	{
+		w, err = vOwner.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 9, Value: w}
+		i++
+	}
+	vCreatedAt := v.CreatedAt
+	if vCreatedAt == nil {
+		vCreatedAt = ptr.Of[Timestamp](Timestamp(1))
+	}
+	{
+		w, err = vCreatedAt.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Comment != nil {
+		w, err = wire.NewValueString(*(v.Comment)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 11, Value: w}
+		i++
+	}
+	if v.Fallback != nil {
+		w, err = v.Fallback.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 12, Value: w}
+		i++
+	}
+
+	w, err = wire.NewValueString(v.Region), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 13, Value: w}
+	i++
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Level_Read(w wire.Value) (Level, error) {
+	var v Level
+	err := v.FromWire(w)
+	return v, err
+}
+
+func _Name_Read(w wire.Value) (Name, error) {
+	var x Name
+	err := x.FromWire(w)
+	return x, err
+}
+
+func _Timestamp_Read(w wire.Value) (Timestamp, error) {
+	var x Timestamp
+	err := x.FromWire(w)
+	return x, err
+}
+
+// FromWire deserializes a Settings struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a Settings struct
+// from the provided intermediate representation.
+//
+//	x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//	if err != nil {
+//		return nil, err
+//	}
+//
+//	var v Settings
+//	if err := v.FromWire(x); err != nil {
+//		return nil, err
+//	}
+//	return &v, nil
+func (v *Settings) FromWire(w wire.Value) error {
+	var err error
+
+	regionIsSet := false
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Enabled = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TI8 {
+				var x int8
+				x, err = field.Value.GetI8(), error(nil)
+				v.Retries = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TI16 {
+				var x int16
+				x, err = field.Value.GetI16(), error(nil)
+				v.Port = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Timeout = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 5:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Limit = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 6:
+			if field.Value.Type() == wire.TDouble {
+				var x float64
+				x, err = field.Value.GetDouble(), error(nil)
+				v.Ratio = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 7:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Host = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 8:
+			if field.Value.Type() == wire.TI32 {
+				var x Level
+				x, err = _Level_Read(field.Value)
+				v.Level = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 9:
+			if field.Value.Type() == wire.TBinary {
+				var x Name
+				x, err = _Name_Read(field.Value)
+				v.Owner = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 10:
+			if field.Value.Type() == wire.TI64 {
+				var x Timestamp
+				x, err = _Timestamp_Read(field.Value)
+				v.CreatedAt = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 11:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Comment = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 12:
+			if field.Value.Type() == wire.TI32 {
+				var x Level
+				x, err = _Level_Read(field.Value)
+				v.Fallback = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 13:
+			if field.Value.Type() == wire.TBinary {
+				v.Region, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					return err
+				}
+				regionIsSet = true
+			}
+		}
+	}
+
+	if v.Enabled == nil {
+		v.Enabled = ptr.Of[bool](true)
+	}
+
+	if v.Retries == nil {
+		v.Retries = ptr.Of[int8](3)
+	}
+
+	if v.Port == nil {
+		v.Port = ptr.Of[int16](8080)
+	}
+
+	if v.Timeout == nil {
+		v.Timeout = ptr.Of[int32](100)
+	}
+
+	if v.Limit == nil {
+		v.Limit = ptr.Of[int64](1000)
+	}
+
+	if v.Ratio == nil {
+		v.Ratio = ptr.Of[float64](0.5)
+	}
+
+	if v.Host == nil {
+		v.Host = ptr.Of[string]("localhost")
+	}
+
+	if v.Level == nil {
+		v.Level = ptr.Of[Level](LevelMedium)
+	}
+
+	if v.Owner == nil {
+		v.Owner = ptr.Of[Name]("root")
+	}
+
+	if v.CreatedAt == nil {
+		v.CreatedAt = ptr.Of[Timestamp](Timestamp(1))
+	}
+
+	if !regionIsSet {
+		return errors.New("field Region of Settings is required")
+	}
+
+	return nil
+}
+
+// Encode serializes a Settings struct directly into bytes, without going
+// through an intermediary type.
+//
+// An error is returned if a Settings struct could not be encoded.
+func (v *Settings) Encode(sw stream.Writer) error {
+	if err := sw.WriteStructBegin(); err != nil {
+		return err
+	}
+
+	vEnabled := v.Enabled
+	if vEnabled == nil {
+		vEnabled = ptr.Of[bool](true)
+	}
+	{
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 1, Type: wire.TBool}); err != nil {
+			return err
+		}
+		if err := sw.WriteBool(*(vEnabled)); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	vRetries := v.Retries
+	if vRetries == nil {
+		vRetries = ptr.Of[int8](3)
+	}
+	{
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 2, Type: wire.TI8}); err != nil {
+			return err
+		}
+		if err := sw.WriteInt8(*(vRetries)); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	vPort := v.Port
+	if vPort == nil {
+		vPort = ptr.Of[int16](8080)
+	}
+	{
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 3, Type: wire.TI16}); err != nil {
+			return err
+		}
+		if err := sw.WriteInt16(*(vPort)); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	vTimeout := v.Timeout
+	if vTimeout == nil {
+		vTimeout = ptr.Of[int32](100)
+	}
+	{
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 4, Type: wire.TI32}); err != nil {
+			return err
+		}
+		if err := sw.WriteInt32(*(vTimeout)); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	vLimit := v.Limit
+	if vLimit == nil {
+		vLimit = ptr.Of[int64](1000)
+	}
+	{
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 5, Type: wire.TI64}); err != nil {
+			return err
+		}
+		if err := sw.WriteInt64(*(vLimit)); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	vRatio := v.Ratio
+	if vRatio == nil {
+		vRatio = ptr.Of[float64](0.5)
+	}
+	{
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 6, Type: wire.TDouble}); err != nil {
+			return err
+		}
+		if err := sw.WriteDouble(*(vRatio)); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	vHost := v.Host
+	if vHost == nil {
+		vHost = ptr.Of[string]("localhost")
+	}
+	{
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 7, Type: wire.TBinary}); err != nil {
+			return err
+		}
+		if err := sw.WriteString(*(vHost)); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	vLevel := v.Level
+	if vLevel == nil {
+		vLevel = ptr.Of[Level](LevelMedium)
+	}
+	{
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 8, Type: wire.TI32}); err != nil {
+			return err
+		}
+		if err := vLevel.Encode(sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	vOwner := v.Owner
+	if vOwner == nil {
+		vOwner = ptr.Of[Name]("root")
+	}
+	{
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 9, Type: wire.TBinary}); err != nil {
+			return err
+		}
+		if err := vOwner.Encode(sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	vCreatedAt := v.CreatedAt
+	if vCreatedAt == nil {
+		vCreatedAt = ptr.Of[Timestamp](Timestamp(1))
+	}
+	{
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 10, Type: wire.TI64}); err != nil {
+			return err
+		}
+		if err := vCreatedAt.Encode(sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.Comment != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 11, Type: wire.TBinary}); err != nil {
+			return err
+		}
+		if err := sw.WriteString(*(v.Comment)); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.Fallback != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 12, Type: wire.TI32}); err != nil {
+			return err
+		}
+		if err := v.Fallback.Encode(sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 13, Type: wire.TBinary}); err != nil {
+		return err
+	}
+	if err := sw.WriteString(v.Region); err != nil {
+		return err
+	}
+	if err := sw.WriteFieldEnd(); err != nil {
+		return err
+	}
+
+	return sw.WriteStructEnd()
+}
+
+func _Level_Decode(sr stream.Reader) (Level, error) {
+	var v Level
+	err := v.Decode(sr)
+	return v, err
+}
+
+func _Name_Decode(sr stream.Reader) (Name, error) {
+	var x Name
+	err := x.Decode(sr)
+	return x, err
+}
+
+func _Timestamp_Decode(sr stream.Reader) (Timestamp, error) {
+	var x Timestamp
+	err := x.Decode(sr)
+	return x, err
+}
+
+// Decode deserializes a Settings struct directly from its Thrift-level
+// representation, without going through an intemediary type.
+//
+// An error is returned if a Settings struct could not be generated from the wire
+// representation.
+func (v *Settings) Decode(sr stream.Reader) error {
+
+	regionIsSet := false
+
+	if err := sr.ReadStructBegin(); err != nil {
+		return err
+	}
+
+	fh, ok, err := sr.ReadFieldBegin()
+	if err != nil {
+		return err
+	}
+
+	for ok {
+		switch {
+		case fh.ID == 1 && fh.Type == wire.TBool:
+			var x bool
+			x, err = sr.ReadBool()
+			v.Enabled = &x
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 2 && fh.Type == wire.TI8:
+			var x int8
+			x, err = sr.ReadInt8()
+			v.Retries = &x
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 3 && fh.Type == wire.TI16:
+			var x int16
+			x, err = sr.ReadInt16()
+			v.Port = &x
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 4 && fh.Type == wire.TI32:
+			var x int32
+			x, err = sr.ReadInt32()
+			v.Timeout = &x
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 5 && fh.Type == wire.TI64:
+			var x int64
+			x, err = sr.ReadInt64()
+			v.Limit = &x
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 6 && fh.Type == wire.TDouble:
+			var x float64
+			x, err = sr.ReadDouble()
+			v.Ratio = &x
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 7 && fh.Type == wire.TBinary:
+			var x string
+			x, err = sr.ReadString()
+			v.Host = &x
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 8 && fh.Type == wire.TI32:
+			var x Level
+			x, err = _Level_Decode(sr)
+			v.Level = &x
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 9 && fh.Type == wire.TBinary:
+			var x Name
+			x, err = _Name_Decode(sr)
+			v.Owner = &x
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 10 && fh.Type == wire.TI64:
+			var x Timestamp
+			x, err = _Timestamp_Decode(sr)
+			v.CreatedAt = &x
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 11 && fh.Type == wire.TBinary:
+			var x string
+			x, err = sr.ReadString()
+			v.Comment = &x
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 12 && fh.Type == wire.TI32:
+			var x Level
+			x, err = _Level_Decode(sr)
+			v.Fallback = &x
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 13 && fh.Type == wire.TBinary:
+			v.Region, err = sr.ReadString()
+			if err != nil {
+				return err
+			}
+			regionIsSet = true
+		default:
+			if err := sr.Skip(fh.Type); err != nil {
+				return err
+			}
+		}
+
+		if err := sr.ReadFieldEnd(); err != nil {
+			return err
+		}
+
+		if fh, ok, err = sr.ReadFieldBegin(); err != nil {
+			return err
+		}
+	}
+
+	if err := sr.ReadStructEnd(); err != nil {
+		return err
+	}
+
+	if v.Enabled == nil {
+		v.Enabled = ptr.Of[bool](true)
+	}
+
+	if v.Retries == nil {
+		v.Retries = ptr.Of[int8](3)
+	}
+
+	if v.Port == nil {
+		v.Port = ptr.Of[int16](8080)
+	}
+
+	if v.Timeout == nil {
+		v.Timeout = ptr.Of[int32](100)
+	}
+
+	if v.Limit == nil {
+		v.Limit = ptr.Of[int64](1000)
+	}
+
+	if v.Ratio == nil {
+		v.Ratio = ptr.Of[float64](0.5)
+	}
+
+	if v.Host == nil {
+		v.Host = ptr.Of[string]("localhost")
+	}
+
+	if v.Level == nil {
+		v.Level = ptr.Of[Level](LevelMedium)
+	}
+
+	if v.Owner == nil {
+		v.Owner = ptr.Of[Name]("root")
+	}
+
+	if v.CreatedAt == nil {
+		v.CreatedAt = ptr.Of[Timestamp](Timestamp(1))
+	}
+
+	if !regionIsSet {
+		return errors.New("field Region of Settings is required")
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a Settings
+// struct.
+func (v *Settings) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [13]string
+	i := 0
+	if v.Enabled != nil {
+		fields[i] = fmt.Sprintf("Enabled: %v", *(v.Enabled))
+		i++
+	}
+	if v.Retries != nil {
+		fields[i] = fmt.Sprintf("Retries: %v", *(v.Retries))
+		i++
+	}
+	if v.Port != nil {
+		fields[i] = fmt.Sprintf("Port: %v", *(v.Port))
+		i++
+	}
+	if v.Timeout != nil {
+		fields[i] = fmt.Sprintf("Timeout: %v", *(v.Timeout))
+		i++
+	}
+	if v.Limit != nil {
+		fields[i] = fmt.Sprintf("Limit: %v", *(v.Limit))
+		i++
+	}
+	if v.Ratio != nil {
+		fields[i] = fmt.Sprintf("Ratio: %v", *(v.Ratio))
+		i++
+	}
+	if v.Host != nil {
+		fields[i] = fmt.Sprintf("Host: %v", *(v.Host))
+		i++
+	}
+	if v.Level != nil {
+		fields[i] = fmt.Sprintf("Level: %v", *(v.Level))
+		i++
+	}
+	if v.Owner != nil {
+		fields[i] = fmt.Sprintf("Owner: %v", *(v.Owner))
+		i++
+	}
+	if v.CreatedAt != nil {
+		fields[i] = fmt.Sprintf("CreatedAt: %v", *(v.CreatedAt))
+		i++
+	}
+	if v.Comment != nil {
+		fields[i] = fmt.Sprintf("Comment: %v", *(v.Comment))
+		i++
+	}
+	if v.Fallback != nil {
+		fields[i] = fmt.Sprintf("Fallback: %v", *(v.Fallback))
+		i++
+	}
+	fields[i] = fmt.Sprintf("Region: %v", v.Region)
+	i++
+
+	return fmt.Sprintf("Settings{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Bool_EqualsPtr(lhs, rhs *bool) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _Byte_EqualsPtr(lhs, rhs *int8) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _I16_EqualsPtr(lhs, rhs *int16) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _I32_EqualsPtr(lhs, rhs *int32) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _I64_EqualsPtr(lhs, rhs *int64) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _Double_EqualsPtr(lhs, rhs *float64) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _String_EqualsPtr(lhs, rhs *string) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _Level_EqualsPtr(lhs, rhs *Level) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _Name_EqualsPtr(lhs, rhs *Name) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _Timestamp_EqualsPtr(lhs, rhs *Timestamp) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this Settings match the
+// provided Settings.
+//
+// This function performs a deep comparison.
+func (v *Settings) Equals(rhs *Settings) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.Enabled, rhs.Enabled) {
+		return false
+	}
+	if !_Byte_EqualsPtr(v.Retries, rhs.Retries) {
+		return false
+	}
+	if !_I16_EqualsPtr(v.Port, rhs.Port) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.Timeout, rhs.Timeout) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Limit, rhs.Limit) {
+		return false
+	}
+	if !_Double_EqualsPtr(v.Ratio, rhs.Ratio) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Host, rhs.Host) {
+		return false
+	}
+	if !_Level_EqualsPtr(v.Level, rhs.Level) {
+		return false
+	}
+	if !_Name_EqualsPtr(v.Owner, rhs.Owner) {
+		return false
+	}
+	if !_Timestamp_EqualsPtr(v.CreatedAt, rhs.CreatedAt) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Comment, rhs.Comment) {
+		return false
+	}
+	if !_Level_EqualsPtr(v.Fallback, rhs.Fallback) {
+		return false
+	}
+	if !(v.Region == rhs.Region) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of Settings.
+func (v *Settings) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Enabled != nil {
+		enc.AddBool("enabled", *v.Enabled)
+	}
+	if v.Retries != nil {
+		enc.AddInt8("retries", *v.Retries)
+	}
+	if v.Port != nil {
+		enc.AddInt16("port", *v.Port)
+	}
+	if v.Timeout != nil {
+		enc.AddInt32("timeout", *v.Timeout)
+	}
+	if v.Limit != nil {
+		enc.AddInt64("limit", *v.Limit)
+	}
+	if v.Ratio != nil {
+		enc.AddFloat64("ratio", *v.Ratio)
+	}
+	if v.Host != nil {
+		enc.AddString("host", *v.Host)
+	}
+	if v.Level != nil {
+		err = multierr.Append(err, enc.AddObject("level", *v.Level))
+	}
+	if v.Owner != nil {
+		enc.AddString("owner", (string)(*v.Owner))
+	}
+	if v.CreatedAt != nil {
+		enc.AddInt64("createdAt", (int64)(*v.CreatedAt))
+	}
+	if v.Comment != nil {
+		enc.AddString("comment", *v.Comment)
+	}
+	if v.Fallback != nil {
+		err = multierr.Append(err, enc.AddObject("fallback", *v.Fallback))
+	}
+	enc.AddString("region", v.Region)
+	return err
+}
+
+// GetEnabled returns the value of Enabled if it is set or its
+// default value if it is unset.
+func (v *Settings) GetEnabled() (o bool) {
+	o = true
+	if v != nil {
+		o = ptr.Deref(v.Enabled, o)
+	}
+	return
+}
+
+// IsSetEnabled returns true if Enabled is not nil.
+func (v *Settings) IsSetEnabled() bool {
+	return v != nil && v.Enabled != nil
+}
+
+// GetRetries returns the value of Retries if it is set or its
+// default value if it is unset.
+func (v *Settings) GetRetries() (o int8) {
+	o = 3
+	if v != nil {
+		o = ptr.Deref(v.Retries, o)
+	}
+	return
+}
+
+// IsSetRetries returns true if Retries is not nil.
+func (v *Settings) IsSetRetries() bool {
+	return v != nil && v.Retries != nil
+}
+
+// GetPort returns the value of Port if it is set or its
+// default value if it is unset.
+func (v *Settings) GetPort() (o int16) {
+	o = 8080
+	if v != nil {
+		o = ptr.Deref(v.Port, o)
+	}
+	return
+}
+
+// IsSetPort returns true if Port is not nil.
+func (v *Settings) IsSetPort() bool {
+	return v != nil && v.Port != nil
+}
+
+// GetTimeout returns the value of Timeout if it is set or its
+// default value if it is unset.
+func (v *Settings) GetTimeout() (o int32) {
+	o = 100
+	if v != nil {
+		o = ptr.Deref(v.Timeout, o)
+	}
+	return
+}
+
+// IsSetTimeout returns true if Timeout is not nil.
+func (v *Settings) IsSetTimeout() bool {
+	return v != nil && v.Timeout != nil
+}
+
+// GetLimit returns the value of Limit if it is set or its
+// default value if it is unset.
+func (v *Settings) GetLimit() (o int64) {
+	o = 1000
+	if v != nil {
+		o = ptr.Deref(v.Limit, o)
+	}
+	return
+}
+
+// IsSetLimit returns true if Limit is not nil.
+func (v *Settings) IsSetLimit() bool {
+	return v != nil && v.Limit != nil
+}
+
+// GetRatio returns the value of Ratio if it is set or its
+// default value if it is unset.
+func (v *Settings) GetRatio() (o float64) {
+	o = 0.5
+	if v != nil {
+		o = ptr.Deref(v.Ratio, o)
+	}
+	return
+}
+
+// IsSetRatio returns true if Ratio is not nil.
+func (v *Settings) IsSetRatio() bool {
+	return v != nil && v.Ratio != nil
+}
+
+// GetHost returns the value of Host if it is set or its
+// default value if it is unset.
+func (v *Settings) GetHost() (o string) {
+	o = "localhost"
+	if v != nil {
+		o = ptr.Deref(v.Host, o)
+	}
+	return
+}
+
+// IsSetHost returns true if Host is not nil.
+func (v *Settings) IsSetHost() bool {
+	return v != nil && v.Host != nil
+}
+
+// GetLevel returns the value of Level if it is set or its
+// default value if it is unset.
+func (v *Settings) GetLevel() (o Level) {
+	o = LevelMedium
+	if v != nil {
+		o = ptr.Deref(v.Level, o)
+	}
+	return
+}
+
+// IsSetLevel returns true if Level is not nil.
+func (v *Settings) IsSetLevel() bool {
+	return v != nil && v.Level != nil
+}
+
+// GetOwner returns the value of Owner if it is set or its
+// default value if it is unset.
+func (v *Settings) GetOwner() (o Name) {
+	o = "root"
+	if v != nil {
+		o = ptr.Deref(v.Owner, o)
+	}
+	return
+}
+
+// IsSetOwner returns true if Owner is not nil.
+func (v *Settings) IsSetOwner() bool {
+	return v != nil && v.Owner != nil
+}
+
+// GetCreatedAt returns the value of CreatedAt if it is set or its
+// default value if it is unset.
+func (v *Settings) GetCreatedAt() (o Timestamp) {
+	o = Timestamp(1)
+	if v != nil {
+		o = ptr.Deref(v.CreatedAt, o)
+	}
+	return
+}
+
+// IsSetCreatedAt returns true if CreatedAt is not nil.
+func (v *Settings) IsSetCreatedAt() bool {
+	return v != nil && v.CreatedAt != nil
+}
+
+// GetComment returns the value of Comment if it is set or its
+// zero value if it is unset.
+func (v *Settings) GetComment() (o string) {
+	if v != nil {
+		o = ptr.Deref(v.Comment, o)
+	}
+	return
+}
+
+// IsSetComment returns true if Comment is not nil.
+func (v *Settings) IsSetComment() bool {
+	return v != nil && v.Comment != nil
+}
+
+// GetFallback returns the value of Fallback if it is set or its
+// zero value if it is unset.
+func (v *Settings) GetFallback() (o Level) {
+	if v != nil {
+		o = ptr.Deref(v.Fallback, o)
+	}
+	return
+}
+
+// IsSetFallback returns true if Fallback is not nil.
+func (v *Settings) IsSetFallback() bool {
+	return v != nil && v.Fallback != nil
+}
+
+// GetRegion returns the value of Region if it is set or its
+// zero value if it is unset.
+func (v *Settings) GetRegion() (o string) {
+	if v != nil {
+		o = v.Region
+	}
+	return
+}
+
+type Timestamp int64
+
+// TimestampPtr returns a pointer to a Timestamp
+func (v Timestamp) Ptr() *Timestamp {
+	return &v
+}
+
+// ToWire translates Timestamp into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+func (v Timestamp) ToWire() (wire.Value, error) {
+	x := (int64)(v)
+	return wire.NewValueI64(x), error(nil)
+}
+
+// String returns a readable string representation of Timestamp.
+func (v Timestamp) String() string {
+	x := (int64)(v)
+
+	return fmt.Sprint(x)
+}
+
+func (v Timestamp) Encode(sw stream.Writer) error {
+	x := (int64)(v)
+	return sw.WriteInt64(x)
+}
+
+// FromWire deserializes Timestamp from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+func (v *Timestamp) FromWire(w wire.Value) error {
+	x, err := w.GetI64(), error(nil)
+	*v = (Timestamp)(x)
+	return err
+}
+
+// Decode deserializes Timestamp directly off the wire.
+func (v *Timestamp) Decode(sr stream.Reader) error {
+	x, err := sr.ReadInt64()
+	*v = (Timestamp)(x)
+	return err
+}
+
+// Equals returns true if this Timestamp is equal to the provided
+// Timestamp.
+func (lhs Timestamp) Equals(rhs Timestamp) bool {
+	return ((int64)(lhs) == (int64)(rhs))
+}
+
+// ThriftModule represents the IDL file used to generate this package.
+var ThriftModule = &thriftreflect.ThriftModule{
+	Name:     "genericptr",
+	Package:  "go.uber.org/thriftrw/gen/internal/tests/genericptr",
+	FilePath: "genericptr.thrift",
+	SHA1:     "002b6918c0dfeaa7349fcc985596cb437aa885e8",
+	Raw:      rawIDL,
+}
+
+const rawIDL = "enum Level {\n    Low, Medium, High\n}\n\ntypedef string Name\ntypedef i64 Timestamp\n\nstruct Settings {\n    1: optional bool enabled = true\n    2: optional byte retries = 3\n    3: optional i16 port = 8080\n    4: optional i32 timeout = 100\n    5: optional i64 limit = 1000\n    6: optional double ratio = 0.5\n    7: optional string host = \"localhost\"\n    8: optional Level level = Level.Medium\n    9: optional Name owner = \"root\"\n    10: optional Timestamp createdAt = 1\n    11: optional string comment\n    12: optional Level fallback\n    13: required string region\n}\n\nconst Settings DefaultSettings = {\n    \"enabled\": false,\n    \"host\": \"example.com\",\n    \"level\": Level.High,\n    \"owner\": \"admin\",\n    \"region\": \"us-east-1\",\n}\n"
@@ -0,0 +1,2695 @@
+// Code generated by thriftrw v1.34.0. DO NOT EDIT.
+// @generated
+
+package reusedecode
+
+import (
+	bytes "bytes"
+	base64 "encoding/base64"
+	errors "errors"
+	fmt "fmt"
+	multierr "go.uber.org/multierr"
+	structs "go.uber.org/thriftrw/gen/internal/tests/structs"
+	stream "go.uber.org/thriftrw/protocol/stream"
+	thriftreflect "go.uber.org/thriftrw/thriftreflect"
+	wire "go.uber.org/thriftrw/wire"
+	zapcore "go.uber.org/zap/zapcore"
+	strings "strings"
+)
+
+type Batch struct {
+	Items   []*Item             `json:"items,required"`
+	Counts  map[string]int64    `json:"counts,omitempty"`
+	Labels  map[string]struct{} `json:"labels,omitempty"`
+	Primary *Item               `json:"primary,omitempty"`
+	Origin  *structs.Point      `json:"origin,omitempty"`
+	Blobs   [][]byte            `json:"blobs,omitempty"`
+	Notes   []struct {
+		Key   *Item
+		Value string
+	} `json:"notes,omitempty"`
+	History Items  `json:"history,omitempty"`
+	Entry   *Entry `json:"entry,omitempty"`
+	Title   *Label `json:"title,omitempty"`
+}
+
+type _List_Item_ValueList []*Item
+
+func (v _List_Item_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid list '[]*Item', index [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_Item_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_Item_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_Item_ValueList) Close() {}
+
+type _Map_String_I64_MapItemList map[string]int64
+
+func (m _Map_String_I64_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+
+		vw, err := wire.NewValueI64(v), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_String_I64_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_String_I64_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_I64_MapItemList) ValueType() wire.Type {
+	return wire.TI64
+}
+
+func (_Map_String_I64_MapItemList) Close() {}
+
+type _Set_String_mapType_ValueList map[string]struct{}
+
+func (v _Set_String_mapType_ValueList) ForEach(f func(wire.Value) error) error {
+	for x := range v {
+		w, err := wire.NewValueString(x), error(nil)
+		if err != nil {
+			return err
+		}
+
+		if err := f(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _Set_String_mapType_ValueList) Size() int {
+	return len(v)
+}
+
+func (_Set_String_mapType_ValueList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Set_String_mapType_ValueList) Close() {}
+
+type _List_Binary_ValueList [][]byte
+
+func (v _List_Binary_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid list '[][]byte', index [%v]: value is nil", i)
+		}
+		w, err := wire.NewValueBinary(x), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_Binary_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_Binary_ValueList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_List_Binary_ValueList) Close() {}
+
+type _Map_Item_String_MapItemList []struct {
+	Key   *Item
+	Value string
+}
+
+func (m _Map_Item_String_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for _, i := range m {
+		k := i.Key
+		v := i.Value
+		if k == nil {
+			return fmt.Errorf("invalid map '[]struct{Key *Item; Value string}': key is nil")
+		}
+		kw, err := k.ToWire()
+		if err != nil {
+			return err
+		}
+
+		vw, err := wire.NewValueString(v), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_Item_String_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_Item_String_MapItemList) KeyType() wire.Type {
+	return wire.TStruct
+}
+
+func (_Map_Item_String_MapItemList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_Item_String_MapItemList) Close() {}
+
+// ToWire translates a Batch struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//	x, err := v.ToWire()
+//	if err != nil {
+//		return err
+//	}
+//
+//	if err := binaryProtocol.Encode(x, writer); err != nil {
+//		return err
+//	}
+func (v *Batch) ToWire() (wire.Value, error) {
+	var (
+		fields [10]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	w, err = wire.NewValueList(_List_Item_ValueList(v.Items)), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.Counts != nil {
+		w, err = wire.NewValueMap(_Map_String_I64_MapItemList(v.Counts)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.Labels != nil {
+		w, err = wire.NewValueSet(_Set_String_mapType_ValueList(v.Labels)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.Primary != nil {
+		w, err = v.Primary.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.Origin != nil {
+		w, err = v.Origin.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+	if v.Blobs != nil {
+		w, err = wire.NewValueList(_List_Binary_ValueList(v.Blobs)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 6, Value: w}
+		i++
+	}
+	if v.Notes != nil {
+		w, err = wire.NewValueMap(_Map_Item_String_MapItemList(v.Notes)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 7, Value: w}
+		i++
+	}
+	if v.History != nil {
+		w, err = v.History.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 8, Value: w}
+		i++
+	}
+	if v.Entry != nil {
+		w, err = v.Entry.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 9, Value: w}
+		i++
+	}
+	if v.Title != nil {
+		w, err = v.Title.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Item_Read(w wire.Value) (*Item, error) {
+	var v Item
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_Item_Read(l wire.ValueList) ([]*Item, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*Item, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _Item_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _Map_String_I64_Read(m wire.MapItemList) (map[string]int64, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+
+	if m.ValueType() != wire.TI64 {
+		return nil, nil
+	}
+
+	o := make(map[string]int64, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		v, err := x.Value.GetI64(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func _Set_String_mapType_Read(s wire.ValueList) (map[string]struct{}, error) {
+	if s.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+
+	o := make(map[string]struct{}, s.Size())
+	err := s.ForEach(func(x wire.Value) error {
+		i, err := x.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		o[i] = struct{}{}
+		return nil
+	})
+	s.Close()
+	return o, err
+}
+
+func _Point_Read(w wire.Value) (*structs.Point, error) {
+	var v structs.Point
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_Binary_Read(l wire.ValueList) ([][]byte, error) {
+	if l.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+
+	o := make([][]byte, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := x.GetBinary(), error(nil)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _Map_Item_String_Read(m wire.MapItemList) ([]struct {
+	Key   *Item
+	Value string
+}, error) {
+	if m.KeyType() != wire.TStruct {
+		return nil, nil
+	}
+
+	if m.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+
+	o := make([]struct {
+		Key   *Item
+		Value string
+	}, 0, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := _Item_Read(x.Key)
+		if err != nil {
+			return err
+		}
+
+		v, err := x.Value.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		o = append(o, struct {
+			Key   *Item
+			Value string
+		}{k, v})
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func _Items_Read(w wire.Value) (Items, error) {
+	var x Items
+	err := x.FromWire(w)
+	return x, err
+}
+
+func _Entry_Read(w wire.Value) (*Entry, error) {
+	var x Entry
+	err := x.FromWire(w)
+	return &x, err
+}
+
+func _Label_Read(w wire.Value) (Label, error) {
+	var x Label
+	err := x.FromWire(w)
+	return x, err
+}
+
+// FromWire deserializes a Batch struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a Batch struct
+// from the provided intermediate representation.
+//
+//	x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//	if err != nil {
+//		return nil, err
+//	}
+//
+//	var v Batch
+//	if err := v.FromWire(x); err != nil {
+//		return nil, err
+//	}
+//	return &v, nil
+func (v *Batch) FromWire(w wire.Value) error {
+	var err error
+
+	itemsIsSet := false
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TList {
+				v.Items, err = _List_Item_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+				itemsIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TMap {
+				v.Counts, err = _Map_String_I64_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TSet {
+				v.Labels, err = _Set_String_mapType_Read(field.Value.GetSet())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TStruct {
+				v.Primary, err = _Item_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 5:
+			if field.Value.Type() == wire.TStruct {
+				v.Origin, err = _Point_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 6:
+			if field.Value.Type() == wire.TList {
+				v.Blobs, err = _List_Binary_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 7:
+			if field.Value.Type() == wire.TMap {
+				v.Notes, err = _Map_Item_String_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 8:
+			if field.Value.Type() == wire.TList {
+				v.History, err = _Items_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 9:
+			if field.Value.Type() == wire.TStruct {
+				v.Entry, err = _Entry_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x Label
+				x, err = _Label_Read(field.Value)
+				v.Title = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	if !itemsIsSet {
+		return errors.New("field Items of Batch is required")
+	}
+
+	return nil
+}
+
+func _List_Item_Encode(val []*Item, sw stream.Writer) error {
+
+	lh := stream.ListHeader{
+		Type:   wire.TStruct,
+		Length: len(val),
+	}
+	if err := sw.WriteListBegin(lh); err != nil {
+		return err
+	}
+
+	for i, v := range val {
+		if v == nil {
+			return fmt.Errorf("invalid list '[]*Item', index [%v]: value is nil", i)
+		}
+		if err := v.Encode(sw); err != nil {
+			return err
+		}
+	}
+	return sw.WriteListEnd()
+}
+
+func _Map_String_I64_Encode(val map[string]int64, sw stream.Writer) error {
+
+	mh := stream.MapHeader{
+		KeyType:   wire.TBinary,
+		ValueType: wire.TI64,
+		Length:    len(val),
+	}
+	if err := sw.WriteMapBegin(mh); err != nil {
+		return err
+	}
+
+	for k, v := range val {
+		if err := sw.WriteString(k); err != nil {
+			return err
+		}
+		if err := sw.WriteInt64(v); err != nil {
+			return err
+		}
+	}
+
+	return sw.WriteMapEnd()
+}
+
+func _Set_String_mapType_Encode(val map[string]struct{}, sw stream.Writer) error {
+
+	sh := stream.SetHeader{
+		Type:   wire.TBinary,
+		Length: len(val),
+	}
+
+	if err := sw.WriteSetBegin(sh); err != nil {
+		return err
+	}
+
+	for v, _ := range val {
+
+		if err := sw.WriteString(v); err != nil {
+			return err
+		}
+	}
+	return sw.WriteSetEnd()
+}
+
+func _List_Binary_Encode(val [][]byte, sw stream.Writer) error {
+
+	lh := stream.ListHeader{
+		Type:   wire.TBinary,
+		Length: len(val),
+	}
+	if err := sw.WriteListBegin(lh); err != nil {
+		return err
+	}
+
+	for i, v := range val {
+		if v == nil {
+			return fmt.Errorf("invalid list '[][]byte', index [%v]: value is nil", i)
+		}
+		if err := sw.WriteBinary(v); err != nil {
+			return err
+		}
+	}
+	return sw.WriteListEnd()
+}
+
+func _Map_Item_String_Encode(val []struct {
+	Key   *Item
+	Value string
+}, sw stream.Writer) error {
+
+	mh := stream.MapHeader{
+		KeyType:   wire.TStruct,
+		ValueType: wire.TBinary,
+		Length:    len(val),
+	}
+	if err := sw.WriteMapBegin(mh); err != nil {
+		return err
+	}
+
+	for _, v := range val {
+		key := v.Key
+		value := v.Value
+
+		if key == nil {
+			return fmt.Errorf("invalid map '[]struct{Key *Item; Value string}': key is nil")
+		}
+		if err := key.Encode(sw); err != nil {
+			return err
+		}
+		if err := sw.WriteString(value); err != nil {
+			return err
+		}
+	}
+
+	return sw.WriteMapEnd()
+}
+
+// Encode serializes a Batch struct directly into bytes, without going
+// through an intermediary type.
+//
+// An error is returned if a Batch struct could not be encoded.
+func (v *Batch) Encode(sw stream.Writer) error {
+	if err := sw.WriteStructBegin(); err != nil {
+		return err
+	}
+
+	if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 1, Type: wire.TList}); err != nil {
+		return err
+	}
+	if err := _List_Item_Encode(v.Items, sw); err != nil {
+		return err
+	}
+	if err := sw.WriteFieldEnd(); err != nil {
+		return err
+	}
+
+	if v.Counts != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 2, Type: wire.TMap}); err != nil {
+			return err
+		}
+		if err := _Map_String_I64_Encode(v.Counts, sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.Labels != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 3, Type: wire.TSet}); err != nil {
+			return err
+		}
+		if err := _Set_String_mapType_Encode(v.Labels, sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.Primary != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 4, Type: wire.TStruct}); err != nil {
+			return err
+		}
+		if err := v.Primary.Encode(sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.Origin != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 5, Type: wire.TStruct}); err != nil {
+			return err
+		}
+		if err := v.Origin.Encode(sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.Blobs != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 6, Type: wire.TList}); err != nil {
+			return err
+		}
+		if err := _List_Binary_Encode(v.Blobs, sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.Notes != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 7, Type: wire.TMap}); err != nil {
+			return err
+		}
+		if err := _Map_Item_String_Encode(v.Notes, sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.History != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 8, Type: wire.TList}); err != nil {
+			return err
+		}
+		if err := v.History.Encode(sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.Entry != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 9, Type: wire.TStruct}); err != nil {
+			return err
+		}
+		if err := v.Entry.Encode(sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.Title != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 10, Type: wire.TBinary}); err != nil {
+			return err
+		}
+		if err := v.Title.Encode(sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	return sw.WriteStructEnd()
+}
+
+func _Item_DecodeReuse(sr stream.Reader, v *Item) (*Item, error) {
+	if v == nil {
+		v = new(Item)
+	}
+	err := v.Decode(sr)
+	return v, err
+}
+
+func _List_Item_DecodeReuse(sr stream.Reader, o []*Item) ([]*Item, error) {
+	lh, err := sr.ReadListBegin()
+	if err != nil {
+		return nil, err
+	}
+
+	if lh.Type != wire.TStruct {
+		for i := 0; i < lh.Length; i++ {
+			if err := sr.Skip(lh.Type); err != nil {
+				return nil, err
+			}
+		}
+		return nil, sr.ReadListEnd()
+	}
+
+	if o == nil || cap(o) < lh.Length {
+		o = append(make([]*Item, 0, lh.Length), o[:cap(o)]...)[:lh.Length]
+	} else {
+		o = o[:lh.Length]
+	}
+	for i := 0; i < lh.Length; i++ {
+		o[i], err = _Item_DecodeReuse(sr, o[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = sr.ReadListEnd(); err != nil {
+		return nil, err
+	}
+	return o, err
+}
+
+func _Map_String_I64_DecodeReuse(sr stream.Reader, o map[string]int64) (map[string]int64, error) {
+	mh, err := sr.ReadMapBegin()
+	if err != nil {
+		return nil, err
+	}
+
+	if mh.KeyType != wire.TBinary || mh.ValueType != wire.TI64 {
+		for i := 0; i < mh.Length; i++ {
+			if err := sr.Skip(mh.KeyType); err != nil {
+				return nil, err
+			}
+
+			if err := sr.Skip(mh.ValueType); err != nil {
+				return nil, err
+			}
+		}
+		return nil, sr.ReadMapEnd()
+	}
+
+	if o == nil {
+		o = make(map[string]int64, mh.Length)
+	} else {
+		clear(o)
+	}
+	for i := 0; i < mh.Length; i++ {
+		k, err := sr.ReadString()
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := sr.ReadInt64()
+		if err != nil {
+			return nil, err
+		}
+
+		o[k] = v
+	}
+
+	if err = sr.ReadMapEnd(); err != nil {
+		return nil, err
+	}
+	return o, err
+}
+
+func _Set_String_mapType_DecodeReuse(sr stream.Reader, o map[string]struct{}) (map[string]struct{}, error) {
+	sh, err := sr.ReadSetBegin()
+	if err != nil {
+		return nil, err
+	}
+
+	if sh.Type != wire.TBinary {
+		for i := 0; i < sh.Length; i++ {
+			if err := sr.Skip(sh.Type); err != nil {
+				return nil, err
+			}
+		}
+		return nil, sr.ReadSetEnd()
+	}
+
+	if o == nil {
+		o = make(map[string]struct{}, sh.Length)
+	} else {
+		clear(o)
+	}
+	for i := 0; i < sh.Length; i++ {
+		v, err := sr.ReadString()
+		if err != nil {
+			return nil, err
+		}
+
+		o[v] = struct{}{}
+	}
+
+	if err = sr.ReadSetEnd(); err != nil {
+		return nil, err
+	}
+	return o, err
+}
+
+func _Point_DecodeReuse(sr stream.Reader, v *structs.Point) (*structs.Point, error) {
+	if v == nil {
+		v = new(structs.Point)
+	} else {
+		*v = structs.Point{}
+	}
+	err := v.Decode(sr)
+	return v, err
+}
+
+func _List_Binary_DecodeReuse(sr stream.Reader, o [][]byte) ([][]byte, error) {
+	lh, err := sr.ReadListBegin()
+	if err != nil {
+		return nil, err
+	}
+
+	if lh.Type != wire.TBinary {
+		for i := 0; i < lh.Length; i++ {
+			if err := sr.Skip(lh.Type); err != nil {
+				return nil, err
+			}
+		}
+		return nil, sr.ReadListEnd()
+	}
+
+	if o == nil || cap(o) < lh.Length {
+		o = append(make([][]byte, 0, lh.Length), o[:cap(o)]...)[:lh.Length]
+	} else {
+		o = o[:lh.Length]
+	}
+	for i := 0; i < lh.Length; i++ {
+		o[i], err = sr.ReadBinary()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = sr.ReadListEnd(); err != nil {
+		return nil, err
+	}
+	return o, err
+}
+
+func _Item_Decode(sr stream.Reader) (*Item, error) {
+	var v Item
+	err := v.Decode(sr)
+	return &v, err
+}
+
+func _Map_Item_String_DecodeReuse(sr stream.Reader, o []struct {
+	Key   *Item
+	Value string
+}) ([]struct {
+	Key   *Item
+	Value string
+}, error) {
+	mh, err := sr.ReadMapBegin()
+	if err != nil {
+		return nil, err
+	}
+
+	if mh.KeyType != wire.TStruct || mh.ValueType != wire.TBinary {
+		for i := 0; i < mh.Length; i++ {
+			if err := sr.Skip(mh.KeyType); err != nil {
+				return nil, err
+			}
+
+			if err := sr.Skip(mh.ValueType); err != nil {
+				return nil, err
+			}
+		}
+		return nil, sr.ReadMapEnd()
+	}
+
+	if o == nil || cap(o) < mh.Length {
+		o = make([]struct {
+			Key   *Item
+			Value string
+		}, 0, mh.Length)
+	} else {
+		o = o[:0]
+	}
+	for i := 0; i < mh.Length; i++ {
+		k, err := _Item_Decode(sr)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := sr.ReadString()
+		if err != nil {
+			return nil, err
+		}
+
+		o = append(o, struct {
+			Key   *Item
+			Value string
+		}{k, v})
+	}
+
+	if err = sr.ReadMapEnd(); err != nil {
+		return nil, err
+	}
+	return o, err
+}
+
+func _Items_DecodeReuse(sr stream.Reader, o Items) (Items, error) {
+	x, err := _List_Item_DecodeReuse(sr, ([]*Item)(o))
+	return (Items)(x), err
+}
+
+func _Entry_DecodeReuse(sr stream.Reader, o *Entry) (*Entry, error) {
+	x, err := _Item_DecodeReuse(sr, (*Item)(o))
+	return (*Entry)(x), err
+}
+
+func _Label_Decode(sr stream.Reader) (Label, error) {
+	var x Label
+	err := x.Decode(sr)
+	return x, err
+}
+
+// Decode deserializes a Batch struct directly from its Thrift-level
+// representation, without going through an intemediary type.
+//
+// An error is returned if a Batch struct could not be generated from the wire
+// representation.
+//
+// Lists, sets, maps, and structs already referenced by this Batch
+// are reused to hold the decoded values, so they must not be in use
+// elsewhere.
+func (v *Batch) Decode(sr stream.Reader) error {
+
+	itemsIsSet := false
+
+	itemsPrev := v.Items
+	countsPrev := v.Counts
+	labelsPrev := v.Labels
+	primaryPrev := v.Primary
+	originPrev := v.Origin
+	blobsPrev := v.Blobs
+	notesPrev := v.Notes
+	historyPrev := v.History
+	entryPrev := v.Entry
+
+	*v = Batch{}
+
+	if err := sr.ReadStructBegin(); err != nil {
+		return err
+	}
+
+	fh, ok, err := sr.ReadFieldBegin()
+	if err != nil {
+		return err
+	}
+
+	for ok {
+		switch {
+		case fh.ID == 1 && fh.Type == wire.TList:
+			v.Items, err = _List_Item_DecodeReuse(sr, itemsPrev)
+			if err != nil {
+				return err
+			}
+			itemsIsSet = true
+		case fh.ID == 2 && fh.Type == wire.TMap:
+			v.Counts, err = _Map_String_I64_DecodeReuse(sr, countsPrev)
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 3 && fh.Type == wire.TSet:
+			v.Labels, err = _Set_String_mapType_DecodeReuse(sr, labelsPrev)
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 4 && fh.Type == wire.TStruct:
+			v.Primary, err = _Item_DecodeReuse(sr, primaryPrev)
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 5 && fh.Type == wire.TStruct:
+			v.Origin, err = _Point_DecodeReuse(sr, originPrev)
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 6 && fh.Type == wire.TList:
+			v.Blobs, err = _List_Binary_DecodeReuse(sr, blobsPrev)
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 7 && fh.Type == wire.TMap:
+			v.Notes, err = _Map_Item_String_DecodeReuse(sr, notesPrev)
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 8 && fh.Type == wire.TList:
+			v.History, err = _Items_DecodeReuse(sr, historyPrev)
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 9 && fh.Type == wire.TStruct:
+			v.Entry, err = _Entry_DecodeReuse(sr, entryPrev)
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 10 && fh.Type == wire.TBinary:
+			var x Label
+			x, err = _Label_Decode(sr)
+			v.Title = &x
+			if err != nil {
+				return err
+			}
+
+		default:
+			if err := sr.Skip(fh.Type); err != nil {
+				return err
+			}
+		}
+
+		if err := sr.ReadFieldEnd(); err != nil {
+			return err
+		}
+
+		if fh, ok, err = sr.ReadFieldBegin(); err != nil {
+			return err
+		}
+	}
+
+	if err := sr.ReadStructEnd(); err != nil {
+		return err
+	}
+
+	if !itemsIsSet {
+		return errors.New("field Items of Batch is required")
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a Batch
+// struct.
+func (v *Batch) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [10]string
+	i := 0
+	fields[i] = fmt.Sprintf("Items: %v", v.Items)
+	i++
+	if v.Counts != nil {
+		fields[i] = fmt.Sprintf("Counts: %v", v.Counts)
+		i++
+	}
+	if v.Labels != nil {
+		fields[i] = fmt.Sprintf("Labels: %v", v.Labels)
+		i++
+	}
+	if v.Primary != nil {
+		fields[i] = fmt.Sprintf("Primary: %v", v.Primary)
+		i++
+	}
+	if v.Origin != nil {
+		fields[i] = fmt.Sprintf("Origin: %v", v.Origin)
+		i++
+	}
+	if v.Blobs != nil {
+		fields[i] = fmt.Sprintf("Blobs: %v", v.Blobs)
+		i++
+	}
+	if v.Notes != nil {
+		fields[i] = fmt.Sprintf("Notes: %v", v.Notes)
+		i++
+	}
+	if v.History != nil {
+		fields[i] = fmt.Sprintf("History: %v", v.History)
+		i++
+	}
+	if v.Entry != nil {
+		fields[i] = fmt.Sprintf("Entry: %v", v.Entry)
+		i++
+	}
+	if v.Title != nil {
+		fields[i] = fmt.Sprintf("Title: %v", *(v.Title))
+		i++
+	}
+
+	return fmt.Sprintf("Batch{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_Item_Equals(lhs, rhs []*Item) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func _Map_String_I64_Equals(lhs, rhs map[string]int64) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _Set_String_mapType_Equals(lhs, rhs map[string]struct{}) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for x := range rhs {
+		if _, ok := lhs[x]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func _List_Binary_Equals(lhs, rhs [][]byte) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !bytes.Equal(lv, rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func _Map_Item_String_Equals(lhs, rhs []struct {
+	Key   *Item
+	Value string
+}) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for _, i := range lhs {
+		lk := i.Key
+		lv := i.Value
+		ok := false
+		for _, j := range rhs {
+			rk := j.Key
+			rv := j.Value
+			if !lk.Equals(rk) {
+				continue
+			}
+
+			if !(lv == rv) {
+				return false
+			}
+			ok = true
+			break
+		}
+
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func _Label_EqualsPtr(lhs, rhs *Label) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this Batch match the
+// provided Batch.
+//
+// This function performs a deep comparison.
+func (v *Batch) Equals(rhs *Batch) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_List_Item_Equals(v.Items, rhs.Items) {
+		return false
+	}
+	if !((v.Counts == nil && rhs.Counts == nil) || (v.Counts != nil && rhs.Counts != nil && _Map_String_I64_Equals(v.Counts, rhs.Counts))) {
+		return false
+	}
+	if !((v.Labels == nil && rhs.Labels == nil) || (v.Labels != nil && rhs.Labels != nil && _Set_String_mapType_Equals(v.Labels, rhs.Labels))) {
+		return false
+	}
+	if !((v.Primary == nil && rhs.Primary == nil) || (v.Primary != nil && rhs.Primary != nil && v.Primary.Equals(rhs.Primary))) {
+		return false
+	}
+	if !((v.Origin == nil && rhs.Origin == nil) || (v.Origin != nil && rhs.Origin != nil && v.Origin.Equals(rhs.Origin))) {
+		return false
+	}
+	if !((v.Blobs == nil && rhs.Blobs == nil) || (v.Blobs != nil && rhs.Blobs != nil && _List_Binary_Equals(v.Blobs, rhs.Blobs))) {
+		return false
+	}
+	if !((v.Notes == nil && rhs.Notes == nil) || (v.Notes != nil && rhs.Notes != nil && _Map_Item_String_Equals(v.Notes, rhs.Notes))) {
+		return false
+	}
+	if !((v.History == nil && rhs.History == nil) || (v.History != nil && rhs.History != nil && v.History.Equals(rhs.History))) {
+		return false
+	}
+	if !((v.Entry == nil && rhs.Entry == nil) || (v.Entry != nil && rhs.Entry != nil && v.Entry.Equals(rhs.Entry))) {
+		return false
+	}
+	if !_Label_EqualsPtr(v.Title, rhs.Title) {
+		return false
+	}
+
+	return true
+}
+
+type _List_Item_Zapper []*Item
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_Item_Zapper.
+func (l _List_Item_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
+type _Map_String_I64_Zapper map[string]int64
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of _Map_String_I64_Zapper.
+func (m _Map_String_I64_Zapper) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	for k, v := range m {
+		enc.AddInt64((string)(k), v)
+	}
+	return err
+}
+
+type _Set_String_mapType_Zapper map[string]struct{}
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _Set_String_mapType_Zapper.
+func (s _Set_String_mapType_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for v := range s {
+		enc.AppendString(v)
+	}
+	return err
+}
+
+type _List_Binary_Zapper [][]byte
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_Binary_Zapper.
+func (l _List_Binary_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		enc.AppendString(base64.StdEncoding.EncodeToString(v))
+	}
+	return err
+}
+
+type _Map_Item_String_Item_Zapper struct {
+	Key   *Item
+	Value string
+}
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _Map_Item_String_Item_Zapper.
+func (v _Map_Item_String_Item_Zapper) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	err = multierr.Append(err, enc.AddObject("key", v.Key))
+	enc.AddString("value", v.Value)
+	return err
+}
+
+type _Map_Item_String_Zapper []struct {
+	Key   *Item
+	Value string
+}
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _Map_Item_String_Zapper.
+func (m _Map_Item_String_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, i := range m {
+		k := i.Key
+		v := i.Value
+		err = multierr.Append(err, enc.AppendObject(_Map_Item_String_Item_Zapper{Key: k, Value: v}))
+	}
+	return err
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of Batch.
+func (v *Batch) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	err = multierr.Append(err, enc.AddArray("items", (_List_Item_Zapper)(v.Items)))
+	if v.Counts != nil {
+		err = multierr.Append(err, enc.AddObject("counts", (_Map_String_I64_Zapper)(v.Counts)))
+	}
+	if v.Labels != nil {
+		err = multierr.Append(err, enc.AddArray("labels", (_Set_String_mapType_Zapper)(v.Labels)))
+	}
+	if v.Primary != nil {
+		err = multierr.Append(err, enc.AddObject("primary", v.Primary))
+	}
+	if v.Origin != nil {
+		err = multierr.Append(err, enc.AddObject("origin", v.Origin))
+	}
+	if v.Blobs != nil {
+		err = multierr.Append(err, enc.AddArray("blobs", (_List_Binary_Zapper)(v.Blobs)))
+	}
+	if v.Notes != nil {
+		err = multierr.Append(err, enc.AddArray("notes", (_Map_Item_String_Zapper)(v.Notes)))
+	}
+	if v.History != nil {
+		err = multierr.Append(err, enc.AddArray("history", (_List_Item_Zapper)(v.History)))
+	}
+	if v.Entry != nil {
+		err = multierr.Append(err, enc.AddObject("entry", v.Entry))
+	}
+	if v.Title != nil {
+		enc.AddString("title", (string)(*v.Title))
+	}
+	return err
+}
+
+// Reset sets all fields of this Batch to their zero values.
+//
+// Decode reuses the lists, sets, maps, and structs already referenced
+// by a Batch, so Reset is only needed to release those references,
+// for example, before returning a value to a pool that is kept for a
+// long time.
+func (v *Batch) Reset() {
+	*v = Batch{}
+}
+
+// GetItems returns the value of Items if it is set or its
+// zero value if it is unset.
+func (v *Batch) GetItems() (o []*Item) {
+	if v != nil {
+		o = v.Items
+	}
+	return
+}
+
+// IsSetItems returns true if Items is not nil.
+func (v *Batch) IsSetItems() bool {
+	return v != nil && v.Items != nil
+}
+
+// GetCounts returns the value of Counts if it is set or its
+// zero value if it is unset.
+func (v *Batch) GetCounts() (o map[string]int64) {
+	if v != nil && v.Counts != nil {
+		return v.Counts
+	}
+
+	return
+}
+
+// IsSetCounts returns true if Counts is not nil.
+func (v *Batch) IsSetCounts() bool {
+	return v != nil && v.Counts != nil
+}
+
+// GetLabels returns the value of Labels if it is set or its
+// zero value if it is unset.
+func (v *Batch) GetLabels() (o map[string]struct{}) {
+	if v != nil && v.Labels != nil {
+		return v.Labels
+	}
+
+	return
+}
+
+// IsSetLabels returns true if Labels is not nil.
+func (v *Batch) IsSetLabels() bool {
+	return v != nil && v.Labels != nil
+}
+
+// GetPrimary returns the value of Primary if it is set or its
+// zero value if it is unset.
+func (v *Batch) GetPrimary() (o *Item) {
+	if v != nil && v.Primary != nil {
+		return v.Primary
+	}
+
+	return
+}
+
+// IsSetPrimary returns true if Primary is not nil.
+func (v *Batch) IsSetPrimary() bool {
+	return v != nil && v.Primary != nil
+}
+
+// GetOrigin returns the value of Origin if it is set or its
+// zero value if it is unset.
+func (v *Batch) GetOrigin() (o *structs.Point) {
+	if v != nil && v.Origin != nil {
+		return v.Origin
+	}
+
+	return
+}
+
+// IsSetOrigin returns true if Origin is not nil.
+func (v *Batch) IsSetOrigin() bool {
+	return v != nil && v.Origin != nil
+}
+
+// GetBlobs returns the value of Blobs if it is set or its
+// zero value if it is unset.
+func (v *Batch) GetBlobs() (o [][]byte) {
+	if v != nil && v.Blobs != nil {
+		return v.Blobs
+	}
+
+	return
+}
+
+// IsSetBlobs returns true if Blobs is not nil.
+func (v *Batch) IsSetBlobs() bool {
+	return v != nil && v.Blobs != nil
+}
+
+// GetNotes returns the value of Notes if it is set or its
+// zero value if it is unset.
+func (v *Batch) GetNotes() (o []struct {
+	Key   *Item
+	Value string
+}) {
+	if v != nil && v.Notes != nil {
+		return v.Notes
+	}
+
+	return
+}
+
+// IsSetNotes returns true if Notes is not nil.
+func (v *Batch) IsSetNotes() bool {
+	return v != nil && v.Notes != nil
+}
+
+// GetHistory returns the value of History if it is set or its
+// zero value if it is unset.
+func (v *Batch) GetHistory() (o Items) {
+	if v != nil && v.History != nil {
+		return v.History
+	}
+
+	return
+}
+
+// IsSetHistory returns true if History is not nil.
+func (v *Batch) IsSetHistory() bool {
+	return v != nil && v.History != nil
+}
+
+// GetEntry returns the value of Entry if it is set or its
+// zero value if it is unset.
+func (v *Batch) GetEntry() (o *Entry) {
+	if v != nil && v.Entry != nil {
+		return v.Entry
+	}
+
+	return
+}
+
+// IsSetEntry returns true if Entry is not nil.
+func (v *Batch) IsSetEntry() bool {
+	return v != nil && v.Entry != nil
+}
+
+// GetTitle returns the value of Title if it is set or its
+// zero value if it is unset.
+func (v *Batch) GetTitle() (o Label) {
+	if v != nil && v.Title != nil {
+		return *v.Title
+	}
+
+	return
+}
+
+// IsSetTitle returns true if Title is not nil.
+func (v *Batch) IsSetTitle() bool {
+	return v != nil && v.Title != nil
+}
+
+type Entry Item
+
+// ToWire translates Entry into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+func (v *Entry) ToWire() (wire.Value, error) {
+	x := (*Item)(v)
+	return x.ToWire()
+}
+
+// String returns a readable string representation of Entry.
+func (v *Entry) String() string {
+	x := (*Item)(v)
+
+	return fmt.Sprint(x)
+}
+
+func (v *Entry) Encode(sw stream.Writer) error {
+	x := (*Item)(v)
+	return x.Encode(sw)
+}
+
+// FromWire deserializes Entry from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+func (v *Entry) FromWire(w wire.Value) error {
+	return (*Item)(v).FromWire(w)
+}
+
+// Decode deserializes Entry directly off the wire.
+func (v *Entry) Decode(sr stream.Reader) error {
+	return (*Item)(v).Decode(sr)
+}
+
+// Equals returns true if this Entry is equal to the provided
+// Entry.
+func (lhs *Entry) Equals(rhs *Entry) bool {
+	return (*Item)(lhs).Equals((*Item)(rhs))
+}
+
+func (v *Entry) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return ((*Item)(v)).MarshalLogObject(enc)
+}
+
+type Item struct {
+	Name string   `json:"name,required"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+type _List_String_ValueList []string
+
+func (v _List_String_ValueList) ForEach(f func(wire.Value) error) error {
+	for _, x := range v {
+		w, err := wire.NewValueString(x), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_String_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_String_ValueList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_List_String_ValueList) Close() {}
+
+// ToWire translates a Item struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//	x, err := v.ToWire()
+//	if err != nil {
+//		return err
+//	}
+//
+//	if err := binaryProtocol.Encode(x, writer); err != nil {
+//		return err
+//	}
+func (v *Item) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	w, err = wire.NewValueString(v.Name), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.Tags != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.Tags)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _List_String_Read(l wire.ValueList) ([]string, error) {
+	if l.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+
+	o := make([]string, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := x.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a Item struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a Item struct
+// from the provided intermediate representation.
+//
+//	x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//	if err != nil {
+//		return nil, err
+//	}
+//
+//	var v Item
+//	if err := v.FromWire(x); err != nil {
+//		return nil, err
+//	}
+//	return &v, nil
+func (v *Item) FromWire(w wire.Value) error {
+	var err error
+
+	nameIsSet := false
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.Name, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					return err
+				}
+				nameIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TList {
+				v.Tags, err = _List_String_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	if !nameIsSet {
+		return errors.New("field Name of Item is required")
+	}
+
+	return nil
+}
+
+func _List_String_Encode(val []string, sw stream.Writer) error {
+
+	lh := stream.ListHeader{
+		Type:   wire.TBinary,
+		Length: len(val),
+	}
+	if err := sw.WriteListBegin(lh); err != nil {
+		return err
+	}
+
+	for _, v := range val {
+		if err := sw.WriteString(v); err != nil {
+			return err
+		}
+	}
+	return sw.WriteListEnd()
+}
+
+// Encode serializes a Item struct directly into bytes, without going
+// through an intermediary type.
+//
+// An error is returned if a Item struct could not be encoded.
+func (v *Item) Encode(sw stream.Writer) error {
+	if err := sw.WriteStructBegin(); err != nil {
+		return err
+	}
+
+	if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 1, Type: wire.TBinary}); err != nil {
+		return err
+	}
+	if err := sw.WriteString(v.Name); err != nil {
+		return err
+	}
+	if err := sw.WriteFieldEnd(); err != nil {
+		return err
+	}
+
+	if v.Tags != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 2, Type: wire.TList}); err != nil {
+			return err
+		}
+		if err := _List_String_Encode(v.Tags, sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	return sw.WriteStructEnd()
+}
+
+func _List_String_DecodeReuse(sr stream.Reader, o []string) ([]string, error) {
+	lh, err := sr.ReadListBegin()
+	if err != nil {
+		return nil, err
+	}
+
+	if lh.Type != wire.TBinary {
+		for i := 0; i < lh.Length; i++ {
+			if err := sr.Skip(lh.Type); err != nil {
+				return nil, err
+			}
+		}
+		return nil, sr.ReadListEnd()
+	}
+
+	if o == nil || cap(o) < lh.Length {
+		o = append(make([]string, 0, lh.Length), o[:cap(o)]...)[:lh.Length]
+	} else {
+		o = o[:lh.Length]
+	}
+	for i := 0; i < lh.Length; i++ {
+		o[i], err = sr.ReadString()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = sr.ReadListEnd(); err != nil {
+		return nil, err
+	}
+	return o, err
+}
+
+// Decode deserializes a Item struct directly from its Thrift-level
+// representation, without going through an intemediary type.
+//
+// An error is returned if a Item struct could not be generated from the wire
+// representation.
+//
+// Lists, sets, maps, and structs already referenced by this Item
+// are reused to hold the decoded values, so they must not be in use
+// elsewhere.
+func (v *Item) Decode(sr stream.Reader) error {
+
+	nameIsSet := false
+
+	tagsPrev := v.Tags
+
+	*v = Item{}
+
+	if err := sr.ReadStructBegin(); err != nil {
+		return err
+	}
+
+	fh, ok, err := sr.ReadFieldBegin()
+	if err != nil {
+		return err
+	}
+
+	for ok {
+		switch {
+		case fh.ID == 1 && fh.Type == wire.TBinary:
+			v.Name, err = sr.ReadString()
+			if err != nil {
+				return err
+			}
+			nameIsSet = true
+		case fh.ID == 2 && fh.Type == wire.TList:
+			v.Tags, err = _List_String_DecodeReuse(sr, tagsPrev)
+			if err != nil {
+				return err
+			}
+
+		default:
+			if err := sr.Skip(fh.Type); err != nil {
+				return err
+			}
+		}
+
+		if err := sr.ReadFieldEnd(); err != nil {
+			return err
+		}
+
+		if fh, ok, err = sr.ReadFieldBegin(); err != nil {
+			return err
+		}
+	}
+
+	if err := sr.ReadStructEnd(); err != nil {
+		return err
+	}
+
+	if !nameIsSet {
+		return errors.New("field Name of Item is required")
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a Item
+// struct.
+func (v *Item) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	fields[i] = fmt.Sprintf("Name: %v", v.Name)
+	i++
+	if v.Tags != nil {
+		fields[i] = fmt.Sprintf("Tags: %v", v.Tags)
+		i++
+	}
+
+	return fmt.Sprintf("Item{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_String_Equals(lhs, rhs []string) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !(lv == rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this Item match the
+// provided Item.
+//
+// This function performs a deep comparison.
+func (v *Item) Equals(rhs *Item) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !(v.Name == rhs.Name) {
+		return false
+	}
+	if !((v.Tags == nil && rhs.Tags == nil) || (v.Tags != nil && rhs.Tags != nil && _List_String_Equals(v.Tags, rhs.Tags))) {
+		return false
+	}
+
+	return true
+}
+
+type _List_String_Zapper []string
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_String_Zapper.
+func (l _List_String_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		enc.AppendString(v)
+	}
+	return err
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of Item.
+func (v *Item) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	enc.AddString("name", v.Name)
+	if v.Tags != nil {
+		err = multierr.Append(err, enc.AddArray("tags", (_List_String_Zapper)(v.Tags)))
+	}
+	return err
+}
+
+// Reset sets all fields of this Item to their zero values.
+//
+// Decode reuses the lists, sets, maps, and structs already referenced
+// by a Item, so Reset is only needed to release those references,
+// for example, before returning a value to a pool that is kept for a
+// long time.
+func (v *Item) Reset() {
+	*v = Item{}
+}
+
+// GetName returns the value of Name if it is set or its
+// zero value if it is unset.
+func (v *Item) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
+}
+
+// GetTags returns the value of Tags if it is set or its
+// zero value if it is unset.
+func (v *Item) GetTags() (o []string) {
+	if v != nil && v.Tags != nil {
+		return v.Tags
+	}
+
+	return
+}
+
+// IsSetTags returns true if Tags is not nil.
+func (v *Item) IsSetTags() bool {
+	return v != nil && v.Tags != nil
+}
+
+func _List_Item_Decode(sr stream.Reader) ([]*Item, error) {
+	lh, err := sr.ReadListBegin()
+	if err != nil {
+		return nil, err
+	}
+
+	if lh.Type != wire.TStruct {
+		for i := 0; i < lh.Length; i++ {
+			if err := sr.Skip(lh.Type); err != nil {
+				return nil, err
+			}
+		}
+		return nil, sr.ReadListEnd()
+	}
+
+	o := make([]*Item, 0, lh.Length)
+	for i := 0; i < lh.Length; i++ {
+		v, err := _Item_Decode(sr)
+		if err != nil {
+			return nil, err
+		}
+		o = append(o, v)
+	}
+
+	if err = sr.ReadListEnd(); err != nil {
+		return nil, err
+	}
+	return o, err
+}
+
+type Items []*Item
+
+// ToWire translates Items into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+func (v Items) ToWire() (wire.Value, error) {
+	x := ([]*Item)(v)
+	return wire.NewValueList(_List_Item_ValueList(x)), error(nil)
+}
+
+// String returns a readable string representation of Items.
+func (v Items) String() string {
+	x := ([]*Item)(v)
+
+	return fmt.Sprint(x)
+}
+
+func (v Items) Encode(sw stream.Writer) error {
+	x := ([]*Item)(v)
+	return _List_Item_Encode(x, sw)
+}
+
+// FromWire deserializes Items from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+func (v *Items) FromWire(w wire.Value) error {
+	x, err := _List_Item_Read(w.GetList())
+	*v = (Items)(x)
+	return err
+}
+
+// Decode deserializes Items directly off the wire.
+func (v *Items) Decode(sr stream.Reader) error {
+	x, err := _List_Item_Decode(sr)
+	*v = (Items)(x)
+	return err
+}
+
+// Equals returns true if this Items is equal to the provided
+// Items.
+func (lhs Items) Equals(rhs Items) bool {
+	return _List_Item_Equals(([]*Item)(lhs), ([]*Item)(rhs))
+}
+
+func (v Items) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	return ((_List_Item_Zapper)(([]*Item)(v))).MarshalLogArray(enc)
+}
+
+type Label string
+
+// LabelPtr returns a pointer to a Label
+func (v Label) Ptr() *Label {
+	return &v
+}
+
+// ToWire translates Label into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+func (v Label) ToWire() (wire.Value, error) {
+	x := (string)(v)
+	return wire.NewValueString(x), error(nil)
+}
+
+// String returns a readable string representation of Label.
+func (v Label) String() string {
+	x := (string)(v)
+	return (string)(x)
+}
+
+func (v Label) Encode(sw stream.Writer) error {
+	x := (string)(v)
+	return sw.WriteString(x)
+}
+
+// FromWire deserializes Label from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+func (v *Label) FromWire(w wire.Value) error {
+	x, err := w.GetString(), error(nil)
+	*v = (Label)(x)
+	return err
+}
+
+// Decode deserializes Label directly off the wire.
+func (v *Label) Decode(sr stream.Reader) error {
+	x, err := sr.ReadString()
+	*v = (Label)(x)
+	return err
+}
+
+// Equals returns true if this Label is equal to the provided
+// Label.
+func (lhs Label) Equals(rhs Label) bool {
+	return ((string)(lhs) == (string)(rhs))
+}
+
+type Payload struct {
+	Batch  *Batch  `json:"batch,omitempty"`
+	Values []int32 `json:"values,omitempty"`
+}
+
+type _List_I32_ValueList []int32
+
+func (v _List_I32_ValueList) ForEach(f func(wire.Value) error) error {
+	for _, x := range v {
+		w, err := wire.NewValueI32(x), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_I32_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_I32_ValueList) ValueType() wire.Type {
+	return wire.TI32
+}
+
+func (_List_I32_ValueList) Close() {}
+
+// ToWire translates a Payload struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//	x, err := v.ToWire()
+//	if err != nil {
+//		return err
+//	}
+//
+//	if err := binaryProtocol.Encode(x, writer); err != nil {
+//		return err
+//	}
+func (v *Payload) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Batch != nil {
+		w, err = v.Batch.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.Values != nil {
+		w, err = wire.NewValueList(_List_I32_ValueList(v.Values)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("Payload should have exactly one field: got %v fields", i)
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Batch_Read(w wire.Value) (*Batch, error) {
+	var v Batch
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_I32_Read(l wire.ValueList) ([]int32, error) {
+	if l.ValueType() != wire.TI32 {
+		return nil, nil
+	}
+
+	o := make([]int32, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := x.GetI32(), error(nil)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a Payload struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a Payload struct
+// from the provided intermediate representation.
+//
+//	x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//	if err != nil {
+//		return nil, err
+//	}
+//
+//	var v Payload
+//	if err := v.FromWire(x); err != nil {
+//		return nil, err
+//	}
+//	return &v, nil
+func (v *Payload) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Batch, err = _Batch_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 2:
+			if field.Value.Type() == wire.TList {
+				v.Values, err = _List_I32_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	count := 0
+	if v.Batch != nil {
+		count++
+	}
+	if v.Values != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("Payload should have exactly one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+func _List_I32_Encode(val []int32, sw stream.Writer) error {
+
+	lh := stream.ListHeader{
+		Type:   wire.TI32,
+		Length: len(val),
+	}
+	if err := sw.WriteListBegin(lh); err != nil {
+		return err
+	}
+
+	for _, v := range val {
+		if err := sw.WriteInt32(v); err != nil {
+			return err
+		}
+	}
+	return sw.WriteListEnd()
+}
+
+// Encode serializes a Payload struct directly into bytes, without going
+// through an intermediary type.
+//
+// An error is returned if a Payload struct could not be encoded.
+func (v *Payload) Encode(sw stream.Writer) error {
+	if err := sw.WriteStructBegin(); err != nil {
+		return err
+	}
+
+	if v.Batch != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 1, Type: wire.TStruct}); err != nil {
+			return err
+		}
+		if err := v.Batch.Encode(sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.Values != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 2, Type: wire.TList}); err != nil {
+			return err
+		}
+		if err := _List_I32_Encode(v.Values, sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	count := 0
+	if v.Batch != nil {
+		count++
+	}
+	if v.Values != nil {
+		count++
+	}
+
+	if count != 1 {
+		return fmt.Errorf("Payload should have exactly one field: got %v fields", count)
+	}
+
+	return sw.WriteStructEnd()
+}
+
+func _Batch_DecodeReuse(sr stream.Reader, v *Batch) (*Batch, error) {
+	if v == nil {
+		v = new(Batch)
+	}
+	err := v.Decode(sr)
+	return v, err
+}
+
+func _List_I32_DecodeReuse(sr stream.Reader, o []int32) ([]int32, error) {
+	lh, err := sr.ReadListBegin()
+	if err != nil {
+		return nil, err
+	}
+
+	if lh.Type != wire.TI32 {
+		for i := 0; i < lh.Length; i++ {
+			if err := sr.Skip(lh.Type); err != nil {
+				return nil, err
+			}
+		}
+		return nil, sr.ReadListEnd()
+	}
+
+	if o == nil || cap(o) < lh.Length {
+		o = append(make([]int32, 0, lh.Length), o[:cap(o)]...)[:lh.Length]
+	} else {
+		o = o[:lh.Length]
+	}
+	for i := 0; i < lh.Length; i++ {
+		o[i], err = sr.ReadInt32()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = sr.ReadListEnd(); err != nil {
+		return nil, err
+	}
+	return o, err
+}
+
+// Decode deserializes a Payload struct directly from its Thrift-level
+// representation, without going through an intemediary type.
+//
+// An error is returned if a Payload struct could not be generated from the wire
+// representation.
+//
+// Lists, sets, maps, and structs already referenced by this Payload
+// are reused to hold the decoded values, so they must not be in use
+// elsewhere.
+func (v *Payload) Decode(sr stream.Reader) error {
+
+	batchPrev := v.Batch
+	valuesPrev := v.Values
+
+	*v = Payload{}
+
+	if err := sr.ReadStructBegin(); err != nil {
+		return err
+	}
+
+	fh, ok, err := sr.ReadFieldBegin()
+	if err != nil {
+		return err
+	}
+
+	for ok {
+		switch {
+		case fh.ID == 1 && fh.Type == wire.TStruct:
+			v.Batch, err = _Batch_DecodeReuse(sr, batchPrev)
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 2 && fh.Type == wire.TList:
+			v.Values, err = _List_I32_DecodeReuse(sr, valuesPrev)
+			if err != nil {
+				return err
+			}
+
+		default:
+			if err := sr.Skip(fh.Type); err != nil {
+				return err
+			}
+		}
+
+		if err := sr.ReadFieldEnd(); err != nil {
+			return err
+		}
+
+		if fh, ok, err = sr.ReadFieldBegin(); err != nil {
+			return err
+		}
+	}
+
+	if err := sr.ReadStructEnd(); err != nil {
+		return err
+	}
+
+	count := 0
+	if v.Batch != nil {
+		count++
+	}
+	if v.Values != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("Payload should have exactly one field: got %v fields", count)
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a Payload
+// struct.
+func (v *Payload) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Batch != nil {
+		fields[i] = fmt.Sprintf("Batch: %v", v.Batch)
+		i++
+	}
+	if v.Values != nil {
+		fields[i] = fmt.Sprintf("Values: %v", v.Values)
+		i++
+	}
+
+	return fmt.Sprintf("Payload{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_I32_Equals(lhs, rhs []int32) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !(lv == rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this Payload match the
+// provided Payload.
+//
+// This function performs a deep comparison.
+func (v *Payload) Equals(rhs *Payload) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Batch == nil && rhs.Batch == nil) || (v.Batch != nil && rhs.Batch != nil && v.Batch.Equals(rhs.Batch))) {
+		return false
+	}
+	if !((v.Values == nil && rhs.Values == nil) || (v.Values != nil && rhs.Values != nil && _List_I32_Equals(v.Values, rhs.Values))) {
+		return false
+	}
+
+	return true
+}
+
+type _List_I32_Zapper []int32
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_I32_Zapper.
+func (l _List_I32_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		enc.AppendInt32(v)
+	}
+	return err
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of Payload.
+func (v *Payload) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Batch != nil {
+		err = multierr.Append(err, enc.AddObject("batch", v.Batch))
+	}
+	if v.Values != nil {
+		err = multierr.Append(err, enc.AddArray("values", (_List_I32_Zapper)(v.Values)))
+	}
+	return err
+}
+
+// Reset sets all fields of this Payload to their zero values.
+//
+// Decode reuses the lists, sets, maps, and structs already referenced
+// by a Payload, so Reset is only needed to release those references,
+// for example, before returning a value to a pool that is kept for a
+// long time.
+func (v *Payload) Reset() {
+	*v = Payload{}
+}
+
+// GetBatch returns the value of Batch if it is set or its
+// zero value if it is unset.
+func (v *Payload) GetBatch() (o *Batch) {
+	if v != nil && v.Batch != nil {
+		return v.Batch
+	}
+
+	return
+}
+
+// IsSetBatch returns true if Batch is not nil.
+func (v *Payload) IsSetBatch() bool {
+	return v != nil && v.Batch != nil
+}
+
+// GetValues returns the value of Values if it is set or its
+// zero value if it is unset.
+func (v *Payload) GetValues() (o []int32) {
+	if v != nil && v.Values != nil {
+		return v.Values
+	}
+
+	return
+}
+
+// IsSetValues returns true if Values is not nil.
+func (v *Payload) IsSetValues() bool {
+	return v != nil && v.Values != nil
+}
+
+// ThriftModule represents the IDL file used to generate this package.
+var ThriftModule = &thriftreflect.ThriftModule{
+	Name:     "reusedecode",
+	Package:  "go.uber.org/thriftrw/gen/internal/tests/reusedecode",
+	FilePath: "reusedecode.thrift",
+	SHA1:     "a51ef04fc699da93ae711cbfd5a426324d954a19",
+	Includes: []*thriftreflect.ThriftModule{
+		structs.ThriftModule,
+	},
+	Raw: rawIDL,
+}
+
+const rawIDL = "include \"./structs.thrift\"\n\nstruct Item {\n    1: required string name\n    2: optional list<string> tags\n}\n\ntypedef list<Item> Items\ntypedef Item Entry\ntypedef string Label\n\nstruct Batch {\n    1: required list<Item> items\n    2: optional map<string, i64> counts\n    3: optional set<string> labels\n    4: optional Item primary\n    5: optional structs.Point origin\n    6: optional list<binary> blobs\n    7: optional map<Item, string> notes\n    8: optional Items history\n    9: optional Entry entry\n    10: optional Label title\n}\n\nunion Payload {\n    1: Batch batch\n    2: list<i32> values\n}\n"
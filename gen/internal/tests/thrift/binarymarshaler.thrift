@@ -0,0 +1,20 @@
+struct Address {
+    1: required string street
+    2: optional string city
+}
+
+struct User {
+    1: required string name
+    2: optional Address address
+    3: optional list<string> tags
+    4: optional map<string, i64> scores
+}
+
+union Contact {
+    1: string email
+    2: Address address
+}
+
+exception NotFoundError {
+    1: optional string message
+}
@@ -0,0 +1,30 @@
+enum Level {
+    Low, Medium, High
+}
+
+typedef string Name
+typedef i64 Timestamp
+
+struct Settings {
+    1: optional bool enabled = true
+    2: optional byte retries = 3
+    3: optional i16 port = 8080
+    4: optional i32 timeout = 100
+    5: optional i64 limit = 1000
+    6: optional double ratio = 0.5
+    7: optional string host = "localhost"
+    8: optional Level level = Level.Medium
+    9: optional Name owner = "root"
+    10: optional Timestamp createdAt = 1
+    11: optional string comment
+    12: optional Level fallback
+    13: required string region
+}
+
+const Settings DefaultSettings = {
+    "enabled": false,
+    "host": "example.com",
+    "level": Level.High,
+    "owner": "admin",
+    "region": "us-east-1",
+}
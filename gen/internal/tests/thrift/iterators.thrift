@@ -0,0 +1,20 @@
+include "./structs.thrift"
+
+typedef list<string> Names
+
+struct Inventory {
+    1: required list<string> names
+    2: optional list<structs.Point> points
+    3: optional set<i32> ids
+    4: optional set<string> (go.type = "slice") tags
+    5: optional map<string, i64> counts
+    6: optional map<structs.Point, string> labels
+    7: optional Names aliases
+    8: optional list<i32> sizes = [1, 2, 3]
+    9: optional string description
+}
+
+union Selection {
+    1: list<i64> values
+    2: map<string, bool> flags
+}
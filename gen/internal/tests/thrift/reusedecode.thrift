@@ -0,0 +1,28 @@
+include "./structs.thrift"
+
+struct Item {
+    1: required string name
+    2: optional list<string> tags
+}
+
+typedef list<Item> Items
+typedef Item Entry
+typedef string Label
+
+struct Batch {
+    1: required list<Item> items
+    2: optional map<string, i64> counts
+    3: optional set<string> labels
+    4: optional Item primary
+    5: optional structs.Point origin
+    6: optional list<binary> blobs
+    7: optional map<Item, string> notes
+    8: optional Items history
+    9: optional Entry entry
+    10: optional Label title
+}
+
+union Payload {
+    1: Batch batch
+    2: list<i32> values
+}
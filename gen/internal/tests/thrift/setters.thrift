@@ -0,0 +1,28 @@
+enum Color {
+    Red, Green, Blue
+}
+
+typedef string UUID
+
+struct Point {
+    1: required double x
+    2: required double y
+}
+
+struct Shape {
+    1: required string name
+    2: optional string label
+    3: optional i32 sides = 4
+    4: optional Color color = Color.Red
+    5: optional UUID id
+    6: optional Point origin
+    7: optional list<Point> points
+    8: optional map<string, string> tags
+    9: optional binary data
+}
+
+union Value {
+    1: string stringValue
+    2: i64 intValue
+    3: Point pointValue
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"testing"
+
+	tit "go.uber.org/thriftrw/gen/internal/tests/iterators"
+	ts "go.uber.org/thriftrw/gen/internal/tests/structs"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterators(t *testing.T) {
+	p1 := &ts.Point{X: 1, Y: 2}
+	p2 := &ts.Point{X: 3, Y: 4}
+	inv := &tit.Inventory{
+		Names:  []string{"a", "b"},
+		Points: []*ts.Point{p1, p2},
+		Ids:    map[int32]struct{}{1: {}, 2: {}},
+		Tags:   []string{"x", "y"},
+		Counts: map[string]int64{"a": 1, "b": 2},
+		Labels: []struct {
+			Key   *ts.Point
+			Value string
+		}{{Key: p1, Value: "one"}, {Key: p2, Value: "two"}},
+		Aliases: tit.Names{"c"},
+	}
+
+	assert.Equal(t, []string{"a", "b"}, slices.Collect(valuesOf(inv.AllNames())))
+	assert.Equal(t, []*ts.Point{p1, p2}, slices.Collect(valuesOf(inv.AllPoints())))
+	assert.Equal(t, []int32{1, 2}, slices.Sorted(inv.AllIds()))
+	assert.Equal(t, []string{"x", "y"}, slices.Collect(inv.AllTags()))
+	assert.Equal(t, map[string]int64{"a": 1, "b": 2}, maps.Collect(inv.AllCounts()))
+	assert.Equal(t, []string{"c"}, slices.Collect(valuesOf(inv.AllAliases())))
+
+	var labels []string
+	for k, v := range inv.AllLabels() {
+		labels = append(labels, v)
+		if k == p1 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"one"}, labels, "iteration must stop early")
+
+	var indexes []int
+	for i := range inv.AllNames() {
+		indexes = append(indexes, i)
+	}
+	assert.Equal(t, []int{0, 1}, indexes)
+}
+
+func TestIteratorsUnsetFields(t *testing.T) {
+	for _, inv := range []*tit.Inventory{nil, {}} {
+		assert.Empty(t, slices.Collect(valuesOf(inv.AllPoints())))
+		assert.Empty(t, slices.Collect(inv.AllIds()))
+		assert.Empty(t, maps.Collect(inv.AllCounts()))
+		assert.Empty(t, slices.Collect(valuesOf(inv.AllLabels())))
+
+		// Unset fields with defaults iterate over the default value like
+		// their getters.
+		assert.Equal(t, []int32{1, 2, 3}, slices.Collect(valuesOf(inv.AllSizes())))
+	}
+}
+
+func TestIteratorsUnion(t *testing.T) {
+	sel := &tit.Selection{Values: []int64{1, 2}}
+	assert.Equal(t, []int64{1, 2}, slices.Collect(valuesOf(sel.AllValues())))
+	assert.Empty(t, maps.Collect(sel.AllFlags()))
+
+	sel = &tit.Selection{Flags: map[string]bool{"a": true}}
+	assert.Empty(t, slices.Collect(valuesOf(sel.AllValues())))
+	assert.Equal(t, map[string]bool{"a": true}, maps.Collect(sel.AllFlags()))
+}
+
+func TestGeneratedValueListIterators(t *testing.T) {
+	inv := &tit.Inventory{
+		Names:  []string{"a", "b"},
+		Counts: map[string]int64{"x": 1},
+		Points: []*ts.Point{nil},
+	}
+
+	v, err := inv.ToWire()
+	require.NoError(t, err)
+
+	var (
+		names  []wire.Value
+		counts []wire.MapItem
+		errs   []error
+	)
+	for _, f := range v.GetStruct().Fields {
+		switch f.ID {
+		case 1:
+			for x, err := range wire.ValueListAll(f.Value.GetList()) {
+				require.NoError(t, err)
+				names = append(names, x)
+			}
+		case 2:
+			for _, err := range wire.ValueListAll(f.Value.GetList()) {
+				errs = append(errs, err)
+			}
+		case 5:
+			for item, err := range wire.MapItemListItems(f.Value.GetMap()) {
+				require.NoError(t, err)
+				counts = append(counts, item)
+			}
+		}
+	}
+
+	assert.Equal(t, []wire.Value{wire.NewValueString("a"), wire.NewValueString("b")}, names)
+	assert.Equal(t, []wire.MapItem{{Key: wire.NewValueString("x"), Value: wire.NewValueI64(1)}}, counts)
+	require.Len(t, errs, 1, "nil list items must fail")
+	assert.EqualError(t, errs[0], "invalid list '[]*structs.Point', index [0]: value is nil")
+}
+
+// valuesOf drops the keys of the given iterator.
+func valuesOf[K, V any](seq iter.Seq2[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range seq {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
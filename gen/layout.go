@@ -0,0 +1,275 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/compile"
+)
+
+// Layout specifies how the code generated for a Thrift file is split into
+// files.
+type Layout int
+
+const (
+	// SingleFileLayout generates the code for a Thrift file into a single
+	// file named after its package.
+	SingleFileLayout Layout = iota
+
+	// DefinitionLayout generates a file for the constants of a Thrift
+	// file, a file for each of its types and services, and a file for its
+	// embedded IDL.
+	DefinitionLayout
+)
+
+// File kinds available to FileNameTemplate with DefinitionLayout.
+const (
+	ConstantsFileKind = "constants"
+	StructFileKind    = "struct"
+	UnionFileKind     = "union"
+	ExceptionFileKind = "exception"
+	EnumFileKind      = "enum"
+	TypedefFileKind   = "typedef"
+	ServiceFileKind   = "service"
+	IDLFileKind       = "idl"
+)
+
+const (
+	_singleFileNameTemplate     = `{{.Dir}}{{if .Index}}_{{.Index}}{{end}}.go`
+	_definitionFileNameTemplate = `{{if .Name}}{{lower .Name}}_{{end}}{{.Kind}}{{if .Index}}_{{.Index}}{{end}}.go`
+)
+
+// FileNameData is the data available to Options.FileNameTemplate.
+type FileNameData struct {
+	// Package is the name of the Go package.
+	Package string
+
+	// Dir is the name of the directory of the Go package. Unlike Package,
+	// this may contain hyphens.
+	Dir string
+
+	// Kind is the kind of code in the file with DefinitionLayout. This is
+	// one of the *FileKind constants. It's empty with SingleFileLayout.
+	Kind string
+
+	// Name is the Thrift name of the type or service in the file with
+	// DefinitionLayout. It's empty for other files.
+	Name string
+
+	// Index is the position of the file among the files for the same code
+	// if it was split with MaxFileSize, starting at 0.
+	Index int
+}
+
+// fileKind returns the kind of file that the given type is generated into.
+func fileKind(spec compile.TypeSpec) string {
+	switch s := spec.(type) {
+	case *compile.EnumSpec:
+		return EnumFileKind
+	case *compile.TypedefSpec:
+		return TypedefFileKind
+	case *compile.StructSpec:
+		switch s.Type {
+		case ast.UnionType:
+			return UnionFileKind
+		case ast.ExceptionType:
+			return ExceptionFileKind
+		}
+	}
+	return StructFileKind
+}
+
+// validateLayout verifies that the layout options are valid and returns the
+// template for file names.
+func validateLayout(o *Options) (*template.Template, error) {
+	text := o.FileNameTemplate
+	switch o.Layout {
+	case SingleFileLayout:
+		if text == "" {
+			text = _singleFileNameTemplate
+		}
+	case DefinitionLayout:
+		if text == "" {
+			text = _definitionFileNameTemplate
+		}
+	default:
+		return nil, fmt.Errorf("unknown layout %v", o.Layout)
+	}
+
+	if o.OutputFile != "" &&
+		(o.Layout != SingleFileLayout || o.MaxFileSize > 0 || o.FileNameTemplate != "") {
+		return nil, fmt.Errorf(
+			"OutputFile cannot be used with a Layout, MaxFileSize, or FileNameTemplate")
+	}
+	if o.MaxFileSize < 0 {
+		return nil, fmt.Errorf("MaxFileSize must not be negative: got %v", o.MaxFileSize)
+	}
+
+	tmpl, err := template.New("file name").
+		Funcs(template.FuncMap{"lower": strings.ToLower}).
+		Option("missingkey=error").
+		Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FileNameTemplate %q: %v", text, err)
+	}
+	return tmpl, nil
+}
+
+// moduleFiles splits the code generated for a Thrift file into files
+// based on the layout options.
+//
+// Code is generated in units: constants, types, the embedded IDL, and
+// service functions. A unit is never split across files.
+type moduleFiles struct {
+	g        *generator
+	o        *Options
+	template *template.Template
+
+	// Names of the generated files relative to the package directory, in
+	// the order they were generated, and their contents.
+	names []string
+	files map[string][]byte
+
+	current  FileNameData
+	size     int // size of the code pending for the current file
+	measured int // number of pending declarations included in size
+}
+
+func newModuleFiles(g *generator, o *Options, tmpl *template.Template, dir string) *moduleFiles {
+	return &moduleFiles{
+		g:        g,
+		o:        o,
+		template: tmpl,
+		files:    make(map[string][]byte),
+		current:  FileNameData{Package: g.PackageName, Dir: dir},
+	}
+}
+
+// Start starts the file for a definition with DefinitionLayout. It has no
+// effect with SingleFileLayout.
+func (f *moduleFiles) Start(kind, name string) error {
+	if f.o.Layout != DefinitionLayout {
+		return nil
+	}
+	if err := f.Flush(); err != nil {
+		return err
+	}
+	f.current = FileNameData{
+		Package: f.current.Package,
+		Dir:     f.current.Dir,
+		Kind:    kind,
+		Name:    name,
+	}
+	return nil
+}
+
+// EndUnit is called after each unit of code is declared. It starts a new
+// file if the current file has reached MaxFileSize.
+func (f *moduleFiles) EndUnit() error {
+	if f.o.MaxFileSize <= 0 {
+		return nil
+	}
+
+	n, err := f.g.declsSize(f.measured)
+	if err != nil {
+		return err
+	}
+	f.size += n
+	f.measured = len(f.g.decls)
+
+	if f.size < f.o.MaxFileSize {
+		return nil
+	}
+	if err := f.Flush(); err != nil {
+		return err
+	}
+	f.current.Index++
+	return nil
+}
+
+// Flush writes the pending code into a file, if there is any.
+func (f *moduleFiles) Flush() error {
+	if len(f.g.decls) == 0 {
+		return nil
+	}
+	return f.write()
+}
+
+// Close writes the remaining code. With SingleFileLayout, a file is written
+// even if the Thrift file is empty.
+func (f *moduleFiles) Close() error {
+	if f.o.Layout == SingleFileLayout && len(f.names) == 0 {
+		return f.write()
+	}
+	return f.Flush()
+}
+
+func (f *moduleFiles) write() error {
+	name, err := f.fileName()
+	if err != nil {
+		return err
+	}
+
+	buff := new(bytes.Buffer)
+	if err := f.g.Write(buff, nil); err != nil {
+		return fmt.Errorf("could not write output for file %q: %v", name, err)
+	}
+
+	f.names = append(f.names, name)
+	f.files[name] = buff.Bytes()
+	f.size = 0
+	f.measured = 0
+	return nil
+}
+
+func (f *moduleFiles) fileName() (string, error) {
+	if f.o.OutputFile != "" {
+		return f.o.OutputFile, nil
+	}
+
+	var sb strings.Builder
+	if err := f.template.Execute(&sb, f.current); err != nil {
+		return "", fmt.Errorf("could not determine file name: %v", err)
+	}
+
+	name := sb.String()
+	switch {
+	case !strings.HasSuffix(name, ".go"):
+		return "", fmt.Errorf("invalid file name %q: must end with .go", name)
+	case strings.HasSuffix(name, "_test.go"):
+		return "", fmt.Errorf("invalid file name %q: must not end with _test.go", name)
+	case strings.ContainsAny(name, `/\`):
+		return "", fmt.Errorf("invalid file name %q: must not contain path separators", name)
+	case strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_"):
+		return "", fmt.Errorf("invalid file name %q: must not start with . or _", name)
+	}
+
+	if _, ok := f.files[name]; ok {
+		return "", fmt.Errorf("file name %q was generated for more than one file: "+
+			"the file name template must produce unique names", name)
+	}
+	return name, nil
+}
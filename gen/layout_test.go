@@ -0,0 +1,260 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const _layoutTestThrift = `
+include "./shared.thrift"
+
+const i32 Answer = 42
+
+struct User {
+	1: optional string name
+	2: optional list<shared.S> items
+}
+union Value { 1: string s; 2: i64 i }
+exception Failure { 1: optional string message }
+enum Color { RED, GREEN }
+typedef list<string> Names
+
+service Users {
+	User get(1: string name) throws (1: Failure f)
+	void put(1: User user)
+}
+`
+
+// generateLayout generates code for a Thrift file with all kinds of
+// definitions with the given options and returns the generated files for
+// that file.
+func generateLayout(t *testing.T, o Options) (map[string][]byte, error) {
+	thriftRoot := t.TempDir()
+	outputDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(thriftRoot, "a.thrift"), []byte(_layoutTestThrift), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(thriftRoot, "shared.thrift"), []byte("struct S {}\n"), 0o644))
+
+	m, err := compile.Compile(filepath.Join(thriftRoot, "a.thrift"))
+	require.NoError(t, err)
+
+	o.OutputDir = outputDir
+	o.ThriftRoot = thriftRoot
+	o.PackagePrefix = "example.com/gen"
+	o.NoRecurse = true
+	if err := Generate(m, &o); err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(outputDir, "a"))
+	require.NoError(t, err)
+	files := make(map[string][]byte)
+	for _, e := range entries {
+		contents, err := os.ReadFile(filepath.Join(outputDir, "a", e.Name()))
+		require.NoError(t, err)
+		files[e.Name()] = contents
+	}
+	return files, nil
+}
+
+// assertImportsUsed verifies that the given files parse, and that every
+// package they import is used.
+func assertImportsUsed(t *testing.T, files map[string][]byte) {
+	for name, contents := range files {
+		f, err := parser.ParseFile(token.NewFileSet(), name, contents, 0)
+		require.NoError(t, err, "%v must parse", name)
+		assert.Equal(t, "a", f.Name.Name, "package name of %v", name)
+
+		used := usedPackageNames(f.Decls)
+		for _, imp := range f.Imports {
+			pkg, err := strconv.Unquote(imp.Path.Value)
+			require.NoError(t, err)
+			if imp.Name != nil {
+				pkg = imp.Name.Name
+			}
+			_, ok := used[filepath.Base(pkg)]
+			assert.True(t, ok, "%v imports %v without using it", name, imp.Path.Value)
+		}
+	}
+}
+
+func fileNames(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestGenerateDefinitionLayout(t *testing.T) {
+	files, err := generateLayout(t, Options{Layout: DefinitionLayout})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"color_enum.go",
+		"constants.go",
+		"failure_exception.go",
+		"idl.go",
+		"names_typedef.go",
+		"user_struct.go",
+		"users_service.go",
+		"value_union.go",
+	}, fileNames(files))
+	assertImportsUsed(t, files)
+
+	assert.Contains(t, string(files["user_struct.go"]), "type User struct")
+	assert.Contains(t, string(files["users_service.go"]), "type Users_Get_Args struct")
+	assert.Contains(t, string(files["idl.go"]), "var ThriftModule")
+
+	again, err := generateLayout(t, Options{Layout: DefinitionLayout})
+	require.NoError(t, err)
+	assert.Equal(t, files, again, "output must be deterministic")
+}
+
+func TestGenerateMaxFileSize(t *testing.T) {
+	single, err := generateLayout(t, Options{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.go"}, fileNames(single))
+
+	files, err := generateLayout(t, Options{MaxFileSize: 1})
+	require.NoError(t, err)
+	assertImportsUsed(t, files)
+
+	// The constant, every type, every service function, and the IDL each
+	// get a file.
+	assert.Equal(t, []string{
+		"a.go", "a_1.go", "a_2.go", "a_3.go", "a_4.go",
+		"a_5.go", "a_6.go", "a_7.go", "a_8.go",
+	}, fileNames(files))
+
+	files, err = generateLayout(t, Options{Layout: DefinitionLayout, MaxFileSize: 1})
+	require.NoError(t, err)
+	assert.Contains(t, files, "users_service.go")
+	assert.Contains(t, files, "users_service_1.go")
+	assertImportsUsed(t, files)
+}
+
+func TestGenerateFileNameTemplate(t *testing.T) {
+	files, err := generateLayout(t, Options{
+		Layout:           DefinitionLayout,
+		FileNameTemplate: `{{.Package}}_{{.Kind}}{{with .Name}}_{{lower .}}{{end}}.go`,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, files, "a_constants.go")
+	assert.Contains(t, files, "a_struct_user.go")
+	assert.Contains(t, files, "a_service_users.go")
+}
+
+func TestGenerateLayoutErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    Options
+		wantErr string
+	}{
+		{
+			desc:    "unknown layout",
+			give:    Options{Layout: Layout(42)},
+			wantErr: "unknown layout 42",
+		},
+		{
+			desc:    "output file with layout",
+			give:    Options{Layout: DefinitionLayout, OutputFile: "foo.go"},
+			wantErr: "OutputFile cannot be used with a Layout, MaxFileSize, or FileNameTemplate",
+		},
+		{
+			desc:    "negative size",
+			give:    Options{MaxFileSize: -1},
+			wantErr: "MaxFileSize must not be negative: got -1",
+		},
+		{
+			desc:    "bad template",
+			give:    Options{FileNameTemplate: "{{"},
+			wantErr: `invalid FileNameTemplate "{{"`,
+		},
+		{
+			desc:    "missing extension",
+			give:    Options{FileNameTemplate: "{{.Package}}"},
+			wantErr: `invalid file name "a": must end with .go`,
+		},
+		{
+			desc:    "test file",
+			give:    Options{FileNameTemplate: "{{.Package}}_test.go"},
+			wantErr: `invalid file name "a_test.go": must not end with _test.go`,
+		},
+		{
+			desc:    "path separator",
+			give:    Options{FileNameTemplate: "{{.Package}}/x.go"},
+			wantErr: `invalid file name "a/x.go": must not contain path separators`,
+		},
+		{
+			desc:    "hidden",
+			give:    Options{FileNameTemplate: ".x.go"},
+			wantErr: `invalid file name ".x.go": must not start with . or _`,
+		},
+		{
+			desc:    "duplicate names",
+			give:    Options{Layout: DefinitionLayout, FileNameTemplate: "{{.Package}}.go"},
+			wantErr: `file name "a.go" was generated for more than one file`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := generateLayout(t, tt.give)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestImportDeclSkipsUnusedImports(t *testing.T) {
+	g := NewGenerator(&GeneratorOptions{
+		Importer:    thriftPackageImporter{},
+		ImportPath:  "example.com/foo",
+		PackageName: "foo",
+	}).(*generator)
+
+	require.NoError(t, g.DeclareFromTemplate(`
+		<$fmt := import "fmt">
+		func hello() { <$fmt>.Println("hello") }
+	`, nil))
+	require.NoError(t, g.EnsureDeclared(`
+		<$strings := import "strings">
+		func hello() { <$strings>.ToUpper("hello") }
+	`, nil))
+
+	decl := g.importDecl(usedPackageNames(g.decls)).(*ast.GenDecl)
+	require.Len(t, decl.Specs, 1)
+	assert.Equal(t, `"fmt"`, decl.Specs[0].(*ast.ImportSpec).Path.Value)
+}
@@ -205,7 +205,23 @@ func (l *listGenerator) Encoder(g Generator, spec *compile.ListSpec) (string, er
 //
 // And returns its name.
 func (l *listGenerator) Decoder(g Generator, spec *compile.ListSpec) (string, error) {
-	name := decoderFuncName(g, spec)
+	return l.decoder(g, spec, decoderFuncName(g, spec), false)
+}
+
+// DecoderReuse is the same as Decoder except that the generated function
+// accepts a previously decoded list whose backing array will be reused if
+// it is large enough.
+//
+//	func $name(sr *stream.Reader, o $listType) ($listType, error) {
+//	        ...
+//	}
+//
+// And returns its name.
+func (l *listGenerator) DecoderReuse(g Generator, spec *compile.ListSpec) (string, error) {
+	return l.decoder(g, spec, decoderReuseFuncName(g, spec), true)
+}
+
+func (l *listGenerator) decoder(g Generator, spec *compile.ListSpec, name string, reuse bool) (string, error) {
 	err := g.EnsureDeclared(
 		`
 		<$stream := import "go.uber.org/thriftrw/protocol/stream">
@@ -215,7 +231,7 @@ func (l *listGenerator) Decoder(g Generator, spec *compile.ListSpec) (string, er
 		<$lh := newVar "lh">
 		<$o := newVar "o">
 		<$v := newVar "v">
-		func <.Name>(<$sr> <$stream>.Reader) (<$listType>, error) {
+		func <.Name>(<$sr> <$stream>.Reader<if .Reuse>, <$o> <$listType><end>) (<$listType>, error) {
 			<$lh>, err := <$sr>.ReadListBegin()
 			if err != nil {
 				return nil, err
@@ -230,14 +246,29 @@ func (l *listGenerator) Decoder(g Generator, spec *compile.ListSpec) (string, er
 				return nil, <$sr>.ReadListEnd()
 			}
 
-			<$o> := make(<$listType>, 0, <$lh>.Length)
-			for i := 0; i <lessthan> <$lh>.Length; i++ {
-				<$v>, err := <decode .Spec.ValueSpec $sr>
-				if err != nil {
-					return nil, err
+			<if .Reuse ->
+				<- /* Keep previously decoded items around so that they may be reused. */ ->
+				if <$o> == nil || cap(<$o>) <lessthan> <$lh>.Length {
+					<$o> = append(make(<$listType>, 0, <$lh>.Length), <$o>[:cap(<$o>)]...)[:<$lh>.Length]
+				} else {
+					<$o> = <$o>[:<$lh>.Length]
 				}
-				<$o> = append(<$o>, <$v>)
-			}
+				for i := 0; i <lessthan> <$lh>.Length; i++ {
+					<$o>[i], err = <decodeReuse .Spec.ValueSpec $sr (printf "%s[i]" $o)>
+					if err != nil {
+						return nil, err
+					}
+				}
+			<- else ->
+				<$o> := make(<$listType>, 0, <$lh>.Length)
+				for i := 0; i <lessthan> <$lh>.Length; i++ {
+					<$v>, err := <decode .Spec.ValueSpec $sr>
+					if err != nil {
+						return nil, err
+					}
+					<$o> = append(<$o>, <$v>)
+				}
+			<- end>
 
 			if err = <$sr>.ReadListEnd(); err != nil {
 				return nil, err
@@ -246,9 +277,10 @@ func (l *listGenerator) Decoder(g Generator, spec *compile.ListSpec) (string, er
 		}
 		`,
 		struct {
-			Name string
-			Spec *compile.ListSpec
-		}{Name: name, Spec: spec},
+			Name  string
+			Spec  *compile.ListSpec
+			Reuse bool
+		}{Name: name, Spec: spec, Reuse: reuse},
 	)
 
 	return name, wrapGenerateError(spec.ThriftName(), err)
@@ -0,0 +1,250 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// manifest describes the inputs and outputs of a call to GenerateModules.
+// It's written to Options.ManifestFile for build systems, and so that the
+// next call can remove files that are no longer generated.
+type manifest struct {
+	// Inputs lists the Thrift files that were read, sorted by path.
+	Inputs []manifestInput `json:"inputs"`
+
+	// Packages lists the Go packages that code was generated into, sorted
+	// by directory.
+	Packages []manifestPackage `json:"packages"`
+
+	// Outputs lists the generated files, sorted by path.
+	Outputs []manifestOutput `json:"outputs"`
+}
+
+// manifestInput is a Thrift file listed in a manifest.
+type manifestInput struct {
+	// Path is the slash-separated path to the file relative to ThriftRoot.
+	Path string `json:"path"`
+
+	// ImportPath is the import path of the package generated for this
+	// file. This is empty if code was not generated for it, for example
+	// with NoRecurse.
+	ImportPath string `json:"import_path,omitempty"`
+}
+
+// manifestPackage is a Go package listed in a manifest.
+type manifestPackage struct {
+	// Dir is the slash-separated path to the directory of the package
+	// relative to OutputDir.
+	Dir string `json:"dir"`
+
+	// ImportPath is the import path of the package.
+	ImportPath string `json:"import_path"`
+
+	// Name is the name of the package.
+	Name string `json:"name"`
+}
+
+// manifestOutput is a file listed in a manifest.
+type manifestOutput struct {
+	// Path is the slash-separated path to the file relative to OutputDir.
+	Path string `json:"path"`
+
+	// SHA1 is the SHA1 of the contents of the file.
+	SHA1 string `json:"sha1"`
+}
+
+// newManifest builds a manifest for the given root modules and the files
+// generated inside dir. generated holds the paths of the Thrift files that
+// code was generated for, and cached lists files that were not regenerated,
+// mapped to the SHA1 of their contents.
+func newManifest(
+	ms []*compile.Module,
+	i thriftPackageImporter,
+	generated map[string]struct{},
+	dir string,
+	files map[string][]byte,
+	cached map[string]string,
+) (*manifest, error) {
+	var m manifest
+	for _, file := range inputFiles(ms) {
+		relPath, err := i.RelativeThriftFilePath(file)
+		if err != nil {
+			return nil, err
+		}
+
+		in := manifestInput{Path: filepath.ToSlash(relPath)}
+		if _, ok := generated[file]; ok {
+			if in.ImportPath, err = i.Package(file); err != nil {
+				return nil, err
+			}
+			in.ImportPath = filepath.ToSlash(in.ImportPath)
+		}
+		m.Inputs = append(m.Inputs, in)
+	}
+	sort.Slice(m.Inputs, func(i, j int) bool {
+		return m.Inputs[i].Path < m.Inputs[j].Path
+	})
+
+	for path, contents := range files {
+		m.Outputs = append(m.Outputs, manifestOutput{
+			Path: filepath.ToSlash(path),
+			SHA1: hashContents(contents),
+		})
+	}
+	for path, sum := range cached {
+		m.Outputs = append(m.Outputs, manifestOutput{
+			Path: filepath.ToSlash(path),
+			SHA1: sum,
+		})
+	}
+	sort.Slice(m.Outputs, func(i, j int) bool {
+		return m.Outputs[i].Path < m.Outputs[j].Path
+	})
+
+	// The Go files in a directory make up a package. The files generated by
+	// plugins are parsed for the names of their packages as well.
+	fset := token.NewFileSet()
+	seen := make(map[string]struct{})
+	for _, out := range m.Outputs {
+		pkgDir := path.Dir(out.Path)
+		if _, ok := seen[pkgDir]; ok || path.Ext(out.Path) != ".go" {
+			continue
+		}
+		seen[pkgDir] = struct{}{}
+
+		filename := filepath.FromSlash(out.Path)
+		var src interface{} // read cached files from disk
+		if contents, ok := files[filename]; ok {
+			src = contents
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, filename), src, parser.PackageClauseOnly)
+		if err != nil {
+			return nil, fmt.Errorf("could not determine package of %q: %v", out.Path, err)
+		}
+
+		m.Packages = append(m.Packages, manifestPackage{
+			Dir:        pkgDir,
+			ImportPath: path.Join(filepath.ToSlash(i.ImportPrefix), pkgDir),
+			Name:       f.Name.Name,
+		})
+	}
+	return &m, nil
+}
+
+// inputFiles returns the paths of the Thrift files for the given modules
+// and all modules included by them.
+func inputFiles(ms []*compile.Module) []string {
+	var files []string
+	seen := make(map[string]struct{})
+	for _, m := range ms {
+		_ = m.Walk(func(m *compile.Module) error {
+			if _, ok := seen[m.ThriftPath]; !ok {
+				seen[m.ThriftPath] = struct{}{}
+				files = append(files, m.ThriftPath)
+			}
+			return nil
+		})
+	}
+	sort.Strings(files)
+	return files
+}
+
+// readManifest reads the manifest at the given path. An empty manifest is
+// returned if the file does not exist.
+func readManifest(path string) (*manifest, error) {
+	var m manifest
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &m, nil
+		}
+		return nil, fmt.Errorf("could not read manifest %q: %v", path, err)
+	}
+
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("invalid manifest %q: %v", path, err)
+	}
+	for _, out := range m.Outputs {
+		// Never touch files outside the output directory.
+		if !filepath.IsLocal(filepath.FromSlash(out.Path)) {
+			return nil, fmt.Errorf("invalid manifest %q: %q is not a relative path inside the output directory", path, out.Path)
+		}
+	}
+	return &m, nil
+}
+
+// staleFiles returns the files listed in this manifest that are not
+// generated anymore and still exist inside dir with the recorded contents.
+// Files that were changed after they were generated are left alone.
+func (m *manifest) staleFiles(dir string, files map[string][]byte, cached map[string]string) []string {
+	var stale []string
+	for _, out := range m.Outputs {
+		path := filepath.FromSlash(out.Path)
+		if _, ok := files[path]; ok {
+			continue
+		}
+		if _, ok := cached[path]; ok {
+			continue
+		}
+
+		contents, err := os.ReadFile(filepath.Join(dir, path))
+		if err == nil && hashContents(contents) == out.SHA1 {
+			stale = append(stale, path)
+		}
+	}
+	return stale
+}
+
+// write writes the manifest to the given path.
+func (m *manifest) write(path string) error {
+	return writeJSONFile(path, m)
+}
+
+// removeFiles removes the given files from dir, along with any
+// directories inside dir that are left empty.
+func removeFiles(dir string, paths []string) error {
+	for _, path := range paths {
+		fullPath := filepath.Join(dir, path)
+		if err := os.Remove(fullPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("could not remove %q: %v", fullPath, err)
+		}
+
+		for d := filepath.Dir(path); d != "."; d = filepath.Dir(d) {
+			// Remove fails if the directory is not empty.
+			if os.Remove(filepath.Join(dir, d)) != nil {
+				break
+			}
+		}
+	}
+	return nil
+}
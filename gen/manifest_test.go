@@ -0,0 +1,203 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/plugin/api"
+	"go.uber.org/thriftrw/plugin/plugintest"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateManifest(t *testing.T) {
+	thriftRoot := t.TempDir()
+	outputDir := t.TempDir()
+	writeThrift := func(name, body string) {
+		require.NoError(t, os.WriteFile(filepath.Join(thriftRoot, name), []byte(body), 0o644))
+	}
+	writeThrift("a.thrift", "include \"./b.thrift\"\ninclude \"./c.thrift\"\nstruct A { 1: optional b.B b; 2: optional c.C c }\n")
+	writeThrift("b.thrift", "struct B {}\n")
+	writeThrift("c.thrift", "struct C {}\n")
+	writeThrift("d.thrift", "struct D {}\n")
+
+	generate := func(check bool) error {
+		m, err := compile.Compile(filepath.Join(thriftRoot, "a.thrift"))
+		require.NoError(t, err)
+		return Generate(m, &Options{
+			OutputDir:     outputDir,
+			PackagePrefix: "example.com/gen",
+			ThriftRoot:    thriftRoot,
+			ManifestFile:  "manifest.json",
+			Check:         check,
+		})
+	}
+	exists := func(path string) bool {
+		_, err := os.Stat(filepath.Join(outputDir, path))
+		return err == nil
+	}
+
+	require.NoError(t, generate(false))
+
+	b, err := os.ReadFile(filepath.Join(outputDir, "manifest.json"))
+	require.NoError(t, err)
+	var m manifest
+	require.NoError(t, json.Unmarshal(b, &m))
+	var paths []string
+	for _, out := range m.Outputs {
+		paths = append(paths, out.Path)
+		assert.Len(t, out.SHA1, 40)
+	}
+	assert.Equal(t, []string{"a/a.go", "b/b.go", "c/c.go"}, paths)
+
+	// Code for d.thrift was generated separately, b/hand.go is hand-written,
+	// and c/c.go was changed after it was generated.
+	d, err := compile.Compile(filepath.Join(thriftRoot, "d.thrift"))
+	require.NoError(t, err)
+	require.NoError(t, Generate(d, &Options{
+		OutputDir:     outputDir,
+		PackagePrefix: "example.com/gen",
+		ThriftRoot:    thriftRoot,
+	}))
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "b/hand.go"), []byte("package b\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "c/c.go"), []byte("package c\n"), 0o644))
+
+	writeThrift("a.thrift", "struct A {}\n")
+
+	err = generate(true /* check */)
+	var checkErr *CheckError
+	require.ErrorAs(t, err, &checkErr)
+	assert.Equal(t, []string{"a/a.go"}, checkErr.Stale)
+	assert.Equal(t, []string{"b/b.go"}, checkErr.Extra)
+	assert.True(t, exists("b/b.go"), "nothing must be removed in check mode")
+
+	require.NoError(t, generate(false))
+	assert.False(t, exists("b/b.go"), "b/b.go is no longer generated")
+	assert.True(t, exists("b/hand.go"), "hand-written files must be kept")
+	assert.True(t, exists("c/c.go"), "changed files must be kept")
+	assert.True(t, exists("d/d.go"), "files not in the manifest must be kept")
+
+	require.NoError(t, os.Remove(filepath.Join(outputDir, "b/hand.go")))
+	writeThrift("a.thrift", "include \"./b.thrift\"\nstruct A { 1: optional b.B b }\n")
+	require.NoError(t, generate(false))
+	assert.True(t, exists("b/b.go"))
+
+	writeThrift("a.thrift", "struct A {}\n")
+	require.NoError(t, generate(false))
+	assert.False(t, exists("b"), "empty directories must be removed")
+}
+
+func TestReadManifestErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    string
+		wantErr string
+	}{
+		{
+			desc:    "invalid JSON",
+			give:    "{",
+			wantErr: "invalid manifest",
+		},
+		{
+			desc:    "parent directory",
+			give:    `{"outputs": [{"path": "../foo.go", "sha1": ""}]}`,
+			wantErr: `"../foo.go" is not a relative path inside the output directory`,
+		},
+		{
+			desc:    "absolute path",
+			give:    `{"outputs": [{"path": "/foo.go", "sha1": ""}]}`,
+			wantErr: `"/foo.go" is not a relative path inside the output directory`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "manifest.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.give), 0o644))
+
+			_, err := readManifest(path)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestGenerateManifestContents(t *testing.T) {
+	thriftRoot := cacheTestFiles(t)
+	outputDir := t.TempDir()
+
+	m, err := compile.Compile(filepath.Join(thriftRoot, "a.thrift"))
+	require.NoError(t, err)
+
+	sg := plugintest.NewMockServiceGenerator(gomock.NewController(t))
+	sg.EXPECT().Generate(gomock.Any()).Return(&api.GenerateServiceResponse{
+		Files: map[string][]byte{
+			"a/aclient/client.go": []byte("package aclient\n"),
+			"a/README":            []byte("hello\n"),
+		},
+	}, nil).Times(2)
+
+	opts := Options{
+		OutputDir:     outputDir,
+		PackagePrefix: "example.com/gen",
+		ThriftRoot:    thriftRoot,
+		Plugin:        CodeGenerator{ServiceGenerator: sg},
+		NoRecurse:     true,
+		ManifestFile:  "manifest.json",
+	}
+	want := manifest{
+		Inputs: []manifestInput{
+			{Path: "a.thrift", ImportPath: "example.com/gen/a"},
+			{Path: "shared.thrift"},
+		},
+		Packages: []manifestPackage{
+			{Dir: "a", ImportPath: "example.com/gen/a", Name: "a"},
+			{Dir: "a/aclient", ImportPath: "example.com/gen/a/aclient", Name: "aclient"},
+		},
+	}
+
+	// The second time, a/a.go is reused from the cache.
+	opts.CacheFile = "cache.json"
+	for i := 0; i < 2; i++ {
+		require.NoError(t, Generate(m, &opts))
+
+		b, err := os.ReadFile(filepath.Join(outputDir, "manifest.json"))
+		require.NoError(t, err)
+		var got manifest
+		require.NoError(t, json.Unmarshal(b, &got))
+
+		var outputs []string
+		for _, out := range got.Outputs {
+			outputs = append(outputs, out.Path)
+		}
+		assert.Equal(t, []string{"a/README", "a/a.go", "a/aclient/client.go"}, outputs)
+
+		got.Outputs = nil
+		assert.Equal(t, want, got)
+	}
+}
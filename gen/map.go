@@ -278,7 +278,22 @@ func (m *mapGenerator) Encoder(g Generator, spec *compile.MapSpec) (string, erro
 //
 // And returns its name.
 func (m *mapGenerator) Decoder(g Generator, spec *compile.MapSpec) (string, error) {
-	name := decoderFuncName(g, spec)
+	return m.decoder(g, spec, decoderFuncName(g, spec), false)
+}
+
+// DecoderReuse is the same as Decoder except that the generated function
+// accepts a previously decoded map whose storage will be cleared and reused.
+//
+//	func $name(sr *stream.Reader, o $mapType) ($mapType, error) {
+//	        ...
+//	}
+//
+// And returns its name.
+func (m *mapGenerator) DecoderReuse(g Generator, spec *compile.MapSpec) (string, error) {
+	return m.decoder(g, spec, decoderReuseFuncName(g, spec), true)
+}
+
+func (m *mapGenerator) decoder(g Generator, spec *compile.MapSpec, name string, reuse bool) (string, error) {
 	err := g.EnsureDeclared(
 		`
 		<$stream := import "go.uber.org/thriftrw/protocol/stream">
@@ -289,7 +304,7 @@ func (m *mapGenerator) Decoder(g Generator, spec *compile.MapSpec) (string, erro
 		<$o := newVar "o">
 		<$k := newVar "k">
 		<$v := newVar "v">
-		func <.Name>(<$sr> <$stream>.Reader) (<$mapType>, error) {
+		func <.Name>(<$sr> <$stream>.Reader<if .Reuse>, <$o> <$mapType><end>) (<$mapType>, error) {
 			<$mh>, err := <$sr>.ReadMapBegin()
 			if err != nil {
 				return nil, err
@@ -308,7 +323,19 @@ func (m *mapGenerator) Decoder(g Generator, spec *compile.MapSpec) (string, erro
 				return nil, <$sr>.ReadMapEnd()
 			}
 
-			<if isHashable .Spec.KeySpec>
+			<if and .Reuse (isHashable .Spec.KeySpec)>
+				if <$o> == nil {
+					<$o> = make(<$mapType>, <$mh>.Length)
+				} else {
+					clear(<$o>)
+				}
+			<else if .Reuse>
+				if <$o> == nil || cap(<$o>) <lessthan> <$mh>.Length {
+					<$o> = make(<$mapType>, 0, <$mh>.Length)
+				} else {
+					<$o> = <$o>[:0]
+				}
+			<else if isHashable .Spec.KeySpec>
 				<$o> := make(<$mapType>, <$mh>.Length)
 			<else>
 				<$o> := make(<$mapType>, 0, <$mh>.Length)
@@ -341,9 +368,10 @@ func (m *mapGenerator) Decoder(g Generator, spec *compile.MapSpec) (string, erro
 		}
 		`,
 		struct {
-			Name string
-			Spec *compile.MapSpec
-		}{Name: name, Spec: spec},
+			Name  string
+			Spec  *compile.MapSpec
+			Reuse bool
+		}{Name: name, Spec: spec, Reuse: reuse},
 	)
 
 	return name, wrapGenerateError(spec.ThriftName(), err)
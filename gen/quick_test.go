@@ -35,6 +35,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
 
+	envex "go.uber.org/thriftrw/envelope/exception"
+	tbm "go.uber.org/thriftrw/gen/internal/tests/binarymarshaler"
 	tl "go.uber.org/thriftrw/gen/internal/tests/collision"
 	tc "go.uber.org/thriftrw/gen/internal/tests/containers"
 	tems "go.uber.org/thriftrw/gen/internal/tests/enum-text-marshal-strict"
@@ -43,15 +45,17 @@ import (
 	tx "go.uber.org/thriftrw/gen/internal/tests/exceptions"
 	ahf "go.uber.org/thriftrw/gen/internal/tests/hyphenated-file"
 	hf "go.uber.org/thriftrw/gen/internal/tests/hyphenated_file"
+	tit "go.uber.org/thriftrw/gen/internal/tests/iterators"
 	nf "go.uber.org/thriftrw/gen/internal/tests/non_hyphenated"
 	tz "go.uber.org/thriftrw/gen/internal/tests/nozap"
+	trd "go.uber.org/thriftrw/gen/internal/tests/reusedecode"
 	tf "go.uber.org/thriftrw/gen/internal/tests/services"
 	tss "go.uber.org/thriftrw/gen/internal/tests/set_to_slice"
+	tst "go.uber.org/thriftrw/gen/internal/tests/setters"
 	ts "go.uber.org/thriftrw/gen/internal/tests/structs"
 	td "go.uber.org/thriftrw/gen/internal/tests/typedefs"
 	tu "go.uber.org/thriftrw/gen/internal/tests/unions"
 	tul "go.uber.org/thriftrw/gen/internal/tests/uuid_conflict"
-	envex "go.uber.org/thriftrw/internal/envelope/exception"
 	"go.uber.org/thriftrw/protocol/binary"
 	"go.uber.org/thriftrw/wire"
 )
@@ -470,6 +474,34 @@ func TestQuickSuite(t *testing.T) {
 			NoLog:  true,
 			Kind:   thriftStruct,
 		},
+		{Sample: tst.Point{}, Kind: thriftStruct},
+		{Sample: tbm.Address{}, Kind: thriftStruct},
+		{Sample: tbm.User{}, Kind: thriftStruct},
+		{
+			Sample:    tbm.Contact{},
+			Generator: unionValueGenerator(tbm.Contact{}),
+			Kind:      thriftStruct,
+		},
+		{Sample: tbm.NotFoundError{}, Kind: thriftStruct},
+		{Sample: trd.Item{}, Kind: thriftStruct},
+		{Sample: trd.Batch{}, NoEquals: true, Kind: thriftStruct},
+		{
+			Sample:    trd.Payload{},
+			Generator: unionValueGenerator(trd.Payload{}),
+			NoEquals:  true,
+			Kind:      thriftStruct,
+		},
+		{
+			Sample:    tst.Value{},
+			Generator: unionValueGenerator(tst.Value{}),
+			Kind:      thriftStruct,
+		},
+		{Sample: tit.Inventory{}, Kind: thriftStruct},
+		{
+			Sample:    tit.Selection{},
+			Generator: unionValueGenerator(tit.Selection{}),
+			Kind:      thriftStruct,
+		},
 
 		// typedefs
 		{Sample: td.BinarySet{}, Kind: thriftTypedef},
@@ -498,6 +530,8 @@ func TestQuickSuite(t *testing.T) {
 		{Sample: tss.MyStringList{}, Kind: thriftTypedef},
 		{Sample: tss.AnotherStringList{}, Kind: thriftTypedef},
 		{Sample: tss.StringListList{}, Kind: thriftTypedef},
+		{Sample: tst.UUID(""), NoLog: true, Kind: thriftTypedef},
+		{Sample: tit.Names{}, Kind: thriftTypedef},
 
 		// enums
 		{
@@ -581,6 +615,11 @@ func TestQuickSuite(t *testing.T) {
 			Generator: knownEnumValuesGenerator(tems.EnumMarshalStrict_Values),
 			Kind:      thriftEnum,
 		},
+		{
+			Sample:    tst.Color(0),
+			Generator: enumValueGenerator(tst.Color_Values),
+			Kind:      thriftEnum,
+		},
 	}
 
 	// Log the seed so that we can reproduce this if it ever fails.
@@ -0,0 +1,171 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"bytes"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	trd "go.uber.org/thriftrw/gen/internal/tests/reusedecode"
+	ts "go.uber.org/thriftrw/gen/internal/tests/structs"
+	"go.uber.org/thriftrw/protocol/binary"
+	"go.uber.org/thriftrw/protocol/stream"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReuseDecodeBatch() *trd.Batch {
+	return &trd.Batch{
+		Items: []*trd.Item{
+			{Name: "foo", Tags: []string{"a", "b"}},
+			{Name: "bar"},
+		},
+		Counts:  map[string]int64{"x": 1, "y": 2},
+		Labels:  map[string]struct{}{"l": {}},
+		Primary: &trd.Item{Name: "primary"},
+		Origin:  &ts.Point{X: 1, Y: 2},
+		Blobs:   [][]byte{[]byte("hello")},
+		Notes: []struct {
+			Key   *trd.Item
+			Value string
+		}{{Key: &trd.Item{Name: "k"}, Value: "v"}},
+		History: trd.Items{{Name: "old"}},
+		Entry:   &trd.Entry{Name: "entry"},
+		Title:   trd.Label("title").Ptr(),
+	}
+}
+
+func streamEncode(t testing.TB, v interface{ Encode(stream.Writer) error }) []byte {
+	var buf bytes.Buffer
+	sw := binary.Default.Writer(&buf)
+	require.NoError(t, v.Encode(sw), "Encode")
+	require.NoError(t, sw.Close(), "Close StreamWriter")
+	return buf.Bytes()
+}
+
+func reuseDecode(t testing.TB, b []byte, into interface{ Decode(stream.Reader) error }) {
+	sr := binary.NewAliasingStreamReader(b)
+	defer sr.Close()
+	require.NoError(t, into.Decode(sr), "Decode")
+}
+
+func TestReuseDecodeRoundTrip(t *testing.T) {
+	give := newReuseDecodeBatch()
+	b := streamEncode(t, give)
+
+	var got trd.Batch
+	reuseDecode(t, b, &got)
+	assert.True(t, give.Equals(&got), "expected %v, got %v", give, &got)
+
+	// Decoding again into the same value must produce the same result.
+	reuseDecode(t, b, &got)
+	assert.True(t, give.Equals(&got), "expected %v, got %v", give, &got)
+}
+
+func TestReuseDecodeReusesStorage(t *testing.T) {
+	b := streamEncode(t, newReuseDecodeBatch())
+
+	var got trd.Batch
+	reuseDecode(t, b, &got)
+
+	items := got.Items
+	item := got.Items[0]
+	counts := got.Counts
+	primary := got.Primary
+	origin := got.Origin
+
+	reuseDecode(t, b, &got)
+	assert.Same(t, &items[0], &got.Items[0], "list storage must be reused")
+	assert.Same(t, item, got.Items[0], "list items must be reused")
+	assert.Same(t, primary, got.Primary, "nested structs must be reused")
+	assert.Same(t, origin, got.Origin, "structs from other packages must be reused")
+
+	counts["z"] = 3
+	assert.Contains(t, got.Counts, "z", "maps must be reused")
+	reuseDecode(t, b, &got)
+	assert.NotContains(t, got.Counts, "z", "reused maps must be cleared")
+}
+
+func TestReuseDecodeReusesTypedefStorage(t *testing.T) {
+	b := streamEncode(t, newReuseDecodeBatch())
+
+	var got trd.Batch
+	reuseDecode(t, b, &got)
+
+	history := got.History
+	entry := got.Entry
+
+	reuseDecode(t, b, &got)
+	assert.Same(t, &history[0], &got.History[0], "typedefs of lists must be reused")
+	assert.Same(t, entry, got.Entry, "typedefs of structs must be reused")
+	assert.Equal(t, trd.Label("title"), got.GetTitle())
+}
+
+func TestReuseDecodeClearsStaleFields(t *testing.T) {
+	b := streamEncode(t, &trd.Batch{Items: []*trd.Item{{Name: "foo"}}})
+
+	got := newReuseDecodeBatch()
+	got.Items[0].Tags = []string{"stale"}
+	reuseDecode(t, b, got)
+
+	assert.Equal(t, &trd.Batch{Items: []*trd.Item{{Name: "foo"}}}, got)
+}
+
+func TestReuseDecodeUnion(t *testing.T) {
+	b := streamEncode(t, &trd.Payload{Values: []int32{1, 2, 3}})
+
+	got := trd.Payload{Batch: newReuseDecodeBatch()}
+	reuseDecode(t, b, &got)
+	assert.Equal(t, trd.Payload{Values: []int32{1, 2, 3}}, got)
+}
+
+func TestReuseDecodeReset(t *testing.T) {
+	v := newReuseDecodeBatch()
+	v.Reset()
+	assert.Equal(t, &trd.Batch{}, v)
+}
+
+func TestReuseDecodeReservedFieldName(t *testing.T) {
+	spec := &compile.StructSpec{
+		Name: "Foo",
+		Fields: compile.FieldGroup{
+			{ID: 1, Name: "reset", Type: &compile.StringSpec{}, Required: true},
+		},
+	}
+	g := NewGenerator(&GeneratorOptions{
+		Importer:    thriftPackageImporter{},
+		ImportPath:  "go.uber.org/thriftrw/gen",
+		PackageName: "gen",
+		ReuseDecode: true,
+	})
+	err := TypeDefinition(g, spec)
+	assert.ErrorContains(t, err, `could not declare field "Reset" (from "reset")`)
+	assert.ErrorContains(t, err, `"Reset" is a reserved ThriftRW identifier`)
+
+	g = NewGenerator(&GeneratorOptions{
+		Importer:    thriftPackageImporter{},
+		ImportPath:  "go.uber.org/thriftrw/gen",
+		PackageName: "gen",
+	})
+	assert.NoError(t, TypeDefinition(g, spec), "Reset is only reserved with ReuseDecode")
+}
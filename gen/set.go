@@ -202,7 +202,22 @@ func (s *setGenerator) Encoder(g Generator, spec *compile.SetSpec) (string, erro
 //
 // And returns its name.
 func (s *setGenerator) Decoder(g Generator, spec *compile.SetSpec) (string, error) {
-	name := decoderFuncName(g, spec)
+	return s.decoder(g, spec, decoderFuncName(g, spec), false)
+}
+
+// DecoderReuse is the same as Decoder except that the generated function
+// accepts a previously decoded set whose storage will be cleared and reused.
+//
+//	func $name(sr *stream.Reader, o $setType) ($setType, error) {
+//	        ...
+//	}
+//
+// And returns its name.
+func (s *setGenerator) DecoderReuse(g Generator, spec *compile.SetSpec) (string, error) {
+	return s.decoder(g, spec, decoderReuseFuncName(g, spec), true)
+}
+
+func (s *setGenerator) decoder(g Generator, spec *compile.SetSpec, name string, reuse bool) (string, error) {
 	err := g.EnsureDeclared(
 		`
 		<$stream := import "go.uber.org/thriftrw/protocol/stream">
@@ -212,7 +227,7 @@ func (s *setGenerator) Decoder(g Generator, spec *compile.SetSpec) (string, erro
 		<$sh := newVar "sh">
 		<$o := newVar "o">
 		<$v := newVar "v">
-		func <.Name>(<$sr> <$stream>.Reader) (<$setType>, error) {
+		func <.Name>(<$sr> <$stream>.Reader<if .Reuse>, <$o> <$setType><end>) (<$setType>, error) {
 			<$sh>, err := <$sr>.ReadSetBegin()
 			if err != nil {
 				return nil, err
@@ -227,7 +242,19 @@ func (s *setGenerator) Decoder(g Generator, spec *compile.SetSpec) (string, erro
 				return nil, <$sr>.ReadSetEnd()
 			}
 
-			<if setUsesMap .Spec>
+			<if and .Reuse (setUsesMap .Spec)>
+				if <$o> == nil {
+					<$o> = make(<$setType>, <$sh>.Length)
+				} else {
+					clear(<$o>)
+				}
+			<else if .Reuse>
+				if <$o> == nil || cap(<$o>) <lessthan> <$sh>.Length {
+					<$o> = make(<$setType>, 0, <$sh>.Length)
+				} else {
+					<$o> = <$o>[:0]
+				}
+			<else if setUsesMap .Spec>
 				<$o> := make(<$setType>, <$sh>.Length)
 			<else>
 				<$o> := make(<$setType>, 0, <$sh>.Length)
@@ -251,9 +278,10 @@ func (s *setGenerator) Decoder(g Generator, spec *compile.SetSpec) (string, erro
 		}
 		`,
 		struct {
-			Name string
-			Spec *compile.SetSpec
-		}{Name: name, Spec: spec},
+			Name  string
+			Spec  *compile.SetSpec
+			Reuse bool
+		}{Name: name, Spec: spec, Reuse: reuse},
 	)
 
 	return name, wrapGenerateError(spec.ThriftName(), err)
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"testing"
+
+	tst "go.uber.org/thriftrw/gen/internal/tests/setters"
+	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetters(t *testing.T) {
+	origin := (&tst.Point{}).SetX(1).SetY(2)
+	assert.Equal(t, &tst.Point{X: 1, Y: 2}, origin)
+
+	shape := (&tst.Shape{}).
+		SetName("square").
+		SetLabel("foo").
+		SetColor(tst.ColorBlue).
+		SetID("abc").
+		SetOrigin(origin).
+		SetPoints([]*tst.Point{origin}).
+		SetTags(map[string]string{"a": "b"}).
+		SetData([]byte("data"))
+
+	assert.Equal(t, &tst.Shape{
+		Name:   "square",
+		Label:  ptr.String("foo"),
+		Color:  tst.ColorBlue.Ptr(),
+		ID:     tst.UUID("abc").Ptr(),
+		Origin: &tst.Point{X: 1, Y: 2},
+		Points: []*tst.Point{{X: 1, Y: 2}},
+		Tags:   map[string]string{"a": "b"},
+		Data:   []byte("data"),
+	}, shape)
+	assert.False(t, shape.IsSetSides())
+}
+
+func TestSettersWithDefaults(t *testing.T) {
+	shape := tst.Default_Shape().SetName("triangle").SetSides(3)
+	assert.Equal(t, "triangle", shape.GetName())
+	assert.Equal(t, int32(3), shape.GetSides())
+	assert.Equal(t, tst.ColorRed, shape.GetColor())
+}
+
+func TestSettersUnion(t *testing.T) {
+	v := (&tst.Value{}).SetStringValue("foo")
+	assertRoundTrip(t, v, wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+		{ID: 1, Value: wire.NewValueString("foo")},
+	}}), "Value")
+
+	v.SetIntValue(42)
+	assert.False(t, v.IsSetStringValue())
+	assert.Equal(t, int64(42), v.GetIntValue())
+	assertRoundTrip(t, v, wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+		{ID: 2, Value: wire.NewValueI64(42)},
+	}}), "Value")
+}
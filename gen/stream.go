@@ -146,6 +146,49 @@ func (sg *StreamGenerator) Decode(g Generator, spec compile.TypeSpec, reader str
 	}
 }
 
+// DecodeReuse is the same as Decode except that the generated expression
+// attempts to reuse the storage of the previously decoded value prev.
+// Storage is reused only for lists, sets, maps, structs, and typedefs of
+// these; all other types are decoded with Decode.
+func (sg *StreamGenerator) DecodeReuse(g Generator, spec compile.TypeSpec, reader string, prev string) (string, error) {
+	var (
+		decoder string
+		err     error
+	)
+	switch s := spec.(type) {
+	case *compile.MapSpec:
+		decoder, err = sg.mapG.DecoderReuse(g, s)
+	case *compile.ListSpec:
+		decoder, err = sg.listG.DecoderReuse(g, s)
+	case *compile.SetSpec:
+		decoder, err = sg.setG.DecoderReuse(g, s)
+	case *compile.StructSpec:
+		decoder, err = sg.structG.DecoderReuse(g, s)
+	case *compile.TypedefSpec:
+		if !isReusable(s) {
+			return sg.Decode(g, spec, reader)
+		}
+		decoder, err = sg.typedefG.DecoderReuse(g, s)
+	default:
+		return sg.Decode(g, spec, reader)
+	}
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s(%s, %s)", decoder, reader, prev), nil
+}
+
+// isReusable returns true if DecodeReuse is able to reuse the storage of
+// previously decoded values of the given type.
+func isReusable(spec compile.TypeSpec) bool {
+	switch compile.RootTypeSpec(spec).(type) {
+	case *compile.MapSpec, *compile.ListSpec, *compile.SetSpec, *compile.StructSpec:
+		return true
+	default:
+		return false
+	}
+}
+
 // DecodePtr generates an expression that assigns the "lhs" to a pointer of the
 // decoded value.
 func (sg *StreamGenerator) DecodePtr(g Generator, spec compile.TypeSpec, lhs string, reader string) (string, error) {
@@ -74,6 +74,43 @@ func (s *structGenerator) Decoder(g Generator, spec *compile.StructSpec) (string
 	return name, wrapGenerateError(spec.ThriftName(), err)
 }
 
+// DecoderReuse generates a function which decodes a struct of the given
+// type into a previously decoded value, allocating a new one only if the
+// previous value is nil.
+func (s *structGenerator) DecoderReuse(g Generator, spec *compile.StructSpec) (string, error) {
+	name := decoderReuseFuncName(g, spec)
+	err := g.EnsureDeclared(
+		`
+		<$stream := import "go.uber.org/thriftrw/protocol/stream">
+
+		<$sr := newVar "sr">
+		<$v := newVar "v">
+		func <.Name>(<$sr> <$stream>.Reader, <$v> <typeReference .Spec>) (<typeReference .Spec>, error) {
+			if <$v> == nil {
+				<$v> = new(<typeName .Spec>)
+			<- if not .Local>
+			} else {
+				*<$v> = <typeName .Spec>{}
+			<- end>
+			}
+			err := <$v>.Decode(<$sr>)
+			return <$v>, err
+		}
+		`,
+		struct {
+			Name string
+			Spec *compile.StructSpec
+
+			// Structs declared in the same package reset themselves in
+			// Decode. Structs from other packages may not have been
+			// generated with the same options.
+			Local bool
+		}{Name: name, Spec: spec, Local: isLocalType(g, spec)},
+	)
+
+	return name, wrapGenerateError(spec.ThriftName(), err)
+}
+
 func structure(g Generator, spec *compile.StructSpec) error {
 	name, err := goName(spec)
 	if err != nil {
@@ -216,6 +216,10 @@ func decoderFuncName(g Generator, spec compile.TypeSpec) string {
 	return fmt.Sprintf("_%s_Decode", g.MangleType(spec))
 }
 
+func decoderReuseFuncName(g Generator, spec compile.TypeSpec) string {
+	return fmt.Sprintf("_%s_DecodeReuse", g.MangleType(spec))
+}
+
 func valueListName(g Generator, spec compile.TypeSpec) string {
 	return fmt.Sprintf("_%s_ValueList", g.MangleType(spec))
 }
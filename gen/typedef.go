@@ -79,6 +79,39 @@ func (t *typedefGenerator) Decoder(g Generator, spec *compile.TypedefSpec) (stri
 	return name, wrapGenerateError(spec.ThriftName(), err)
 }
 
+// DecoderReuse generates a function which decodes a typedef of a list, set,
+// map, or struct into the storage of a previously decoded value of the same
+// typedef.
+//
+//	func $name(sr stream.Reader, o $typedefType) ($typedefType, error) {
+//		...
+//	}
+//
+// And returns its name.
+func (t *typedefGenerator) DecoderReuse(g Generator, spec *compile.TypedefSpec) (string, error) {
+	name := decoderReuseFuncName(g, spec)
+	err := g.EnsureDeclared(
+		`
+		<$stream := import "go.uber.org/thriftrw/protocol/stream">
+
+		<$sr := newVar "sr">
+		<$o := newVar "o">
+		<$x := newVar "x">
+		func <.Name>(<$sr> <$stream>.Reader, <$o> <typeReference .Spec>) (<typeReference .Spec>, error) {
+			<- $prev := printf "(%v)(%v)" (typeReference .Spec.Target) $o>
+			<$x>, err := <decodeReuse .Spec.Target $sr $prev>
+			return (<typeReference .Spec>)(<$x>), err
+		}
+		`,
+		struct {
+			Name string
+			Spec *compile.TypedefSpec
+		}{Name: name, Spec: spec},
+	)
+
+	return name, wrapGenerateError(spec.ThriftName(), err)
+}
+
 // typedef generates code for the given typedef.
 func typedef(g Generator, spec *compile.TypedefSpec) error {
 	err := g.DeclareFromTemplate(
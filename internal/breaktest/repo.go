@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/require"
 )
@@ -103,3 +104,20 @@ func CreateRepoAndCommit(t *testing.T, tmpDir string, from map[string]string,
 
 	return repository
 }
+
+// Commit writes the given Thrift files to the worktree of a repository
+// created by CreateRepoAndCommit, removes the files in remove, and commits
+// the result on the current branch. It returns the hash of the new commit.
+func Commit(t *testing.T, repository *git.Repository, tmpDir string,
+	contents map[string]string, remove []string) plumbing.Hash {
+	t.Helper()
+	worktree, err := repository.Worktree()
+	require.NoError(t, err)
+
+	w := newWriteThrift(tmpDir, contents, worktree, remove)
+	require.NoError(t, w.writeThrifts(""))
+
+	head, err := repository.Head()
+	require.NoError(t, err)
+	return head.Hash()
+}
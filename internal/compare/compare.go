@@ -23,25 +23,53 @@ package compare
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"go.uber.org/thriftrw/ast"
 	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/internal/compare/spec"
 )
 
 // Diagnostic is a message associated with an error and a file name.
 type Diagnostic struct {
-	FilePath string // FilePath where error was discovered.
-	Message  string // Message contains error message.
+	FilePath string   // FilePath where error was discovered.
+	Line     int      `json:",omitempty"` // Line in FilePath, if known.
+	Column   int      `json:",omitempty"` // Column in FilePath, if known.
+	Message  string   // Message contains error message.
+	Rule     Rule     `json:",omitempty"` // Rule that reported this error.
+	Severity Severity `json:",omitempty"` // Severity of this error.
 }
 
 func (d *Diagnostic) String() string {
-	return fmt.Sprintf("%s:%s", d.FilePath, d.Message)
+	var b strings.Builder
+	b.WriteString(d.FilePath)
+	if d.Line > 0 {
+		fmt.Fprintf(&b, ":%d:%d", d.Line, d.Column)
+	}
+	b.WriteString(":")
+	if d.Severity == SeverityWarning {
+		b.WriteString("warning: ")
+	}
+	b.WriteString(d.Message)
+	return b.String()
 }
 
 // Pass provides all reported errors.
 type Pass struct {
 	lints  []Diagnostic
 	GitDir string
+
+	// Config controls which rules are reported and their severities. The
+	// default severity of each rule is used if Config is nil.
+	Config *Config
+
+	// FromFS and ToFS are used to read the previous and new versions of
+	// the Thrift files so that diagnostics can report line and column
+	// numbers. Positions are not reported if these are nil.
+	FromFS, ToFS compile.FS
+
+	positions *positions // positions for the modules being compared
 }
 
 // Report reports an error.
@@ -49,6 +77,29 @@ func (p *Pass) Report(d Diagnostic) {
 	p.lints = append(p.lints, d)
 }
 
+// reportf reports an error for the given rule with a formatted message
+// unless the rule is disabled or suppressed for the given site.
+func (p *Pass) reportf(rule Rule, at site, format string, args ...interface{}) {
+	if at.ignores(rule) {
+		return
+	}
+
+	sev := p.Config.severity(rule, at.file)
+	if sev == SeverityOff {
+		return
+	}
+
+	pos := p.positions.lookup(at.path)
+	p.Report(Diagnostic{
+		FilePath: at.file,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Message:  fmt.Sprintf(format, args...),
+		Rule:     rule,
+		Severity: sev,
+	})
+}
+
 // Lints returns all errors.
 func (p *Pass) Lints() []Diagnostic {
 	return p.lints
@@ -63,75 +114,249 @@ func (p *Pass) String() string {
 	return b.String()
 }
 
-// CompareModules looks for removed methods and added required fields.
+// CompareModules looks for changes between two versions of a module that
+// are not backwards compatible.
 func (p *Pass) CompareModules(from, to *compile.Module) {
-	for name, fromService := range from.Services {
-		p.service(fromService, to.Services[name])
+	p.positions = &positions{
+		from: readPositions(p.FromFS, from.ThriftPath),
+		to:   readPositions(p.ToFS, to.ThriftPath),
+	}
+	defer func() { p.positions = nil }()
+
+	for _, name := range sortedKeys(from.Services) {
+		p.service(from.Services[name], to.Services[name])
 	}
 
 	file := p.getRelativePath(from.ThriftPath)
-	for n, fromType := range from.Types {
-		p.typ(fromType, to.Types[n], file)
+	for _, n := range sortedKeys(from.Types) {
+		p.typ(from.Types[n], to.Types[n], file)
+	}
+}
+
+// DeletedModule reports changes that are not backwards compatible for a
+// module that was deleted entirely.
+func (p *Pass) DeletedModule(from *compile.Module) {
+	p.positions = &positions{from: readPositions(p.FromFS, from.ThriftPath)}
+	defer func() { p.positions = nil }()
+
+	// Deleted types are ok if their usages were also removed, so only
+	// services are reported.
+	for _, name := range sortedKeys(from.Services) {
+		p.service(from.Services[name], nil)
 	}
 }
 
 func (p *Pass) typ(from, to compile.TypeSpec, file string) {
-	if f, ok := from.(*compile.StructSpec); ok {
-		t, ok := to.(*compile.StructSpec)
-		if !ok {
-			// A struct was deleted which is ok if it's unused or
-			// it's usage was also removed.
-			return
+	if to == nil {
+		// A type was deleted which is ok if it's unused or
+		// it's usage was also removed.
+		return
+	}
+
+	at := site{file: file}.child(to.ThriftName(), typeAnnotations(to))
+	// Structs and exceptions have the same representation so their fields
+	// are compared instead.
+	fromKind, toKind := spec.Kind(from), spec.Kind(to)
+	if fromKind != toKind && !(spec.IsStructOrException(fromKind) && spec.IsStructOrException(toKind)) {
+		p.reportf(RuleTypeKindChanged, at,
+			"changing %q from %s to %s", from.ThriftName(), fromKind, toKind)
+		return
+	}
+
+	switch f := from.(type) {
+	case *compile.StructSpec:
+		t := to.(*compile.StructSpec)
+		p.fields(f.Fields, t.Fields, t.ThriftName(), at)
+		p.fieldIdentities(f.Fields, t.Fields, t.ThriftName(), at)
+		if f.Type == ast.UnionType {
+			p.unionArms(f, t, at)
+		}
+	case *compile.EnumSpec:
+		p.enumSpecs(f, to.(*compile.EnumSpec), at)
+	case *compile.TypedefSpec:
+		t := to.(*compile.TypedefSpec)
+		if !spec.Same(f.Target, t.Target) {
+			p.reportf(RuleTypedefTargetChanged, at,
+				"changing target of typedef %q from %q to %q",
+				t.ThriftName(), f.Target.ThriftName(), t.Target.ThriftName())
 		}
-		p.structSpecs(f, t, file)
 	}
 }
 
-func (p *Pass) requiredField(fromField, toField *compile.FieldSpec, to *compile.StructSpec, file string) {
+// typeAnnotations returns the annotations of a user-defined type.
+func typeAnnotations(spec compile.TypeSpec) compile.Annotations {
+	switch s := spec.(type) {
+	case *compile.StructSpec:
+		return s.Annotations
+	case *compile.EnumSpec:
+		return s.Annotations
+	case *compile.TypedefSpec:
+		return s.Annotations
+	default:
+		return nil
+	}
+}
+
+func (p *Pass) requiredField(fromField, toField *compile.FieldSpec, to string, at site) {
 	fromRequired := fromField.Required
 	if !fromRequired && toField.Required {
-		p.Report(Diagnostic{
-			FilePath: file,
-			Message: fmt.Sprintf(
-				"changing an optional field %q in %q to required",
-				toField.ThriftName(), to.ThriftName()),
-		})
+		p.reportf(RuleFieldOptionalToRequired, at,
+			"changing an optional field %q in %q to required",
+			toField.ThriftName(), to)
 	}
 }
 
-func (p *Pass) changedTypes(fromField, toField *compile.FieldSpec, to *compile.StructSpec, file string) {
+func (p *Pass) changedTypes(fromField, toField *compile.FieldSpec, to string, at site) {
 	if fromField.Type == nil || toField.Type == nil {
 		return
 	}
 
-	if fromField.Type.ThriftName() != toField.Type.ThriftName() {
-		p.Report(Diagnostic{
-			FilePath: file,
-			Message: fmt.Sprintf(
-				"changing type of field %q in struct %q from %q to %q",
-				toField.ThriftName(), to.ThriftName(), fromField.Type.ThriftName(),
-				toField.Type.ThriftName()),
-		})
+	if !spec.Same(fromField.Type, toField.Type) {
+		p.reportf(RuleFieldTypeChanged, at,
+			"changing type of field %q in struct %q from %q to %q",
+			toField.ThriftName(), to, fromField.Type.ThriftName(),
+			toField.Type.ThriftName())
 	}
 }
 
-// StructSpecs compares two structs defined in a Thrift file.
-func (p *Pass) structSpecs(from, to *compile.StructSpec, file string) {
-	fields := make(map[int16]*compile.FieldSpec, len(from.Fields))
+// fields compares the fields of two versions of a struct or a method's
+// arguments, matching fields by their IDs.
+func (p *Pass) fields(from, to compile.FieldGroup, owner string, at site) {
+	fields := make(map[int16]*compile.FieldSpec, len(from))
 	// Assume that these two should be compared.
-	for _, f := range from.Fields {
+	for _, f := range from {
 		fields[f.ID] = f
 	}
-	for _, toField := range to.Fields {
+	toIDs := make(map[int16]struct{}, len(to))
+	for _, toField := range to {
+		toIDs[toField.ID] = struct{}{}
+		fieldAt := at.child(toField.Name, toField.Annotations)
 		if fromField, ok := fields[toField.ID]; ok {
-			p.requiredField(fromField, toField, to, file)
-			p.changedTypes(fromField, toField, to, file)
+			p.requiredField(fromField, toField, owner, fieldAt)
+			// IDs given to a field with a different name are reported
+			// once by fieldIdentities.
+			if fromField.Name == toField.Name {
+				p.changedTypes(fromField, toField, owner, fieldAt)
+			}
 		} else if toField.Required {
-			p.Report(Diagnostic{
-				FilePath: file,
-				Message: fmt.Sprintf("adding a required field %q to %q",
-					toField.ThriftName(), to.ThriftName()),
-			})
+			p.reportf(RuleFieldRequiredAdded, fieldAt,
+				"adding a required field %q to %q", toField.ThriftName(), owner)
+		}
+	}
+	for _, fromField := range from {
+		if _, ok := toIDs[fromField.ID]; !ok && fromField.Required {
+			p.reportf(RuleFieldRequiredRemoved, at.child(fromField.Name, nil),
+				"removing a required field %q from %q", fromField.ThriftName(), owner)
+		}
+	}
+}
+
+// fieldIdentities compares the names and IDs of fields between two
+// versions of a struct or a method's arguments.
+func (p *Pass) fieldIdentities(from, to compile.FieldGroup, owner string, at site) {
+	fromByID := make(map[int16]*compile.FieldSpec, len(from))
+	for _, f := range from {
+		fromByID[f.ID] = f
+	}
+	toByName := make(map[string]*compile.FieldSpec, len(to))
+	for _, f := range to {
+		toByName[f.Name] = f
+	}
+
+	// Fields reported as renumbered, by name.
+	renumbered := make(map[string]struct{})
+	for _, fromField := range from {
+		if toField, ok := toByName[fromField.Name]; ok && toField.ID != fromField.ID {
+			renumbered[toField.Name] = struct{}{}
+			p.reportf(RuleFieldRenumbered, at.child(toField.Name, toField.Annotations),
+				"changing ID of field %q in %q from %d to %d",
+				fromField.ThriftName(), owner, fromField.ID, toField.ID)
+		}
+	}
+
+	for _, toField := range to {
+		fromField, ok := fromByID[toField.ID]
+		if !ok || fromField.Name == toField.Name {
+			continue
+		}
+		if _, ok := renumbered[toField.Name]; ok {
+			// Already reported as renumbered to this ID.
+			continue
+		}
+
+		fieldAt := at.child(toField.Name, toField.Annotations)
+		_, moved := toByName[fromField.Name]
+		if moved || !spec.Same(fromField.Type, toField.Type) {
+			p.reportf(RuleFieldIDReused, fieldAt,
+				"reusing ID %d of field %q in %q for field %q",
+				toField.ID, fromField.ThriftName(), owner, toField.ThriftName())
+		} else {
+			p.reportf(RuleFieldRenamed, fieldAt,
+				"renaming field %q in %q to %q breaks the JSON protocol",
+				fromField.ThriftName(), owner, toField.ThriftName())
+		}
+	}
+}
+
+// unionArms compares the arms of two versions of a union.
+func (p *Pass) unionArms(from, to *compile.StructSpec, at site) {
+	fromIDs := make(map[int16]struct{}, len(from.Fields))
+	for _, f := range from.Fields {
+		fromIDs[f.ID] = struct{}{}
+	}
+	toIDs := make(map[int16]struct{}, len(to.Fields))
+	for _, f := range to.Fields {
+		toIDs[f.ID] = struct{}{}
+		if _, ok := fromIDs[f.ID]; !ok {
+			p.reportf(RuleUnionArmAdded, at.child(f.Name, f.Annotations),
+				"adding arm %q to union %q", f.ThriftName(), to.ThriftName())
+		}
+	}
+	for _, f := range from.Fields {
+		if _, ok := toIDs[f.ID]; !ok {
+			p.reportf(RuleUnionArmRemoved, at.child(f.Name, nil),
+				"removing arm %q from union %q", f.ThriftName(), to.ThriftName())
+		}
+	}
+}
+
+// enumSpecs compares two versions of an enum. Items are matched by name.
+// Items that could not be matched by name are matched by value and
+// reported as renamed.
+func (p *Pass) enumSpecs(from, to *compile.EnumSpec, at site) {
+	items := make(map[string]compile.EnumItem, len(to.Items))
+	for _, item := range to.Items {
+		items[item.Name] = item
+	}
+	fromNames := make(map[string]struct{}, len(from.Items))
+	for _, item := range from.Items {
+		fromNames[item.Name] = struct{}{}
+	}
+	// Items that are new in to, by value.
+	added := make(map[int32]compile.EnumItem)
+	for _, item := range to.Items {
+		if _, ok := fromNames[item.Name]; !ok {
+			added[item.Value] = item
+		}
+	}
+
+	for _, fromItem := range from.Items {
+		toItem, ok := items[fromItem.Name]
+		if !ok {
+			toItem, ok = added[fromItem.Value]
+		}
+		switch {
+		case !ok:
+			p.reportf(RuleEnumItemRemoved, at.child(fromItem.Name, nil),
+				"removing item %q from enum %q", fromItem.Name, to.ThriftName())
+		case toItem.Name != fromItem.Name:
+			p.reportf(RuleEnumItemRenamed, at.child(toItem.Name, toItem.Annotations),
+				"renaming item %q in enum %q to %q breaks the JSON protocol",
+				fromItem.Name, to.ThriftName(), toItem.Name)
+		case toItem.Value != fromItem.Value:
+			p.reportf(RuleEnumItemRenumbered, at.child(toItem.Name, toItem.Annotations),
+				"changing value of item %q in enum %q from %d to %d",
+				fromItem.Name, to.ThriftName(), fromItem.Value, toItem.Value)
 		}
 	}
 }
@@ -139,16 +364,24 @@ func (p *Pass) structSpecs(from, to *compile.StructSpec, file string) {
 func (p *Pass) service(from, to *compile.ServiceSpec) {
 	if to == nil {
 		// Service was deleted, which is not backwards compatible.
-		p.Report(Diagnostic{
-			FilePath: filepath.Base(from.File), // toModule could have been deleted.
-			Message:  fmt.Sprintf("deleting service %q", from.Name),
-		})
-
+		// toModule could have been deleted.
+		at := site{file: filepath.Base(from.File), path: from.Name}
+		p.reportf(RuleServiceRemoved, at, "deleting service %q", from.Name)
 		return
 	}
-	file := p.getRelativePath(from.File)
-	for n := range from.Functions {
-		p.function(to.Functions[n], n, file, from.Name)
+	at := site{file: p.getRelativePath(from.File)}.child(to.Name, to.Annotations)
+	// Adding a parent only adds methods to the service, which is safe.
+	switch fromParent, toParent := spec.ParentName(from), spec.ParentName(to); {
+	case fromParent == "" || fromParent == toParent:
+	case toParent == "":
+		p.reportf(RuleServiceExtendsChanged, at,
+			"removing parent %q of service %q", fromParent, from.Name)
+	default:
+		p.reportf(RuleServiceExtendsChanged, at,
+			"changing parent of service %q from %q to %q", from.Name, fromParent, toParent)
+	}
+	for _, n := range sortedKeys(from.Functions) {
+		p.function(from.Functions[n], to.Functions[n], n, at, from.Name)
 	}
 }
 
@@ -163,12 +396,61 @@ func (p *Pass) getRelativePath(filePath string) string {
 	return filepath.Base(filePath)
 }
 
-func (p *Pass) function(to *compile.FunctionSpec, fn string, path string, service string) {
-	file := p.getRelativePath(path)
+func (p *Pass) function(from, to *compile.FunctionSpec, fn string, service site, serviceName string) {
 	if to == nil {
-		p.Report(Diagnostic{
-			FilePath: file,
-			Message:  fmt.Sprintf("removing method %q in service %q", fn, service),
-		})
+		p.reportf(RuleMethodRemoved, service.child(fn, nil),
+			"removing method %q in service %q", fn, serviceName)
+		return
+	}
+
+	at := service.child(fn, to.Annotations)
+	method := serviceName + "." + fn
+	if from.OneWay != to.OneWay {
+		p.reportf(RuleMethodOnewayChanged, at,
+			"changing method %q in service %q from oneway=%t to oneway=%t",
+			fn, serviceName, from.OneWay, to.OneWay)
+	}
+
+	args, toArgs := compile.FieldGroup(from.ArgsSpec), compile.FieldGroup(to.ArgsSpec)
+	p.fields(args, toArgs, method, at)
+	p.fieldIdentities(args, toArgs, method, at)
+
+	if from.ResultSpec == nil || to.ResultSpec == nil {
+		// oneway functions do not have results.
+		return
+	}
+
+	fromReturn, toReturn := from.ResultSpec.ReturnType, to.ResultSpec.ReturnType
+	if !spec.Same(fromReturn, toReturn) {
+		p.reportf(RuleMethodReturnTypeChanged, at,
+			"changing return type of method %q in service %q from %q to %q",
+			fn, serviceName, spec.Name(fromReturn), spec.Name(toReturn))
+	}
+
+	exceptions := make(map[int16]*compile.FieldSpec, len(to.ResultSpec.Exceptions))
+	for _, e := range to.ResultSpec.Exceptions {
+		exceptions[e.ID] = e
+	}
+	for _, e := range from.ResultSpec.Exceptions {
+		toException, ok := exceptions[e.ID]
+		if !ok {
+			p.reportf(RuleMethodExceptionRemoved, at,
+				"removing exception %q from method %q in service %q", e.ThriftName(), fn, serviceName)
+		} else if !spec.Same(e.Type, toException.Type) {
+			p.reportf(RuleMethodExceptionChanged, at.child(toException.Name, toException.Annotations),
+				"replacing exception %q of method %q in service %q with %q",
+				e.ThriftName(), fn, serviceName, toException.ThriftName())
+		}
+	}
+}
+
+// sortedKeys returns the keys of the given map in a stable order so that
+// diagnostics are reported deterministically.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
+	sort.Strings(keys)
+	return keys
 }
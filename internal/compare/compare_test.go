@@ -22,9 +22,11 @@ package compare
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/thriftrw/compile"
 )
 
@@ -93,13 +95,14 @@ func TestErrorRequiredCase(t *testing.T) {
 						Name:     "fieldA",
 					},
 					&compile.FieldSpec{
+						ID:       1,
 						Required: true,
 						Name:     "fieldB",
 					},
 				},
 			},
 			wantError: `foo.thrift:changing an optional field "fieldA" in "structA" to required` +
-				"\n" + `foo.thrift:changing an optional field "fieldB" in "structA" to required`,
+				"\n" + `foo.thrift:adding a required field "fieldB" to "structA"`,
 		},
 		{
 			desc: "found a type changed",
@@ -120,6 +123,7 @@ func TestErrorRequiredCase(t *testing.T) {
 						Type: &compile.BoolSpec{},
 					},
 					&compile.FieldSpec{
+						ID:   1,
 						Name: "fieldB",
 						Type: &compile.BinarySpec{},
 					},
@@ -134,7 +138,7 @@ func TestErrorRequiredCase(t *testing.T) {
 		t.Run(tt.desc, func(t *testing.T) {
 			t.Parallel()
 			pass := Pass{}
-			pass.structSpecs(tt.fromStruct, tt.toStruct, "foo.thrift")
+			pass.typ(tt.fromStruct, tt.toStruct, "foo.thrift")
 			want := fmt.Sprintf("%s\n", tt.wantError)
 			assert.Equal(t, want, pass.String(), "wrong lint diagnostics")
 		})
@@ -192,7 +196,7 @@ func TestRequiredCaseOk(t *testing.T) {
 		t.Run(tt.desc, func(t *testing.T) {
 			t.Parallel()
 			pass := Pass{}
-			pass.structSpecs(tt.fromStruct, tt.toStruct, "foo.thrift")
+			pass.typ(tt.fromStruct, tt.toStruct, "foo.thrift")
 			assert.Equal(t, "", pass.String(), "wrong lint diagnostics")
 		})
 	}
@@ -272,3 +276,223 @@ func TestRelativePath(t *testing.T) {
 	}
 
 }
+
+func TestCompareModulesRules(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		desc string
+		from string
+		to   string
+		want []Diagnostic
+	}{
+		{
+			desc: "field renumbered",
+			from: "struct S {\n 1: optional string a\n 2: optional string b\n}",
+			to:   "struct S {\n 1: optional string a\n 3: optional string b\n}",
+			want: []Diagnostic{
+				{Rule: RuleFieldRenumbered, Message: `changing ID of field "b" in "S" from 2 to 3`},
+			},
+		},
+		{
+			desc: "field renamed",
+			from: "struct S {\n 1: optional string a\n}",
+			to:   "struct S {\n 1: optional string b\n}",
+			want: []Diagnostic{
+				{Rule: RuleFieldRenamed, Message: `renaming field "a" in "S" to "b" breaks the JSON protocol`},
+			},
+		},
+		{
+			desc: "field ID reused with a different type",
+			from: "struct S {\n 1: optional string a\n}",
+			to:   "struct S {\n 1: optional i64 b\n}",
+			want: []Diagnostic{
+				{Rule: RuleFieldIDReused, Message: `reusing ID 1 of field "a" in "S" for field "b"`},
+			},
+		},
+		{
+			desc: "field ID reused after moving field",
+			from: "struct S {\n 1: optional string a\n}",
+			to:   "struct S {\n 1: optional string b\n 2: optional string a\n}",
+			want: []Diagnostic{
+				{Rule: RuleFieldRenumbered, Message: `changing ID of field "a" in "S" from 1 to 2`},
+				{Rule: RuleFieldIDReused, Message: `reusing ID 1 of field "a" in "S" for field "b"`},
+			},
+		},
+		{
+			desc: "field IDs swapped",
+			from: "struct S {\n 1: optional string a\n 2: optional string b\n}",
+			to:   "struct S {\n 1: optional string b\n 2: optional string a\n}",
+			want: []Diagnostic{
+				{Rule: RuleFieldRenumbered, Message: `changing ID of field "a" in "S" from 1 to 2`},
+				{Rule: RuleFieldRenumbered, Message: `changing ID of field "b" in "S" from 2 to 1`},
+			},
+		},
+		{
+			desc: "required field removed",
+			from: "struct S {\n 1: required string a\n}",
+			to:   "struct S {}",
+			want: []Diagnostic{
+				{Rule: RuleFieldRequiredRemoved, Message: `removing a required field "a" from "S"`},
+			},
+		},
+		{
+			desc: "typedef resolved for field types",
+			from: "struct S {\n 1: optional string a\n 2: optional list<string> b\n}",
+			to: "typedef string UUID\n" +
+				"struct S {\n 1: optional UUID a\n 2: optional list<UUID> b\n}",
+		},
+		{
+			desc: "typedef target changed",
+			from: "typedef string UUID",
+			to:   "typedef binary UUID",
+			want: []Diagnostic{
+				{Rule: RuleTypedefTargetChanged, Message: `changing target of typedef "UUID" from "string" to "binary"`},
+			},
+		},
+		{
+			desc: "typedef of typedef target unchanged",
+			from: "typedef string UUID",
+			to:   "typedef string Str\ntypedef Str UUID",
+		},
+		{
+			desc: "enum items",
+			from: "enum E {\n A = 1\n B = 2\n C = 3\n}",
+			to:   "enum E {\n A = 1\n B = 4\n D = 5\n}",
+			want: []Diagnostic{
+				{Rule: RuleEnumItemRenumbered, Message: `changing value of item "B" in enum "E" from 2 to 4`},
+				{Rule: RuleEnumItemRemoved, Message: `removing item "C" from enum "E"`},
+			},
+		},
+		{
+			desc: "enum item renamed",
+			from: "enum E {\n A = 1\n B = 2\n}",
+			to:   "enum E {\n A = 1\n C = 2\n}",
+			want: []Diagnostic{
+				{Rule: RuleEnumItemRenamed, Message: `renaming item "B" in enum "E" to "C" breaks the JSON protocol`},
+			},
+		},
+		{
+			desc: "union arms",
+			from: "union U {\n 1: string a\n 2: i64 b\n}",
+			to:   "union U {\n 1: string a\n 3: double c\n}",
+			want: []Diagnostic{
+				{Rule: RuleUnionArmAdded, Message: `adding arm "c" to union "U"`},
+				{Rule: RuleUnionArmRemoved, Message: `removing arm "b" from union "U"`},
+			},
+		},
+		{
+			desc: "exception fields",
+			from: "exception E {\n 1: optional string message\n}",
+			to:   "exception E {\n 1: optional string message\n 2: required i32 code\n}",
+			want: []Diagnostic{
+				{Rule: RuleFieldRequiredAdded, Message: `adding a required field "code" to "E"`},
+			},
+		},
+		{
+			desc: "type kind changed",
+			from: "struct S {}",
+			to:   "union S {}",
+			want: []Diagnostic{
+				{Rule: RuleTypeKindChanged, Message: `changing "S" from struct to union`},
+			},
+		},
+		{
+			desc: "struct changed to exception",
+			from: "struct S {\n 1: optional string a\n}",
+			to:   "exception S {\n 1: required string a\n}",
+			want: []Diagnostic{
+				{Rule: RuleFieldOptionalToRequired, Message: `changing an optional field "a" in "S" to required`},
+			},
+		},
+		{
+			desc: "method arguments",
+			from: "service S {\n void m(1: string a, 2: i32 b)\n}",
+			to:   "service S {\n void m(1: binary a, 3: i32 b, 4: required string c)\n}",
+			want: []Diagnostic{
+				{Rule: RuleFieldTypeChanged, Message: `changing type of field "a" in struct "S.m" from "string" to "binary"`},
+				{Rule: RuleFieldRequiredAdded, Message: `adding a required field "c" to "S.m"`},
+				{Rule: RuleFieldRenumbered, Message: `changing ID of field "b" in "S.m" from 2 to 3`},
+			},
+		},
+		{
+			desc: "method return type",
+			from: "service S {\n string m()\n}",
+			to:   "service S {\n void m()\n}",
+			want: []Diagnostic{
+				{Rule: RuleMethodReturnTypeChanged, Message: `changing return type of method "m" in service "S" from "string" to "void"`},
+			},
+		},
+		{
+			desc: "method return type typedef",
+			from: "service S {\n string m()\n}",
+			to:   "typedef string Str\nservice S {\n Str m()\n}",
+		},
+		{
+			desc: "method exceptions",
+			from: "exception E1 {}\nexception E2 {}\n" +
+				"service S {\n void m() throws (1: E1 e1, 2: E2 e2)\n}",
+			to: "exception E1 {}\nexception E2 {}\n" +
+				"service S {\n void m() throws (1: E2 e2)\n}",
+			want: []Diagnostic{
+				{Rule: RuleMethodExceptionChanged, Message: `replacing exception "e1" of method "m" in service "S" with "e2"`},
+				{Rule: RuleMethodExceptionRemoved, Message: `removing exception "e2" from method "m" in service "S"`},
+			},
+		},
+		{
+			desc: "oneway toggled",
+			from: "service S {\n oneway void m()\n}",
+			to:   "service S {\n void m()\n}",
+			want: []Diagnostic{
+				{Rule: RuleMethodOnewayChanged, Message: `changing method "m" in service "S" from oneway=true to oneway=false`},
+			},
+		},
+		{
+			desc: "extends changed",
+			from: "service A {}\nservice B {}\nservice S extends A {}",
+			to:   "service A {}\nservice B {}\nservice S extends B {}",
+			want: []Diagnostic{
+				{Rule: RuleServiceExtendsChanged, Message: `changing parent of service "S" from "A" to "B"`},
+			},
+		},
+		{
+			desc: "extends removed",
+			from: "service A {}\nservice S extends A {}",
+			to:   "service A {}\nservice S {}",
+			want: []Diagnostic{
+				{Rule: RuleServiceExtendsChanged, Message: `removing parent "A" of service "S"`},
+			},
+		},
+		{
+			desc: "extends added",
+			from: "service A {}\nservice S {}",
+			to:   "service A {}\nservice S extends A {}",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			writeFiles(t, dir, map[string]string{
+				"from/foo.thrift": tt.from,
+				"to/foo.thrift":   tt.to,
+			})
+
+			pass, err := ComparePaths(
+				filepath.Join(dir, "from/foo.thrift"),
+				filepath.Join(dir, "to/foo.thrift"),
+				nil,
+			)
+			require.NoError(t, err)
+
+			// Positions and severities are covered by other tests.
+			var got []Diagnostic
+			for _, d := range pass.Lints() {
+				assert.Equal(t, "foo.thrift", d.FilePath)
+				got = append(got, Diagnostic{Rule: d.Rule, Message: d.Message})
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
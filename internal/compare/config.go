@@ -0,0 +1,144 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compare
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config configures the rules reported by thriftbreak.
+//
+//	rules:
+//	  field-renamed: off
+//	  union-arm-added: warning
+//	overrides:
+//	  - paths: ["legacy/"]
+//	    rules:
+//	      field-id-reused: warning
+type Config struct {
+	// Rules changes the severity of rules for all files.
+	Rules map[Rule]Severity `yaml:"rules"`
+
+	// Overrides changes the severity of rules for specific files. Later
+	// overrides take precedence over earlier ones.
+	Overrides []ConfigOverride `yaml:"overrides"`
+}
+
+// ConfigOverride changes the severity of rules for files matching any of
+// the given paths.
+type ConfigOverride struct {
+	// Paths are slash-separated patterns relative to the root of the
+	// comparison, matched with path.Match. Patterns that end with "/"
+	// match all files inside that directory.
+	Paths []string `yaml:"paths"`
+
+	Rules map[Rule]Severity `yaml:"rules"`
+}
+
+// LoadConfig reads a Config from the YAML file at the given path.
+func LoadConfig(filename string) (*Config, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return nil, fmt.Errorf("parse %q: %w", filename, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %q: %w", filename, err)
+	}
+	return &cfg, nil
+}
+
+func (c *Config) validate() error {
+	if err := validateRules(c.Rules); err != nil {
+		return err
+	}
+	for _, o := range c.Overrides {
+		for _, pattern := range o.Paths {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("bad path pattern %q: %w", pattern, err)
+			}
+		}
+		if err := validateRules(o.Rules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateRules(rules map[Rule]Severity) error {
+	for rule, sev := range rules {
+		if _, ok := defaultSeverities[rule]; !ok {
+			return fmt.Errorf("unknown rule %q", rule)
+		}
+		switch sev {
+		case SeverityError, SeverityWarning, SeverityOff:
+		default:
+			return fmt.Errorf("unknown severity %q for rule %q: "+
+				"must be one of %q, %q, or %q",
+				sev, rule, SeverityError, SeverityWarning, SeverityOff)
+		}
+	}
+	return nil
+}
+
+// severity returns the severity of the given rule for the given file.
+func (c *Config) severity(rule Rule, file string) Severity {
+	sev := defaultSeverities[rule]
+	if c == nil {
+		return sev
+	}
+
+	if s, ok := c.Rules[rule]; ok {
+		sev = s
+	}
+	file = filepath.ToSlash(file)
+	for _, o := range c.Overrides {
+		if s, ok := o.Rules[rule]; ok && o.matches(file) {
+			sev = s
+		}
+	}
+	return sev
+}
+
+func (o *ConfigOverride) matches(file string) bool {
+	for _, pattern := range o.Paths {
+		if strings.HasSuffix(pattern, "/") {
+			if strings.HasPrefix(file, pattern) {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, file); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compare
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		desc    string
+		give    string
+		want    *Config
+		wantErr string
+	}{
+		{
+			desc: "valid",
+			give: "rules:\n" +
+				"  field-renamed: off\n" +
+				"overrides:\n" +
+				"  - paths: [legacy/]\n" +
+				"    rules:\n" +
+				"      field-id-reused: warning\n",
+			want: &Config{
+				Rules: map[Rule]Severity{RuleFieldRenamed: SeverityOff},
+				Overrides: []ConfigOverride{{
+					Paths: []string{"legacy/"},
+					Rules: map[Rule]Severity{RuleFieldIDReused: SeverityWarning},
+				}},
+			},
+		},
+		{
+			desc:    "unknown rule",
+			give:    "rules:\n  not-a-rule: off\n",
+			wantErr: `unknown rule "not-a-rule"`,
+		},
+		{
+			desc:    "unknown severity",
+			give:    "overrides:\n  - paths: [foo.thrift]\n    rules:\n      field-renamed: fatal\n",
+			wantErr: `unknown severity "fatal" for rule "field-renamed"`,
+		},
+		{
+			desc:    "bad pattern",
+			give:    "overrides:\n  - paths: ['[']\n",
+			wantErr: `bad path pattern "["`,
+		},
+		{
+			desc:    "not YAML",
+			give:    "rules: [",
+			wantErr: "parse",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.give), 0o644))
+
+			cfg, err := LoadConfig(path)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg)
+		})
+	}
+}
+
+func TestConfigSeverity(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		Rules: map[Rule]Severity{
+			RuleFieldRenamed:  SeverityError,
+			RuleFieldIDReused: SeverityWarning,
+		},
+		Overrides: []ConfigOverride{
+			{
+				Paths: []string{"legacy/"},
+				Rules: map[Rule]Severity{RuleFieldIDReused: SeverityOff},
+			},
+			{
+				Paths: []string{"legacy/keep.thrift", "*.thrift"},
+				Rules: map[Rule]Severity{RuleFieldIDReused: SeverityError},
+			},
+		},
+	}
+
+	tests := []struct {
+		rule Rule
+		file string
+		want Severity
+	}{
+		{RuleMethodRemoved, "foo.thrift", SeverityError},
+		{RuleEnumItemRenamed, "foo.thrift", SeverityWarning},
+		{RuleFieldRenamed, "foo.thrift", SeverityError},
+		{RuleFieldIDReused, "sub/foo.thrift", SeverityWarning},
+		{RuleFieldIDReused, "foo.thrift", SeverityError},
+		{RuleFieldIDReused, "legacy/nested/foo.thrift", SeverityOff},
+		{RuleFieldIDReused, "legacy/keep.thrift", SeverityError},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, cfg.severity(tt.rule, tt.file),
+			"severity of %q in %q", tt.rule, tt.file)
+	}
+
+	var nilConfig *Config
+	assert.Equal(t, SeverityWarning, nilConfig.severity(RuleFieldRenamed, "foo.thrift"))
+}
+
+func TestEveryRuleHasDefaultSeverity(t *testing.T) {
+	t.Parallel()
+	for rule, sev := range defaultSeverities {
+		assert.NotEmpty(t, rule)
+		assert.Contains(t, []Severity{SeverityError, SeverityWarning}, sev, "rule %q", rule)
+	}
+}
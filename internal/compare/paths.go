@@ -0,0 +1,177 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compare
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// ComparePaths compares two versions of Thrift files that are present on
+// the local filesystem rather than in a git repository.
+//
+// from and to must either both be Thrift files or both be directories. For
+// directories, Thrift files are matched by their paths relative to from and
+// to. Files present only in from are treated as deleted and files present
+// only in to are treated as added. cfg may be nil to use the default
+// configuration.
+func ComparePaths(from, to string, cfg *Config) (Pass, error) {
+	// Compiled modules hold absolute paths so resolve these upfront to be
+	// able to report paths relative to from.
+	from, err := filepath.Abs(from)
+	if err != nil {
+		return Pass{}, err
+	}
+	to, err = filepath.Abs(to)
+	if err != nil {
+		return Pass{}, err
+	}
+
+	fromInfo, err := os.Stat(from)
+	if err != nil {
+		return Pass{}, err
+	}
+	toInfo, err := os.Stat(to)
+	if err != nil {
+		return Pass{}, err
+	}
+
+	switch {
+	case !fromInfo.IsDir() && !toInfo.IsDir():
+		pass := Pass{GitDir: filepath.Dir(from), Config: cfg, FromFS: osFS{}, ToFS: osFS{}}
+		return pass, pass.compareFiles(from, to)
+	case fromInfo.IsDir() && toInfo.IsDir():
+		return compareDirs(from, to, cfg)
+	default:
+		return Pass{}, fmt.Errorf(
+			"cannot compare %q with %q: both must be files or both must be directories", from, to)
+	}
+}
+
+func compareDirs(from, to string, cfg *Config) (Pass, error) {
+	pass := Pass{GitDir: from, Config: cfg, FromFS: osFS{}, ToFS: osFS{}}
+
+	fromFiles, err := findThriftFiles(from)
+	if err != nil {
+		return pass, err
+	}
+	toFiles, err := findThriftFiles(to)
+	if err != nil {
+		return pass, err
+	}
+
+	files := make([]string, 0, len(fromFiles)+len(toFiles))
+	for f := range fromFiles {
+		files = append(files, f)
+	}
+	for f := range toFiles {
+		if _, ok := fromFiles[f]; !ok {
+			files = append(files, f)
+		}
+	}
+	sort.Strings(files)
+
+	for _, f := range files {
+		fromPath, toPath := filepath.Join(from, f), filepath.Join(to, f)
+		_, inFrom := fromFiles[f]
+		_, inTo := toFiles[f]
+
+		var err error
+		switch {
+		case inFrom && inTo:
+			err = pass.compareFiles(fromPath, toPath)
+		case inFrom:
+			err = pass.compareFiles(fromPath, "")
+		default:
+			err = pass.compareFiles("", toPath)
+		}
+		if err != nil {
+			return pass, err
+		}
+	}
+
+	return pass, nil
+}
+
+// compareFiles compiles and compares the given Thrift files through
+// p.FromFS and p.ToFS. An empty path indicates that the file does not exist
+// on that side of the comparison.
+func (p *Pass) compareFiles(from, to string) error {
+	var toModule *compile.Module
+	if to != "" {
+		m, err := compile.Compile(to, compile.Filesystem(p.ToFS))
+		if err != nil {
+			return err
+		}
+		toModule = m
+	}
+
+	if from == "" {
+		// A new file was added so there is nothing that it could have
+		// broken. It is still compiled above to surface errors.
+		return nil
+	}
+
+	fromModule, err := compile.Compile(from, compile.Filesystem(p.FromFS))
+	if err != nil {
+		return err
+	}
+
+	if toModule == nil {
+		p.DeletedModule(fromModule)
+		return nil
+	}
+	p.CompareModules(fromModule, toModule)
+	return nil
+}
+
+// findThriftFiles returns the paths of all Thrift files inside dir, relative
+// to dir.
+func findThriftFiles(dir string) (map[string]struct{}, error) {
+	files := make(map[string]struct{})
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".thrift" {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[rel] = struct{}{}
+		return nil
+	})
+	return files, err
+}
+
+// osFS is a compile.FS that reads from the local filesystem.
+type osFS struct{}
+
+func (osFS) Read(filename string) ([]byte, error) { return os.ReadFile(filename) }
+
+func (osFS) Abs(p string) (string, error) { return filepath.Abs(p) }
@@ -0,0 +1,134 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compare
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+	}
+}
+
+func TestComparePathsDirectories(t *testing.T) {
+	from, to := t.TempDir(), t.TempDir()
+	writeFiles(t, from, map[string]string{
+		"a.thrift":        "service Foo {\n    void methodA()\n}",
+		"sub/b.thrift":    `include "../a.thrift"` + "\nstruct S {\n    1: optional string x\n}",
+		"deleted.thrift":  "service Bar {}",
+		"not-thrift.json": "{}",
+	})
+	writeFiles(t, to, map[string]string{
+		"a.thrift":     "service Foo {}",
+		"sub/b.thrift": `include "../a.thrift"` + "\nstruct S {\n    1: required string x\n}",
+		"new.thrift":   `include "./a.thrift"` + "\nservice Baz {}",
+	})
+
+	pass, err := ComparePaths(from, to, nil)
+	require.NoError(t, err)
+	assert.Equal(t,
+		`a.thrift:1:1:removing method "methodA" in service "Foo"`+"\n"+
+			`deleted.thrift:1:1:deleting service "Bar"`+"\n"+
+			`sub/b.thrift:3:5:changing an optional field "x" in "S" to required`+"\n",
+		pass.String())
+}
+
+func TestComparePathsAddedFileWithErrors(t *testing.T) {
+	from, to := t.TempDir(), t.TempDir()
+	writeFiles(t, to, map[string]string{
+		"new.thrift": `include "./missing.thrift"`,
+	})
+
+	_, err := ComparePaths(from, to, nil)
+	assert.ErrorContains(t, err, "missing.thrift")
+}
+
+func TestComparePathsFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"v1/foo.thrift": "service Foo {\n    void methodA()\n}",
+		"v2/foo.thrift": "service Foo {}",
+	})
+
+	pass, err := ComparePaths(
+		filepath.Join(dir, "v1/foo.thrift"),
+		filepath.Join(dir, "v2/foo.thrift"),
+		nil,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, `foo.thrift:1:1:removing method "methodA" in service "Foo"`+"\n", pass.String())
+}
+
+func TestComparePathsErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"foo.thrift": "service Foo {}"})
+
+	_, err := ComparePaths(dir, filepath.Join(dir, "foo.thrift"), nil)
+	assert.ErrorContains(t, err, "both must be files or both must be directories")
+
+	_, err = ComparePaths(filepath.Join(dir, "missing"), dir, nil)
+	assert.Error(t, err)
+}
+
+// memFS is a compile.FS backed by an in-memory map of absolute paths.
+type memFS map[string]string
+
+func (fs memFS) Read(path string) ([]byte, error) {
+	if contents, ok := fs[path]; ok {
+		return []byte(contents), nil
+	}
+	return nil, fmt.Errorf("file not found: %q", path)
+}
+
+func (memFS) Abs(p string) (string, error) { return p, nil }
+
+func TestCompareFilesUsesFS(t *testing.T) {
+	pass := Pass{
+		GitDir: "/idl",
+		FromFS: memFS{
+			"/idl/foo.thrift":     "service Foo {\n    void methodA()\n}",
+			"/idl/deleted.thrift": "\nservice Bar {}",
+		},
+		ToFS: memFS{
+			"/idl/foo.thrift": "service Foo {}",
+			"/idl/new.thrift": "service Baz {}",
+		},
+	}
+
+	require.NoError(t, pass.compareFiles("/idl/foo.thrift", "/idl/foo.thrift"))
+	require.NoError(t, pass.compareFiles("/idl/deleted.thrift", ""))
+	require.NoError(t, pass.compareFiles("", "/idl/new.thrift"))
+	assert.Equal(t,
+		`foo.thrift:1:1:removing method "methodA" in service "Foo"`+"\n"+
+			`deleted.thrift:2:1:deleting service "Bar"`+"\n",
+		pass.String())
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compare
+
+// Severity is the severity of a Diagnostic.
+type Severity string
+
+const (
+	// SeverityError marks breaking changes. These cause thriftbreak to
+	// fail.
+	SeverityError Severity = "error"
+
+	// SeverityWarning marks changes that may break some users.
+	SeverityWarning Severity = "warning"
+
+	// SeverityOff disables a rule. Diagnostics are never reported with this
+	// severity.
+	SeverityOff Severity = "off"
+)
+
+// Rule is the stable identifier of a compatibility check. Rule IDs are
+// never reused or renamed so that they may be referenced from outside
+// thriftbreak.
+type Rule string
+
+// Rules reported for services and their methods.
+const (
+	// RuleServiceRemoved reports services that were deleted.
+	RuleServiceRemoved Rule = "service-removed"
+
+	// RuleServiceExtendsChanged reports services whose parent service was
+	// removed or replaced. This removes methods the service responds to.
+	// Adding a parent to a service that had none is not reported.
+	RuleServiceExtendsChanged Rule = "service-extends-changed"
+
+	// RuleMethodRemoved reports methods that were deleted from a service.
+	RuleMethodRemoved Rule = "method-removed"
+
+	// RuleMethodOnewayChanged reports methods that were changed to or from
+	// oneway. Callers and servers disagree on whether a response is sent.
+	RuleMethodOnewayChanged Rule = "method-oneway-changed"
+
+	// RuleMethodReturnTypeChanged reports methods whose return type was
+	// changed.
+	RuleMethodReturnTypeChanged Rule = "method-return-type-changed"
+
+	// RuleMethodExceptionRemoved reports exceptions that were removed from
+	// the throws clause of a method. Callers will fail to decode responses
+	// from servers that still throw them.
+	RuleMethodExceptionRemoved Rule = "method-exception-removed"
+
+	// RuleMethodExceptionChanged reports exceptions in the throws clause of
+	// a method whose type was changed without changing their field ID.
+	RuleMethodExceptionChanged Rule = "method-exception-changed"
+)
+
+// Rules reported for fields of structs, unions, exceptions, and method
+// arguments.
+const (
+	// RuleFieldRequiredAdded reports new required fields.
+	RuleFieldRequiredAdded Rule = "field-required-added"
+
+	// RuleFieldRequiredRemoved reports required fields that were deleted.
+	// Older readers will reject values that do not have them.
+	RuleFieldRequiredRemoved Rule = "field-required-removed"
+
+	// RuleFieldOptionalToRequired reports optional fields that were made
+	// required.
+	RuleFieldOptionalToRequired Rule = "field-optional-to-required"
+
+	// RuleFieldTypeChanged reports fields whose type was changed. Types
+	// are compared after resolving typedefs.
+	RuleFieldTypeChanged Rule = "field-type-changed"
+
+	// RuleFieldRenumbered reports fields that kept their name but were
+	// given a different field ID.
+	RuleFieldRenumbered Rule = "field-renumbered"
+
+	// RuleFieldRenamed reports fields that kept their ID but were given a
+	// different name. This is safe for the binary protocol but breaks
+	// protocols and encodings that identify fields by name, such as JSON.
+	RuleFieldRenamed Rule = "field-renamed"
+
+	// RuleFieldIDReused reports field IDs that were given to a different
+	// field: either to a field of a different type, or while the field
+	// that previously held the ID still exists under a different ID.
+	RuleFieldIDReused Rule = "field-id-reused"
+
+	// RuleUnionArmAdded reports new arms of a union. Older readers reject
+	// unions that do not have exactly one known arm set.
+	RuleUnionArmAdded Rule = "union-arm-added"
+
+	// RuleUnionArmRemoved reports arms that were removed from a union.
+	RuleUnionArmRemoved Rule = "union-arm-removed"
+)
+
+// Rules reported for type declarations.
+const (
+	// RuleTypeKindChanged reports types that changed between struct,
+	// union, exception, enum, and typedef.
+	RuleTypeKindChanged Rule = "type-kind-changed"
+
+	// RuleEnumItemRemoved reports items that were removed from an enum.
+	RuleEnumItemRemoved Rule = "enum-item-removed"
+
+	// RuleEnumItemRenumbered reports enum items that kept their name but
+	// were given a different value.
+	RuleEnumItemRenumbered Rule = "enum-item-renumbered"
+
+	// RuleEnumItemRenamed reports enum items that kept their value but
+	// were given a different name. This is compatible with binary
+	// protocols but breaks JSON, which uses the names of items.
+	RuleEnumItemRenamed Rule = "enum-item-renamed"
+
+	// RuleTypedefTargetChanged reports typedefs whose underlying type
+	// changed.
+	RuleTypedefTargetChanged Rule = "typedef-target-changed"
+)
+
+// defaultSeverities holds the default severity of every known rule.
+var defaultSeverities = map[Rule]Severity{
+	RuleServiceRemoved:          SeverityError,
+	RuleServiceExtendsChanged:   SeverityError,
+	RuleMethodRemoved:           SeverityError,
+	RuleMethodOnewayChanged:     SeverityError,
+	RuleMethodReturnTypeChanged: SeverityError,
+	RuleMethodExceptionRemoved:  SeverityError,
+	RuleMethodExceptionChanged:  SeverityError,
+	RuleFieldRequiredAdded:      SeverityError,
+	RuleFieldRequiredRemoved:    SeverityError,
+	RuleFieldOptionalToRequired: SeverityError,
+	RuleFieldTypeChanged:        SeverityError,
+	RuleFieldRenumbered:         SeverityError,
+	RuleFieldRenamed:            SeverityWarning,
+	RuleFieldIDReused:           SeverityError,
+	RuleUnionArmAdded:           SeverityError,
+	RuleUnionArmRemoved:         SeverityError,
+	RuleTypeKindChanged:         SeverityError,
+	RuleEnumItemRemoved:         SeverityError,
+	RuleEnumItemRenumbered:      SeverityError,
+	RuleEnumItemRenamed:         SeverityWarning,
+	RuleTypedefTargetChanged:    SeverityError,
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compare
+
+import (
+	"strings"
+
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/idl"
+)
+
+// IgnoreAnnotation is the annotation used to acknowledge intentional
+// breaking changes. Its value is a comma-separated list of rule IDs that
+// are not reported for the annotated element and the elements inside it.
+//
+//	struct User {
+//	  1: optional string userName (thriftbreak.ignore = "field-renamed")
+//	}
+const IgnoreAnnotation = "thriftbreak.ignore"
+
+// site identifies the element of a Thrift file that a diagnostic is
+// reported for.
+type site struct {
+	file string
+
+	// path is a dot-separated path to the element, e.g.
+	// "Service.method.arg".
+	path string
+
+	// annotations of the element and the elements containing it in the new
+	// version of the file.
+	annotations []compile.Annotations
+}
+
+// child returns the site of an element named name inside this element.
+func (s site) child(name string, annotations compile.Annotations) site {
+	path := name
+	if s.path != "" {
+		path = s.path + "." + name
+	}
+
+	anns := s.annotations
+	if len(annotations) > 0 {
+		anns = append(anns[:len(anns):len(anns)], annotations)
+	}
+	return site{file: s.file, path: path, annotations: anns}
+}
+
+// ignores reports whether the given rule was suppressed with
+// IgnoreAnnotation for this site.
+func (s site) ignores(rule Rule) bool {
+	for _, anns := range s.annotations {
+		for _, r := range strings.Split(anns[IgnoreAnnotation], ",") {
+			if Rule(strings.TrimSpace(r)) == rule {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// positions holds the positions of elements in the previous and new
+// versions of a Thrift file, indexed by their site paths.
+type positions struct {
+	from, to map[string]ast.Position
+}
+
+// lookup returns the position of the element at the given path.
+//
+// Positions in the new version of the file are preferred. If the element
+// does not exist there, the position of the closest element containing it
+// is used. The previous version of the file is used only if none of these
+// exist, for example, for deleted services.
+func (ps *positions) lookup(path string) ast.Position {
+	if ps == nil {
+		return ast.Position{}
+	}
+
+	for p := path; p != ""; {
+		if pos, ok := ps.to[p]; ok {
+			return pos
+		}
+		i := strings.LastIndexByte(p, '.')
+		if i < 0 {
+			break
+		}
+		p = p[:i]
+	}
+	return ps.from[path]
+}
+
+// readPositions reads and parses the given Thrift file, returning the
+// positions of its elements. Positions are best-effort: nil is returned if
+// the file cannot be read or parsed.
+func readPositions(fs compile.FS, path string) map[string]ast.Position {
+	if fs == nil || path == "" {
+		return nil
+	}
+	b, err := fs.Read(path)
+	if err != nil {
+		return nil
+	}
+	prog, err := idl.Parse(b)
+	if err != nil {
+		return nil
+	}
+
+	idx := make(map[string]ast.Position)
+	addFields := func(prefix string, fields []*ast.Field) {
+		for _, f := range fields {
+			idx[prefix+"."+f.Name] = ast.Position{Line: f.Line, Column: f.Column}
+		}
+	}
+	for _, d := range prog.Definitions {
+		info := d.Info()
+		idx[info.Name] = ast.Position{Line: info.Line, Column: info.Column}
+
+		switch d := d.(type) {
+		case *ast.Struct:
+			addFields(d.Name, d.Fields)
+		case *ast.Enum:
+			for _, item := range d.Items {
+				idx[d.Name+"."+item.Name] = ast.Position{Line: item.Line, Column: item.Column}
+			}
+		case *ast.Service:
+			for _, fn := range d.Functions {
+				name := d.Name + "." + fn.Name
+				idx[name] = ast.Position{Line: fn.Line, Column: fn.Column}
+				addFields(name, fn.Parameters)
+				addFields(name, fn.Exceptions)
+			}
+		}
+	}
+	return idx
+}
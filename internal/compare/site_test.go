@@ -0,0 +1,172 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compare
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnosticString(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		desc string
+		give Diagnostic
+		want string
+	}{
+		{
+			desc: "no position",
+			give: Diagnostic{FilePath: "foo.thrift", Message: "error"},
+			want: "foo.thrift:error",
+		},
+		{
+			desc: "position",
+			give: Diagnostic{FilePath: "foo.thrift", Line: 3, Column: 5, Message: "error", Severity: SeverityError},
+			want: "foo.thrift:3:5:error",
+		},
+		{
+			desc: "warning",
+			give: Diagnostic{FilePath: "foo.thrift", Line: 3, Column: 5, Message: "oops", Severity: SeverityWarning},
+			want: "foo.thrift:3:5:warning: oops",
+		},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.give.String(), tt.desc)
+	}
+}
+
+func TestDiagnosticPositions(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"from/foo.thrift": "struct S {\n" +
+			"  1: required string a\n" +
+			"  2: optional string b\n" +
+			"}\n" +
+			"service Svc {\n" +
+			"  void m(1: string x)\n" +
+			"}\n" +
+			"service Gone {}\n",
+		"to/foo.thrift": "\n" +
+			"struct S {\n" +
+			"  2: optional string c\n" +
+			"}\n" +
+			"service Svc {\n" +
+			"  void m(1: i32 x)\n" +
+			"}\n",
+	})
+
+	pass, err := ComparePaths(
+		filepath.Join(dir, "from/foo.thrift"),
+		filepath.Join(dir, "to/foo.thrift"),
+		nil,
+	)
+	require.NoError(t, err)
+	assert.Equal(t,
+		// Removed elements are reported at the position of the
+		// element that contained them.
+		`foo.thrift:8:1:deleting service "Gone"`+"\n"+
+			`foo.thrift:6:10:changing type of field "x" in struct "Svc.m" from "string" to "i32"`+"\n"+
+			`foo.thrift:2:1:removing a required field "a" from "S"`+"\n"+
+			`foo.thrift:3:3:warning: renaming field "b" in "S" to "c" breaks the JSON protocol`+"\n",
+		pass.String())
+}
+
+func TestIgnoreAnnotation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		desc string
+		to   string
+		want string
+	}{
+		{
+			desc: "not ignored",
+			to:   "struct S {\n  1: optional i64 a\n}",
+			want: `foo.thrift:2:3:changing type of field "a" in struct "S" from "string" to "i64"` + "\n",
+		},
+		{
+			desc: "ignored on field",
+			to:   "struct S {\n  1: optional i64 a (thriftbreak.ignore = \"field-type-changed\")\n}",
+		},
+		{
+			desc: "ignored on struct",
+			to:   "struct S {\n  1: optional i64 a\n} (thriftbreak.ignore = \"field-renamed, field-type-changed\")",
+		},
+		{
+			desc: "other rule ignored",
+			to:   "struct S {\n  1: optional i64 a (thriftbreak.ignore = \"field-renamed\")\n}",
+			want: `foo.thrift:2:3:changing type of field "a" in struct "S" from "string" to "i64"` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			writeFiles(t, dir, map[string]string{
+				"from/foo.thrift": "struct S {\n  1: optional string a\n}",
+				"to/foo.thrift":   tt.to,
+			})
+
+			pass, err := ComparePaths(
+				filepath.Join(dir, "from/foo.thrift"),
+				filepath.Join(dir, "to/foo.thrift"),
+				nil,
+			)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, pass.String())
+		})
+	}
+}
+
+func TestComparePathsConfig(t *testing.T) {
+	t.Parallel()
+	from, to := t.TempDir(), t.TempDir()
+	writeFiles(t, from, map[string]string{
+		"a.thrift":        "struct S {\n  1: optional string a\n}",
+		"legacy/b.thrift": "struct S {\n  1: optional string a\n}",
+	})
+	writeFiles(t, to, map[string]string{
+		"a.thrift":        "struct S {\n  1: optional string b\n}",
+		"legacy/b.thrift": "struct S {\n  1: optional string b\n}",
+	})
+
+	pass, err := ComparePaths(from, to, &Config{
+		Rules: map[Rule]Severity{RuleFieldRenamed: SeverityError},
+		Overrides: []ConfigOverride{{
+			Paths: []string{"legacy/"},
+			Rules: map[Rule]Severity{RuleFieldRenamed: SeverityOff},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Diagnostic{{
+		FilePath: "a.thrift",
+		Line:     2,
+		Column:   3,
+		Message:  `renaming field "a" in "S" to "b" breaks the JSON protocol`,
+		Rule:     RuleFieldRenamed,
+		Severity: SeverityError,
+	}}, pass.Lints())
+}
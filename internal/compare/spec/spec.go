@@ -0,0 +1,105 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package spec holds helpers shared by the public compare package and
+// thriftbreak to inspect compiled Thrift specs.
+package spec
+
+import (
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/compile"
+)
+
+// ParentName returns the name of the service extended by the given service,
+// or an empty string if it does not extend another service.
+func ParentName(s *compile.ServiceSpec) string {
+	if s.Parent == nil {
+		return ""
+	}
+	return s.Parent.Name
+}
+
+// Kind describes the kind of declaration of a user-defined type.
+func Kind(spec compile.TypeSpec) string {
+	switch s := spec.(type) {
+	case *compile.StructSpec:
+		switch s.Type {
+		case ast.UnionType:
+			return "union"
+		case ast.ExceptionType:
+			return "exception"
+		default:
+			return "struct"
+		}
+	case *compile.EnumSpec:
+		return "enum"
+	case *compile.TypedefSpec:
+		return "typedef"
+	default:
+		return spec.ThriftName()
+	}
+}
+
+// IsStructOrException reports whether the given kind, as returned by Kind,
+// is a struct or an exception. These have the same wire representation.
+func IsStructOrException(kind string) bool {
+	return kind == "struct" || kind == "exception"
+}
+
+// Name returns the name of a type or "void" for nil types, which stand for
+// the return types of functions that do not return anything.
+func Name(spec compile.TypeSpec) string {
+	if spec == nil {
+		return "void"
+	}
+	return spec.ThriftName()
+}
+
+// Root resolves typedefs to their underlying types. Typedefs that have not
+// been linked are returned as-is.
+func Root(spec compile.TypeSpec) compile.TypeSpec {
+	if root := compile.RootTypeSpec(spec); root != nil {
+		return root
+	}
+	return spec
+}
+
+// Same reports whether two types are the same after resolving typedefs,
+// including typedefs of the items in containers.
+func Same(from, to compile.TypeSpec) bool {
+	if from == nil || to == nil {
+		return from == nil && to == nil
+	}
+
+	from, to = Root(from), Root(to)
+	switch f := from.(type) {
+	case *compile.ListSpec:
+		t, ok := to.(*compile.ListSpec)
+		return ok && Same(f.ValueSpec, t.ValueSpec)
+	case *compile.SetSpec:
+		t, ok := to.(*compile.SetSpec)
+		return ok && Same(f.ValueSpec, t.ValueSpec)
+	case *compile.MapSpec:
+		t, ok := to.(*compile.MapSpec)
+		return ok && Same(f.KeySpec, t.KeySpec) && Same(f.ValueSpec, t.ValueSpec)
+	default:
+		return from.ThriftName() == to.ThriftName()
+	}
+}
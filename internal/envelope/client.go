@@ -24,7 +24,7 @@ import (
 	"bytes"
 	"fmt"
 
-	"go.uber.org/thriftrw/internal/envelope/exception"
+	"go.uber.org/thriftrw/envelope/exception"
 	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
 )
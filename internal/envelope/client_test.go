@@ -5,8 +5,8 @@ import (
 	"io"
 	"testing"
 
+	"go.uber.org/thriftrw/envelope/exception"
 	"go.uber.org/thriftrw/internal/envelope/envelopetest"
-	"go.uber.org/thriftrw/internal/envelope/exception"
 	"go.uber.org/thriftrw/ptr"
 	"go.uber.org/thriftrw/wire"
 
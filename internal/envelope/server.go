@@ -24,9 +24,8 @@ import (
 	"bytes"
 	"fmt"
 
-	"go.uber.org/thriftrw/internal/envelope/exception"
+	"go.uber.org/thriftrw/envelope/exception"
 	"go.uber.org/thriftrw/protocol"
-	"go.uber.org/thriftrw/ptr"
 	"go.uber.org/thriftrw/wire"
 )
 
@@ -74,7 +73,9 @@ func (s Server) Handle(data []byte) ([]byte, error) {
 	response.Value, err = s.h.Handle(request.Name, request.Value)
 	if err != nil {
 		response.Type = wire.Exception
-		switch err.(type) {
+		switch e := err.(type) {
+		case *exception.TApplicationException:
+			response.Value, err = e.ToWire()
 		case ErrUnknownMethod:
 			response.Value, err = tappExc(err, exception.ExceptionTypeUnknownMethod)
 		default:
@@ -96,8 +97,5 @@ func (s Server) Handle(data []byte) ([]byte, error) {
 
 // Helper to build TApplicationException wire.Values
 func tappExc(err error, typ exception.ExceptionType) (wire.Value, error) {
-	return (&exception.TApplicationException{
-		Message: ptr.String(err.Error()),
-		Type:    &typ,
-	}).ToWire()
+	return exception.New(typ, err.Error()).ToWire()
 }
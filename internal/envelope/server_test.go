@@ -5,6 +5,7 @@ import (
 	"io"
 	"testing"
 
+	"go.uber.org/thriftrw/envelope/exception"
 	"go.uber.org/thriftrw/wire"
 
 	"github.com/golang/mock/gomock"
@@ -99,6 +100,27 @@ func TestServer(t *testing.T) {
 				}}),
 			},
 		},
+		{
+			desc: "application exception",
+			giveEnvelope: wire.Envelope{
+				Name:  "hello",
+				Type:  wire.Call,
+				SeqID: 1,
+				Value: wire.NewValueStruct(wire.Struct{}),
+			},
+			handler: func(string, wire.Value) (wire.Value, error) {
+				return wire.Value{}, exception.New(exception.ExceptionTypeInvalidProtocol, "bad request")
+			},
+			wantEnvelope: &wire.Envelope{
+				Name:  "hello",
+				Type:  wire.Exception,
+				SeqID: 1,
+				Value: wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+					{ID: 1, Value: wire.NewValueString("bad request")},
+					{ID: 2, Value: wire.NewValueI32(9)}, // Invalid protocol
+				}}),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -22,10 +22,12 @@ package git
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/utils/merkletrie"
 	"go.uber.org/thriftrw/compile"
@@ -49,11 +51,37 @@ func NewGitFS(gitDir string, repo *git.Repository, tree *object.Tree) *FS {
 	}
 }
 
+// Revisions specifies the commits compared by CompareRevisions.
+type Revisions struct {
+	// From is the revision holding the previous version of the Thrift
+	// files. Defaults to the first parent of To.
+	From string
+
+	// To is the revision holding the new version of the Thrift files.
+	// Defaults to HEAD.
+	To string
+
+	// MergeBase compares To against the best common ancestor of From and
+	// To rather than against From itself. This reports only the changes
+	// made on the branch holding To, similar to "git diff From...To".
+	MergeBase bool
+}
+
 // Compare takes a path to a git repository and returns errors between HEAD and HEAD~
 // for any incompatible Thrift changes between the two shas.
 func Compare(path string) (compare.Pass, error) {
+	return CompareRevisions(path, Revisions{}, nil)
+}
+
+// CompareRevisions takes a path to a git repository and returns errors for
+// any incompatible Thrift changes between the given revisions.
+// Revisions may be anything understood by "git rev-parse", such as branch
+// names, tags, or commit hashes. cfg may be nil to use the default
+// configuration.
+func CompareRevisions(path string, revs Revisions, cfg *compare.Config) (compare.Pass, error) {
 	pass := compare.Pass{
 		GitDir: path,
+		Config: cfg,
 	}
 	r, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{
 		DetectDotGit:          true,
@@ -63,36 +91,42 @@ func Compare(path string) (compare.Pass, error) {
 		return pass, err
 	}
 
-	h, err := findChangedThrift(r)
+	h, err := findChangedThrift(r, revs)
 	if err != nil {
 		return pass, fmt.Errorf("failed to find changed thrift files: %w", err)
 	}
 	fs := NewGitFS(path, r, h.to)
 	fsFrom := NewGitFS(path, r, h.from)
-	var errs error
+	pass.FromFS, pass.ToFS = fsFrom, fs
 	for _, c := range h.changes {
 		var toModule *compile.Module
-		if c.change == merkletrie.Modify {
-			toModule, err = compile.Compile(c.file, compile.Filesystem(fs))
+		switch c.change {
+		case merkletrie.Insert, merkletrie.Modify:
+			toModule, err = compile.Compile(c.toFile, compile.Filesystem(fs))
 			if err != nil {
 				return pass, err
 			}
-		} else if c.change == merkletrie.Delete {
-			// something got deleted, so we are creating an empty module here.
-			toModule = &compile.Module{
-				Name: c.file,
-			}
+		}
+
+		if c.change == merkletrie.Insert {
+			// A new file was added so there is nothing that it could
+			// have broken. It is still compiled above to surface errors.
+			continue
 		}
 
 		fromModule, err := compile.Compile(c.file, compile.Filesystem(fsFrom))
 		if err != nil {
 			return pass, err
 		}
+		if c.change == merkletrie.Delete {
+			pass.DeletedModule(fromModule)
+			continue
+		}
 		pass.CompareModules(fromModule, toModule)
 	}
 	// p will have lints as a field which we can sort in cli.
 
-	return pass, errs
+	return pass, nil
 }
 
 func (fs FS) Read(path string) ([]byte, error) {
@@ -133,23 +167,64 @@ type treeChanges struct {
 }
 
 type change struct {
-	file   string
+	file   string // path in the from tree
+	toFile string // path in the to tree; differs from file on renames
 	change merkletrie.Action
 }
 
-// findChangedThrift reads a git repo and finds any Thrift files that got changed
-// between HEAD and previous commit.
-func findChangedThrift(r *git.Repository) (*treeChanges, error) {
-	// Get Repo's HEAD
-	refHead, err := r.Head() // *plumbing.Reference
+// resolveCommit resolves a git revision into a commit.
+func resolveCommit(r *git.Repository, rev string) (*object.Commit, error) {
+	h, err := r.ResolveRevision(plumbing.Revision(rev))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("resolve revision %q: %w", rev, err)
+	}
+	return r.CommitObject(*h)
+}
+
+// resolveRevisions finds the commits to compare for the given revisions.
+func resolveRevisions(r *git.Repository, revs Revisions) (from, to *object.Commit, err error) {
+	if revs.MergeBase && revs.From == "" {
+		return nil, nil, errors.New("a from revision is required to use the merge base")
+	}
+
+	toRev := revs.To
+	if toRev == "" {
+		toRev = "HEAD"
 	}
-	commit, err := r.CommitObject(refHead.Hash()) // *object.Commit
+	to, err = resolveCommit(r, toRev)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	if revs.From == "" {
+		from, err = to.Parent(0)
+		return from, to, err
+	}
+
+	from, err = resolveCommit(r, revs.From)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if revs.MergeBase {
+		bases, err := from.MergeBase(to)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(bases) == 0 {
+			return nil, nil, fmt.Errorf(
+				"revisions %q and %q do not have a common ancestor", revs.From, toRev)
+		}
+		from = bases[0]
 	}
-	parentCommit, err := commit.Parent(0) // *object.Commit
+
+	return from, to, nil
+}
+
+// findChangedThrift reads a git repo and finds any Thrift files that got
+// changed between the given revisions.
+func findChangedThrift(r *git.Repository, revs Revisions) (*treeChanges, error) {
+	parentCommit, commit, err := resolveRevisions(r, revs)
 	if err != nil {
 		return nil, err
 	}
@@ -163,22 +238,25 @@ func findChangedThrift(r *git.Repository) (*treeChanges, error) {
 		return nil, err
 	}
 	// Diff the trees and find what changed.
-	objects, _ := object.DiffTreeWithOptions(context.Background(),
+	objects, err := object.DiffTreeWithOptions(context.Background(),
 		pc, c, &object.DiffTreeOptions{DetectRenames: true}) // *object.Changes
+	if err != nil {
+		return nil, err
+	}
 	var changed []*change
 	for _, o := range objects {
 		a, err := o.Action() // Insert, delete or modify.
 		if err != nil {
 			return nil, err
 		}
-		from, _, _ := o.Files()
-		// New file was added which doesnt have a name.
-		if from == nil {
-			continue
+		name := o.From.Name
+		if a == merkletrie.Insert {
+			name = o.To.Name
 		}
-		if filepath.Ext(from.Name) == ".thrift" {
+		if filepath.Ext(name) == ".thrift" {
 			changed = append(changed, &change{
 				file:   o.From.Name,
+				toFile: o.To.Name,
 				change: a,
 			})
 		}
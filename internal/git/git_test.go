@@ -21,9 +21,12 @@
 package git
 
 import (
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/utils/merkletrie"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -60,23 +63,23 @@ func TestOpenRepoWithErrors(t *testing.T) {
 	}
 	remove := []string{"test/d.thrift"}
 	repo := breaktest.CreateRepoAndCommit(t, tmpDir, from, to, remove)
-	treechanges, err := findChangedThrift(repo)
+	treechanges, err := findChangedThrift(repo, Revisions{})
 	assert.NoError(t, err)
 	assert.Equal(t, []*change{
-		{file: "test/c.thrift", change: merkletrie.Modify},
+		{file: "test/c.thrift", toFile: "test/c.thrift", change: merkletrie.Modify},
 		{file: "test/d.thrift", change: merkletrie.Delete},
-		{file: "test/v2.thrift", change: merkletrie.Modify},
-		{file: "v1.thrift", change: merkletrie.Modify},
+		{file: "test/v2.thrift", toFile: "test/v2.thrift", change: merkletrie.Modify},
+		{file: "v1.thrift", toFile: "v1.thrift", change: merkletrie.Modify},
 	}, treechanges.changes)
 
 	pass, err := Compare(tmpDir)
 	require.NoError(t, err)
 	assert.Equal(t,
-		`c.thrift:deleting service "Baz"`+"\n"+
-			`d.thrift:deleting service "Qux"`+"\n"+
-			`v2.thrift:deleting service "Bar"`+"\n"+
-			`v1.thrift:removing method "methodA" in service "Foo"`+"\n"+
-			`v1.thrift:adding a required field "C" to "AddedRequiredField"`+"\n",
+		`c.thrift:1:1:deleting service "Baz"`+"\n"+
+			`d.thrift:2:3:deleting service "Qux"`+"\n"+
+			`v2.thrift:1:1:deleting service "Bar"`+"\n"+
+			`v1.thrift:7:1:removing method "methodA" in service "Foo"`+"\n"+
+			`v1.thrift:5:5:adding a required field "C" to "AddedRequiredField"`+"\n",
 		pass.String())
 }
 
@@ -101,17 +104,17 @@ func TestNewFileAdded(t *testing.T) {
 		"foo.proto": "", // Testing that we support new files being added.
 	}
 	repo := breaktest.CreateRepoAndCommit(t, tmpDir, from, to, nil)
-	treechanges, err := findChangedThrift(repo)
+	treechanges, err := findChangedThrift(repo, Revisions{})
 	assert.NoError(t, err)
 	assert.Equal(t, []*change{
-		{file: "v1.thrift", change: merkletrie.Modify},
+		{file: "v1.thrift", toFile: "v1.thrift", change: merkletrie.Modify},
 	}, treechanges.changes)
 
 	pass, err := Compare(tmpDir)
 	require.NoError(t, err)
 	assert.Equal(t,
-		`v1.thrift:removing method "methodA" in service "Foo"`+"\n"+
-			`v1.thrift:adding a required field "C" to "AddedRequiredField"`+"\n",
+		`v1.thrift:7:1:removing method "methodA" in service "Foo"`+"\n"+
+			`v1.thrift:5:5:adding a required field "C" to "AddedRequiredField"`+"\n",
 		pass.String())
 }
 
@@ -119,6 +122,129 @@ func TestFindChangedThriftError(t *testing.T) {
 	tmpDir := t.TempDir()
 	repository, err := git.PlainInit(tmpDir, false)
 	require.NoError(t, err, "expect no error when creating a temporary repo")
-	_, err = findChangedThrift(repository)
+	_, err = findChangedThrift(repository, Revisions{})
 	assert.Error(t, err)
 }
+
+func TestCompareRevisions(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := breaktest.CreateRepoAndCommit(t, tmpDir,
+		map[string]string{
+			"a.thrift": "service Foo {\n    void methodA()\n    void methodB()\n}",
+		},
+		map[string]string{
+			"a.thrift": "service Foo {\n    void methodB()\n}",
+		},
+		nil,
+	)
+	head, err := repo.Head()
+	require.NoError(t, err)
+	second := head.Hash().String()
+
+	third := breaktest.Commit(t, repo, tmpDir, map[string]string{
+		"a.thrift": "service Foo {}",
+		"b.thrift": `include "./a.thrift"` + "\nservice Bar {}",
+	}, nil).String()
+
+	t.Run("defaults to HEAD and its parent", func(t *testing.T) {
+		pass, err := CompareRevisions(tmpDir, Revisions{}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, `a.thrift:1:1:removing method "methodB" in service "Foo"`+"\n", pass.String())
+	})
+
+	t.Run("from and to", func(t *testing.T) {
+		pass, err := CompareRevisions(tmpDir, Revisions{From: second + "~1", To: second}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, `a.thrift:1:1:removing method "methodA" in service "Foo"`+"\n", pass.String())
+	})
+
+	t.Run("spans multiple commits", func(t *testing.T) {
+		pass, err := CompareRevisions(tmpDir, Revisions{From: "HEAD~2", To: third}, nil)
+		require.NoError(t, err)
+		assert.Equal(t,
+			`a.thrift:1:1:removing method "methodA" in service "Foo"`+"\n"+
+				`a.thrift:1:1:removing method "methodB" in service "Foo"`+"\n",
+			sortedLines(pass.String()))
+	})
+
+	t.Run("added files are included", func(t *testing.T) {
+		treechanges, err := findChangedThrift(repo, Revisions{From: second})
+		require.NoError(t, err)
+		assert.Equal(t, []*change{
+			{file: "a.thrift", toFile: "a.thrift", change: merkletrie.Modify},
+			{toFile: "b.thrift", change: merkletrie.Insert},
+		}, treechanges.changes)
+	})
+
+	t.Run("unknown revision", func(t *testing.T) {
+		_, err := CompareRevisions(tmpDir, Revisions{From: "does-not-exist"}, nil)
+		assert.ErrorContains(t, err, `resolve revision "does-not-exist"`)
+	})
+
+	t.Run("merge base requires from", func(t *testing.T) {
+		_, err := CompareRevisions(tmpDir, Revisions{MergeBase: true}, nil)
+		assert.ErrorContains(t, err, "a from revision is required")
+	})
+}
+
+func TestCompareAddedFileWithErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	breaktest.CreateRepoAndCommit(t, tmpDir,
+		map[string]string{"a.thrift": "service Foo {}"},
+		map[string]string{"b.thrift": `include "./missing.thrift"`},
+		nil,
+	)
+
+	_, err := Compare(tmpDir)
+	assert.ErrorContains(t, err, "missing.thrift")
+}
+
+func TestCompareMergeBase(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := breaktest.CreateRepoAndCommit(t, tmpDir,
+		map[string]string{
+			"a.thrift": "service Foo {\n    void methodA()\n}",
+		},
+		map[string]string{
+			"b.thrift": "service Bar {}",
+		},
+		nil,
+	)
+	head, err := repo.Head()
+	require.NoError(t, err)
+	main := head.Name().Short()
+
+	// Branch off of the first commit and remove methodA. The main branch
+	// has added b.thrift since.
+	first, err := repo.ResolveRevision(plumbing.Revision(main + "~1"))
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, worktree.Checkout(&git.CheckoutOptions{
+		Hash:   *first,
+		Branch: plumbing.NewBranchReferenceName("feature"),
+		Create: true,
+	}))
+	breaktest.Commit(t, repo, tmpDir, map[string]string{
+		"a.thrift": "service Foo {}",
+	}, nil)
+
+	pass, err := CompareRevisions(tmpDir, Revisions{From: main, To: "feature"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t,
+		`a.thrift:1:1:removing method "methodA" in service "Foo"`+"\n"+
+			`b.thrift:1:1:deleting service "Bar"`+"\n",
+		pass.String(), "comparing against the tip of main reports changes made on main")
+
+	pass, err = CompareRevisions(tmpDir, Revisions{From: main, To: "feature", MergeBase: true}, nil)
+	require.NoError(t, err)
+	assert.Equal(t,
+		`a.thrift:1:1:removing method "methodA" in service "Foo"`+"\n",
+		pass.String(), "comparing against the merge base reports only changes on feature")
+}
+
+func sortedLines(s string) string {
+	lines := strings.SplitAfter(s, "\n")
+	sort.Strings(lines)
+	return strings.Join(lines, "")
+}
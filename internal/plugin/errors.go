@@ -22,6 +22,10 @@ package plugin
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/thriftrw/plugin/api"
 )
 
 type errHandshakeFailed struct {
@@ -48,3 +52,21 @@ type errAPIVersionMismatch struct {
 func (e errAPIVersionMismatch) Error() string {
 	return fmt.Sprintf("plugin API version mismatch: expected %v but got %v", e.Want, e.Got)
 }
+
+type errUnknownOption struct {
+	Name     string
+	Declared []*api.Option
+}
+
+func (e errUnknownOption) Error() string {
+	if len(e.Declared) == 0 {
+		return fmt.Sprintf("unknown option %q: the plugin does not accept any options", e.Name)
+	}
+
+	names := make([]string, len(e.Declared))
+	for i, opt := range e.Declared {
+		names[i] = opt.Name
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("unknown option %q: the plugin accepts %v", e.Name, strings.Join(names, ", "))
+}
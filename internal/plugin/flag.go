@@ -21,13 +21,19 @@
 package plugin
 
 import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
+	"strings"
 	"sync"
 
 	"go.uber.org/thriftrw/internal/concurrent"
 	"go.uber.org/thriftrw/internal/process"
+	"go.uber.org/thriftrw/plugin/api"
 
 	"github.com/anmitsu/go-shlex"
 	"go.uber.org/multierr"
@@ -49,18 +55,31 @@ const _pluginExecPrefix = "thriftrw-plugin-"
 type Flag struct {
 	Name    string    // Name of the plugin
 	Command *exec.Cmd // Command specification
+
+	// Values for options declared by the plugin, keyed by option name.
+	Options map[string]string
 }
 
 // Handle gets a Handle to this plugin specification.
 //
+// The plugin is killed if the context finishes before the handle is closed.
+//
 // The returned handle MUST be closed by the caller if error was nil.
-func (f *Flag) Handle() (Handle, error) {
-	transport, err := process.NewClient(f.Command)
+func (f *Flag) Handle(ctx context.Context) (Handle, error) {
+	h, err := f.handle(ctx, f.Options)
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (f *Flag) handle(ctx context.Context, options map[string]string) (*transportHandle, error) {
+	transport, err := process.NewClient(ctx, f.Command)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open plugin %q: %v", f.Name, err)
 	}
 
-	handle, err := NewTransportHandle(f.Name, transport)
+	handle, err := newTransportHandle(f.Name, transport, options)
 	if err != nil {
 		return nil, multierr.Combine(
 			fmt.Errorf("failed to open plugin %q: %v", f.Name, err),
@@ -71,6 +90,24 @@ func (f *Flag) Handle() (Handle, error) {
 	return handle, nil
 }
 
+// DescribeOptions starts the plugin and returns the options it declares.
+//
+// The plugin is stopped before this returns. Because the plugin's Command
+// can be run only once, Handle MUST NOT be called after DescribeOptions.
+func (f *Flag) DescribeOptions(ctx context.Context) (_ []*api.Option, err error) {
+	// Option values are not validated here so that users can look up the
+	// options of a plugin they've provided incorrect values for.
+	handle, err := f.handle(ctx, nil /* options */)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = multierr.Append(err, handle.Close())
+	}()
+
+	return handle.DeclaredOptions, nil
+}
+
 // UnmarshalFlag parses a string specification of a plugin.
 func (f *Flag) UnmarshalFlag(value string) error {
 	tokens, err := shlex.Split(value, true /* posix */)
@@ -82,18 +119,30 @@ func (f *Flag) UnmarshalFlag(value string) error {
 		return fmt.Errorf("invalid plugin %q: please provide a name", value)
 	}
 
-	f.Name = tokens[0]
-	exe := _pluginExecPrefix + f.Name
+	flag, err := NewFlag(tokens[0], tokens[1:]...)
+	if err != nil {
+		return fmt.Errorf("invalid plugin %q: %v", value, err)
+	}
+
+	*f = *flag
+	return nil
+}
+
+// NewFlag builds a plugin specification for the plugin with the given name,
+// passing it the given arguments.
+//
+// The executable thriftrw-plugin-$name must be available on the $PATH.
+func NewFlag(name string, args ...string) (*Flag, error) {
+	exe := _pluginExecPrefix + name
 	path, err := exec.LookPath(exe)
 	if err != nil {
-		return fmt.Errorf("invalid plugin %q: could not find executable %q: %v", value, exe, err)
+		return nil, fmt.Errorf("could not find executable %q: %v", exe, err)
 	}
 
-	cmd := exec.Command(path, tokens[1:]...)
+	cmd := exec.Command(path, args...)
 	cmd.Stderr = os.Stderr // connect stderr so that plugins can log
 
-	f.Command = cmd
-	return nil
+	return &Flag{Name: name, Command: cmd}, nil
 }
 
 // Flags is a collection of ThriftRW external plugin specifications.
@@ -102,15 +151,18 @@ type Flags []Flag
 // Handle gets a MultiHandle to all the plugins in this list or nil if the
 // list is empty.
 //
+// The plugins are killed if the context finishes before the handle is
+// closed.
+//
 // The returned handle MUST be closed by the caller if error was nil.
-func (fs Flags) Handle() (MultiHandle, error) {
+func (fs Flags) Handle(ctx context.Context) (MultiHandle, error) {
 	var (
 		lock  sync.Mutex
 		multi MultiHandle
 	)
 
 	err := concurrent.Range(fs, func(_ int, f Flag) error {
-		h, err := f.Handle()
+		h, err := f.Handle(ctx)
 		if err != nil {
 			return err
 		}
@@ -128,3 +180,63 @@ func (fs Flags) Handle() (MultiHandle, error) {
 
 	return nil, multierr.Append(err, multi.Close())
 }
+
+// SetOptions assigns option values to the plugins in this list.
+//
+// Each value is in the form PLUGIN.NAME=VALUE, where PLUGIN is the name of a
+// plugin in this list and NAME is the name of an option declared by it.
+// Values are validated against the declared options when the plugins are
+// started.
+func (fs Flags) SetOptions(values []string) error {
+	for _, value := range values {
+		pluginName, option, ok := strings.Cut(value, ".")
+		if !ok {
+			return fmt.Errorf("invalid plugin option %q: expected PLUGIN.NAME=VALUE", value)
+		}
+		name, optValue, ok := strings.Cut(option, "=")
+		if !ok || pluginName == "" || name == "" {
+			return fmt.Errorf("invalid plugin option %q: expected PLUGIN.NAME=VALUE", value)
+		}
+
+		found := false
+		for i := range fs {
+			f := &fs[i]
+			if f.Name != pluginName {
+				continue
+			}
+			found = true
+			if f.Options == nil {
+				f.Options = make(map[string]string)
+			}
+			f.Options[name] = optValue
+		}
+		if !found {
+			return fmt.Errorf("invalid plugin option %q: plugin %q was not provided with --plugin", value, pluginName)
+		}
+	}
+	return nil
+}
+
+// ID identifies these plugins and their configuration. It changes if the
+// list of plugins, their arguments, their option values, or the contents of
+// their executables change.
+func (fs Flags) ID() (string, error) {
+	h := sha1.New()
+	for _, f := range fs {
+		exe, err := os.ReadFile(f.Command.Path)
+		if err != nil {
+			return "", fmt.Errorf("could not read plugin %q: %v", f.Name, err)
+		}
+		fmt.Fprintf(h, "%q %q %x\n", f.Name, f.Command.Args[1:], sha1.Sum(exe))
+
+		names := make([]string, 0, len(f.Options))
+		for name := range f.Options {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(h, "\t%q=%q\n", name, f.Options[name])
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -1,11 +1,13 @@
 package plugin
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -150,7 +152,7 @@ func TestFlagHandle(t *testing.T) {
 			Name:    tt.name,
 			Command: exec.Command(tt.path, tt.args...),
 		}
-		h, err := f.Handle()
+		h, err := f.Handle(context.Background())
 
 		if len(tt.wantErrors) > 0 {
 			if !assert.Error(t, err, "%v: expected error", tt.desc) {
@@ -168,6 +170,29 @@ func TestFlagHandle(t *testing.T) {
 	}
 }
 
+func TestFlagHandleFailures(t *testing.T) {
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		f := Flag{Name: "hang", Command: exec.Command(testdata(t, "thriftrw-plugin-hang"))}
+		_, err := f.Handle(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `failed to open plugin "hang":`)
+		assert.Contains(t, err.Error(), "timed out and was killed")
+		assert.Contains(t, err.Error(), "stderr:\nwaiting forever")
+	})
+
+	t.Run("crash", func(t *testing.T) {
+		f := Flag{Name: "crash", Command: exec.Command(testdata(t, "thriftrw-plugin-crash"))}
+		_, err := f.Handle(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `failed to open plugin "crash":`)
+		assert.Contains(t, err.Error(), "failed with: exit status 1")
+		assert.Contains(t, err.Error(), "stderr:\ngreat sadness")
+	})
+}
+
 func TestFlagsHandle(t *testing.T) {
 	type plug struct {
 		name string
@@ -245,7 +270,7 @@ func TestFlagsHandle(t *testing.T) {
 			})
 		}
 
-		h, err := flags.Handle()
+		h, err := flags.Handle(context.Background())
 
 		if len(tt.wantErrors) > 0 {
 			if !assert.Error(t, err, "%v: expected error", tt.desc) {
@@ -264,3 +289,130 @@ func TestFlagsHandle(t *testing.T) {
 		}
 	}
 }
+
+func TestFlagDescribeOptions(t *testing.T) {
+	f := Flag{
+		Name:    "options",
+		Command: exec.Command(testdata(t, "thriftrw-plugin-options")),
+		// Invalid values must not prevent describing the plugin.
+		Options: map[string]string{"limit": "many"},
+	}
+
+	opts, err := f.DescribeOptions(context.Background())
+	require.NoError(t, err)
+
+	names := make([]string, len(opts))
+	for i, opt := range opts {
+		names[i] = opt.Name
+	}
+	assert.Equal(t, []string{"verbose", "limit"}, names)
+}
+
+func TestFlagHandleInvalidOptions(t *testing.T) {
+	f := Flag{
+		Name:    "options",
+		Command: exec.Command(testdata(t, "thriftrw-plugin-options")),
+		Options: map[string]string{"limit": "many"},
+	}
+
+	_, err := f.Handle(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `failed to open plugin "options":`)
+	assert.Contains(t, err.Error(), `invalid value for option "limit": "many" is not an integer`)
+}
+
+func TestFlagsSetOptions(t *testing.T) {
+	tests := []struct {
+		desc   string
+		values []string
+
+		want      map[string]map[string]string
+		wantError string
+	}{
+		{
+			desc: "no values",
+			want: map[string]map[string]string{"foo": nil, "bar": nil},
+		},
+		{
+			desc:   "values",
+			values: []string{"foo.a=1", "bar.b=x=y", "foo.c=", "foo.a=2"},
+			want: map[string]map[string]string{
+				"foo": {"a": "2", "c": ""},
+				"bar": {"b": "x=y"},
+			},
+		},
+		{
+			desc:      "missing plugin name",
+			values:    []string{"a=1"},
+			wantError: `invalid plugin option "a=1": expected PLUGIN.NAME=VALUE`,
+		},
+		{
+			desc:      "missing value",
+			values:    []string{"foo.a"},
+			wantError: `invalid plugin option "foo.a": expected PLUGIN.NAME=VALUE`,
+		},
+		{
+			desc:      "missing option name",
+			values:    []string{"foo.=1"},
+			wantError: `invalid plugin option "foo.=1": expected PLUGIN.NAME=VALUE`,
+		},
+		{
+			desc:      "unknown plugin",
+			values:    []string{"baz.a=1"},
+			wantError: `invalid plugin option "baz.a=1": plugin "baz" was not provided with --plugin`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			fs := Flags{{Name: "foo"}, {Name: "bar"}}
+			err := fs.SetOptions(tt.values)
+			if tt.wantError != "" {
+				assert.EqualError(t, err, tt.wantError)
+				return
+			}
+
+			require.NoError(t, err)
+			got := make(map[string]map[string]string)
+			for _, f := range fs {
+				got[f.Name] = f.Options
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFlagsID(t *testing.T) {
+	done := prependToPath(testdata(t))
+	defer done()
+
+	newFlags := func(specs ...string) Flags {
+		var fs Flags
+		for _, spec := range specs {
+			var f Flag
+			require.NoError(t, f.UnmarshalFlag(spec))
+			fs = append(fs, f)
+		}
+		return fs
+	}
+
+	id := func(fs Flags) string {
+		id, err := fs.ID()
+		require.NoError(t, err)
+		return id
+	}
+
+	base := id(newFlags("empty --foo"))
+	assert.Equal(t, base, id(newFlags("empty --foo")), "ID must be stable")
+	assert.NotEqual(t, base, id(newFlags("empty --bar")), "arguments must be part of the ID")
+	assert.NotEqual(t, base, id(newFlags("empty --foo", "empty")), "plugins must be part of the ID")
+
+	withOptions := newFlags("empty --foo")
+	require.NoError(t, withOptions.SetOptions([]string{"empty.a=1"}))
+	assert.NotEqual(t, base, id(withOptions), "options must be part of the ID")
+
+	missing := newFlags("empty")
+	missing[0].Command.Path = filepath.Join(t.TempDir(), "does-not-exist")
+	_, err := missing.ID()
+	assert.ErrorContains(t, err, `could not read plugin "empty"`)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package plugin
+
+import (
+	"fmt"
+
+	"go.uber.org/atomic"
+	"go.uber.org/thriftrw/plugin/api"
+)
+
+// inProcessHandle is a Handle to a plugin that runs inside this process.
+type inProcessHandle struct {
+	name string
+
+	ServiceGeneratorImpl api.ServiceGenerator
+	Running              *atomic.Bool
+	Options              map[string]string
+}
+
+// NewInProcessHandle builds a Handle to a plugin that runs inside this
+// process. The ServiceGenerator may be nil if the plugin does not generate
+// code for services.
+//
+// Requests are made to the plugin directly, without a handshake. The output
+// of the plugin is validated the same way as that of plugins that run as
+// separate processes.
+//
+// Options are sent to the plugin with every request as-is. Use
+// ResolveOptions to validate them against the options the plugin declares.
+func NewInProcessHandle(name string, sg api.ServiceGenerator, options map[string]string) Handle {
+	return &inProcessHandle{
+		name:                 name,
+		ServiceGeneratorImpl: sg,
+		Running:              atomic.NewBool(true),
+		Options:              options,
+	}
+}
+
+func (h *inProcessHandle) Name() string {
+	return h.name
+}
+
+func (h *inProcessHandle) Close() error {
+	h.Running.Store(false)
+	return nil
+}
+
+func (h *inProcessHandle) ServiceGenerator() ServiceGenerator {
+	if !h.Running.Load() {
+		panic(fmt.Sprintf("handle for plugin %q has already been closed", h.name))
+	}
+
+	if h.ServiceGeneratorImpl == nil {
+		return nil
+	}
+
+	return inProcessServiceGenerator{handle: h}
+}
+
+// inProcessServiceGenerator is a ServiceGenerator that validates the output
+// of an in-process plugin.
+type inProcessServiceGenerator struct {
+	handle *inProcessHandle
+}
+
+func (sg inProcessServiceGenerator) Handle() Handle {
+	return sg.handle
+}
+
+func (sg inProcessServiceGenerator) Generate(req *api.GenerateServiceRequest) (*api.GenerateServiceResponse, error) {
+	h := sg.handle
+	if !h.Running.Load() {
+		panic(fmt.Sprintf("handle for plugin %q has already been closed", h.name))
+	}
+
+	if h.Options != nil {
+		req = withOptions(req, h.Options)
+	}
+	return generateServices(h.name, h.ServiceGeneratorImpl, req)
+}
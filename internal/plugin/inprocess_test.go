@@ -0,0 +1,115 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package plugin
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/thriftrw/plugin/api"
+	"go.uber.org/thriftrw/plugin/plugintest"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInProcessHandle(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	req := &api.GenerateServiceRequest{}
+	sg := plugintest.NewMockServiceGenerator(mockCtrl)
+	sg.EXPECT().Generate(req).Return(&api.GenerateServiceResponse{
+		Files: map[string][]byte{"foo/bar.go": []byte("package foo")},
+	}, nil)
+
+	h := NewInProcessHandle("foo", sg, nil)
+	assert.Equal(t, "foo", h.Name())
+
+	gen := h.ServiceGenerator()
+	require.NotNil(t, gen)
+	assert.Equal(t, h, gen.Handle())
+
+	res, err := gen.Generate(req)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"foo/bar.go": []byte("package foo")}, res.Files)
+
+	require.NoError(t, h.Close())
+	assert.Panics(t, func() { h.ServiceGenerator() })
+	assert.Panics(t, func() { _, _ = gen.Generate(req) })
+}
+
+func TestInProcessHandleOptions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	sg := plugintest.NewMockServiceGenerator(mockCtrl)
+	sg.EXPECT().Generate(&api.GenerateServiceRequest{
+		Options: map[string]string{"verbose": "true"},
+	}).Return(&api.GenerateServiceResponse{}, nil)
+
+	h := NewInProcessHandle("foo", sg, map[string]string{"verbose": "true"})
+	defer h.Close()
+
+	req := &api.GenerateServiceRequest{}
+	_, err := h.ServiceGenerator().Generate(req)
+	require.NoError(t, err)
+	assert.Nil(t, req.Options, "request must not be modified")
+}
+
+func TestInProcessHandleNoServiceGenerator(t *testing.T) {
+	h := NewInProcessHandle("foo", nil, nil)
+	assert.Nil(t, h.ServiceGenerator())
+	assert.NoError(t, h.Close())
+}
+
+func TestInProcessHandleErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		res     *api.GenerateServiceResponse
+		err     error
+		wantErr string
+	}{
+		{
+			desc:    "generate error",
+			err:     errors.New("great sadness"),
+			wantErr: `plugin "foo" failed to generate service code: great sadness`,
+		},
+		{
+			desc: "parent directory",
+			res: &api.GenerateServiceResponse{
+				Files: map[string][]byte{"../bar.go": []byte("package bar")},
+			},
+			wantErr: `plugin "foo" is attempting to write to a parent directory: path "../bar.go" contains ".."`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+
+			sg := plugintest.NewMockServiceGenerator(mockCtrl)
+			sg.EXPECT().Generate(gomock.Any()).Return(tt.res, tt.err)
+
+			_, err := NewInProcessHandle("foo", sg, nil).ServiceGenerator().Generate(&api.GenerateServiceRequest{})
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package plugin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/thriftrw/plugin/api"
+)
+
+// ResolveOptions validates the option values provided by the user against
+// the options declared by a plugin. It returns the values that should be sent
+// to the plugin, including defaults for options the user did not provide.
+// The declarations themselves are validated as well.
+//
+// Values are normalized for their types so that plugins always receive
+// "true" or "false" for BOOL options and base 10 integers for INT options.
+func ResolveOptions(declared []*api.Option, values map[string]string) (map[string]string, error) {
+	known := make(map[string]*api.Option, len(declared))
+	for _, opt := range declared {
+		if err := validateOption(opt); err != nil {
+			return nil, err
+		}
+		if _, ok := known[opt.Name]; ok {
+			return nil, fmt.Errorf("option %q was declared more than once", opt.Name)
+		}
+		known[opt.Name] = opt
+	}
+
+	resolved := make(map[string]string, len(declared))
+	for name, value := range values {
+		opt, ok := known[name]
+		if !ok {
+			return nil, errUnknownOption{Name: name, Declared: declared}
+		}
+
+		v, err := normalizeOptionValue(opt.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for option %q: %v", name, err)
+		}
+		resolved[name] = v
+	}
+
+	for _, opt := range declared {
+		if _, ok := resolved[opt.Name]; ok || opt.DefaultValue == nil {
+			continue
+		}
+		// Defaults were validated by validateOption.
+		resolved[opt.Name], _ = normalizeOptionValue(opt.Type, *opt.DefaultValue)
+	}
+
+	if len(resolved) == 0 {
+		return nil, nil
+	}
+	return resolved, nil
+}
+
+func validateOption(opt *api.Option) error {
+	if opt.Name == "" {
+		return fmt.Errorf("options must have a name")
+	}
+	if strings.Contains(opt.Name, "=") {
+		return fmt.Errorf("invalid option name %q: names must not contain %q", opt.Name, "=")
+	}
+
+	switch opt.Type {
+	case api.OptionTypeString, api.OptionTypeBool, api.OptionTypeInt:
+	default:
+		return fmt.Errorf("option %q has unknown type %v", opt.Name, opt.Type)
+	}
+
+	if opt.DefaultValue != nil {
+		if _, err := normalizeOptionValue(opt.Type, *opt.DefaultValue); err != nil {
+			return fmt.Errorf("invalid default value for option %q: %v", opt.Name, err)
+		}
+	}
+	return nil
+}
+
+func normalizeOptionValue(t api.OptionType, value string) (string, error) {
+	switch t {
+	case api.OptionTypeBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a boolean", value)
+		}
+		return strconv.FormatBool(b), nil
+	case api.OptionTypeInt:
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("%q is not an integer", value)
+		}
+		return strconv.FormatInt(i, 10), nil
+	default:
+		return value, nil
+	}
+}
+
+// withOptions returns a copy of the request with the given option values.
+func withOptions(req *api.GenerateServiceRequest, options map[string]string) *api.GenerateServiceRequest {
+	r := *req
+	r.Options = options
+	return &r
+}
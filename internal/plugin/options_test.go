@@ -0,0 +1,125 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package plugin
+
+import (
+	"testing"
+
+	"go.uber.org/thriftrw/plugin/api"
+	"go.uber.org/thriftrw/ptr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveOptions(t *testing.T) {
+	declared := []*api.Option{
+		{Name: "name", Type: api.OptionTypeString},
+		{Name: "verbose", Type: api.OptionTypeBool, DefaultValue: ptr.String("F")},
+		{Name: "limit", Type: api.OptionTypeInt},
+	}
+
+	tests := []struct {
+		desc     string
+		declared []*api.Option
+		values   map[string]string
+
+		want      map[string]string
+		wantError string
+	}{
+		{desc: "no options"},
+		{
+			desc:     "defaults",
+			declared: declared,
+			want:     map[string]string{"verbose": "false"},
+		},
+		{
+			desc:     "values normalized",
+			declared: declared,
+			values:   map[string]string{"name": " foo ", "verbose": "1", "limit": "+42"},
+			want:     map[string]string{"name": " foo ", "verbose": "true", "limit": "42"},
+		},
+		{
+			desc:      "unknown option",
+			declared:  declared,
+			values:    map[string]string{"nope": "1"},
+			wantError: `unknown option "nope": the plugin accepts limit, name, verbose`,
+		},
+		{
+			desc:      "no options declared",
+			values:    map[string]string{"nope": "1"},
+			wantError: `unknown option "nope": the plugin does not accept any options`,
+		},
+		{
+			desc:      "invalid bool",
+			declared:  declared,
+			values:    map[string]string{"verbose": "maybe"},
+			wantError: `invalid value for option "verbose": "maybe" is not a boolean`,
+		},
+		{
+			desc:      "invalid int",
+			declared:  declared,
+			values:    map[string]string{"limit": "1.5"},
+			wantError: `invalid value for option "limit": "1.5" is not an integer`,
+		},
+		{
+			desc:      "missing name",
+			declared:  []*api.Option{{Type: api.OptionTypeString}},
+			wantError: "options must have a name",
+		},
+		{
+			desc:      "invalid name",
+			declared:  []*api.Option{{Name: "a=b", Type: api.OptionTypeString}},
+			wantError: `invalid option name "a=b"`,
+		},
+		{
+			desc: "duplicate name",
+			declared: []*api.Option{
+				{Name: "a", Type: api.OptionTypeString},
+				{Name: "a", Type: api.OptionTypeBool},
+			},
+			wantError: `option "a" was declared more than once`,
+		},
+		{
+			desc:      "unknown type",
+			declared:  []*api.Option{{Name: "a", Type: api.OptionType(42)}},
+			wantError: `option "a" has unknown type OptionType(42)`,
+		},
+		{
+			desc:      "invalid default",
+			declared:  []*api.Option{{Name: "a", Type: api.OptionTypeInt, DefaultValue: ptr.String("x")}},
+			wantError: `invalid default value for option "a": "x" is not an integer`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ResolveOptions(tt.declared, tt.values)
+			if tt.wantError != "" {
+				assert.ErrorContains(t, err, tt.wantError)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
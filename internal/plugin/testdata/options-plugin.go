@@ -0,0 +1,28 @@
+package main
+
+import (
+	"go.uber.org/thriftrw/plugin"
+	"go.uber.org/thriftrw/plugin/api"
+	"go.uber.org/thriftrw/ptr"
+)
+
+// A plugin that declares options but does not do anything.
+
+func main() {
+	plugin.Main(&plugin.Plugin{
+		Name: "options",
+		Options: []*api.Option{
+			{
+				Name: "verbose",
+				Type: api.OptionTypeBool,
+				Help: ptr.String("Log more information."),
+			},
+			{
+				Name:         "limit",
+				Type:         api.OptionTypeInt,
+				Help:         ptr.String("Maximum number of files."),
+				DefaultValue: ptr.String("10"),
+			},
+		},
+	})
+}
@@ -43,12 +43,25 @@ type transportHandle struct {
 	Client    api.Plugin
 	Running   *atomic.Bool
 	Features  map[api.Feature]struct{}
+
+	// Options declared by the plugin and the values that will be sent to
+	// it for them.
+	DeclaredOptions []*api.Option
+	Options         map[string]string
 }
 
 // NewTransportHandle builds a new Handle which speaks to the given transport.
 //
 // If the transport is an io.Closer, it will be closed when the handle is closed.
-func NewTransportHandle(name string, t envelope.Transport) (Handle, error) {
+//
+// Options are values for the options declared by the plugin during the
+// handshake, keyed by option name. The handshake fails if these are not
+// valid for the plugin.
+func NewTransportHandle(name string, t envelope.Transport, options map[string]string) (Handle, error) {
+	return newTransportHandle(name, t, options)
+}
+
+func newTransportHandle(name string, t envelope.Transport, options map[string]string) (*transportHandle, error) {
 	client := api.NewPluginClient(multiplex.NewClient(
 		"Plugin",
 		envelope.NewClient(_proto, t),
@@ -73,17 +86,28 @@ func NewTransportHandle(name string, t envelope.Transport) (Handle, error) {
 		}
 	}
 
+	resolved, err := ResolveOptions(handshake.Options, options)
+	if err != nil {
+		// The plugin is working correctly so let it exit cleanly.
+		return nil, multierr.Append(
+			errHandshakeFailed{Name: name, Reason: err},
+			client.Goodbye(),
+		)
+	}
+
 	features := make(map[api.Feature]struct{}, len(handshake.Features))
 	for _, feature := range handshake.Features {
 		features[feature] = struct{}{}
 	}
 
 	return &transportHandle{
-		name:      name,
-		Transport: t,
-		Client:    client,
-		Running:   atomic.NewBool(true),
-		Features:  features,
+		name:            name,
+		Transport:       t,
+		Client:          client,
+		Running:         atomic.NewBool(true),
+		Features:        features,
+		DeclaredOptions: handshake.Options,
+		Options:         resolved,
 	}, nil
 }
 
@@ -142,7 +166,16 @@ func (sg *serviceGenerator) Generate(req *api.GenerateServiceRequest) (*api.Gene
 		panic(fmt.Sprintf("handle for plugin %q has already been closed", name))
 	}
 
-	res, err := sg.ServiceGenerator.Generate(req)
+	if opts := sg.handle.Options; opts != nil {
+		req = withOptions(req, opts)
+	}
+	return generateServices(name, sg.ServiceGenerator, req)
+}
+
+// generateServices calls the given plugin's ServiceGenerator and validates
+// its output.
+func generateServices(name string, sg api.ServiceGenerator, req *api.GenerateServiceRequest) (*api.GenerateServiceResponse, error) {
+	res, err := sg.Generate(req)
 	if err != nil {
 		return res, fmt.Errorf("plugin %q failed to generate service code: %v", name, err)
 	}
@@ -72,7 +72,7 @@ func (s *fakePluginServer) Handshake(t *testing.T, pluginName string, features [
 			Features:       features,
 		}, nil)
 
-	handle, err := NewTransportHandle(pluginName, s.ClientTransport)
+	handle, err := NewTransportHandle(pluginName, s.ClientTransport, nil)
 	require.NoError(t, err, "handshake with fakePluginServer failed")
 
 	return handle
@@ -108,7 +108,7 @@ func TestTransportHandleTransportError(t *testing.T) {
 	transport := envelopetest.NewMockTransport(mockCtrl)
 	transport.EXPECT().Send(gomock.Any()).Return(nil, errors.New("great sadness"))
 
-	_, err := NewTransportHandle("foo", transport)
+	_, err := NewTransportHandle("foo", transport, nil)
 	require.Error(t, err)
 	assert.Equal(t, err.Error(), `handshake with plugin "foo" failed: great sadness`)
 }
@@ -155,7 +155,7 @@ func TestTransportHandleHandshakeError(t *testing.T) {
 			defer server.Close()
 
 			server.Plugin.EXPECT().Handshake(&api.HandshakeRequest{}).Return(tt.response, nil)
-			_, err := NewTransportHandle(tt.name, server.ClientTransport)
+			_, err := NewTransportHandle(tt.name, server.ClientTransport, nil)
 			if assert.Error(t, err, tt.desc) {
 				assert.Equal(t, err.Error(), tt.wantError, tt.desc)
 			}
@@ -384,3 +384,67 @@ func TestServiceGeneratorGenerate(t *testing.T) {
 		}()
 	}
 }
+
+func TestTransportHandleOptions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	server := newFakePluginServer(mockCtrl)
+	defer server.Close()
+
+	server.Plugin.EXPECT().Handshake(&api.HandshakeRequest{}).
+		Return(&api.HandshakeResponse{
+			Name:       "foo",
+			APIVersion: api.APIVersion,
+			Features:   []api.Feature{api.FeatureServiceGenerator},
+			Options: []*api.Option{
+				{Name: "verbose", Type: api.OptionTypeBool},
+				{Name: "limit", Type: api.OptionTypeInt, DefaultValue: ptr.String("10")},
+			},
+		}, nil)
+
+	handle, err := NewTransportHandle("foo", server.ClientTransport, map[string]string{"verbose": "1"})
+	require.NoError(t, err)
+	defer func() {
+		server.ExpectGoodbye()
+		require.NoError(t, handle.Close())
+	}()
+
+	newRequest := func() *api.GenerateServiceRequest {
+		return &api.GenerateServiceRequest{
+			RootServices: []api.ServiceID{},
+			Services:     map[api.ServiceID]*api.Service{},
+			Modules:      map[api.ModuleID]*api.Module{},
+		}
+	}
+
+	want := newRequest()
+	want.Options = map[string]string{"verbose": "true", "limit": "10"}
+	server.ServiceGenerator.EXPECT().Generate(want).
+		Return(&api.GenerateServiceResponse{}, nil)
+
+	req := newRequest()
+	_, err = handle.ServiceGenerator().Generate(req)
+	require.NoError(t, err)
+	assert.Nil(t, req.Options, "request must not be modified")
+}
+
+func TestTransportHandleInvalidOptions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	server := newFakePluginServer(mockCtrl)
+	defer server.Close()
+
+	server.Plugin.EXPECT().Handshake(&api.HandshakeRequest{}).
+		Return(&api.HandshakeResponse{
+			Name:       "foo",
+			APIVersion: api.APIVersion,
+			Features:   []api.Feature{},
+		}, nil)
+	server.ExpectGoodbye()
+
+	_, err := NewTransportHandle("foo", server.ClientTransport, map[string]string{"verbose": "true"})
+	assert.ErrorContains(t, err,
+		`handshake with plugin "foo" failed: unknown option "verbose": the plugin does not accept any options`)
+}
@@ -21,9 +21,14 @@
 package process
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os/exec"
+	"strings"
+	"sync"
+	"time"
 
 	"go.uber.org/thriftrw/internal/frame"
 
@@ -31,21 +36,44 @@ import (
 	"go.uber.org/multierr"
 )
 
+// _waitDelay is the time we wait for the output of an external process to be
+// drained after it exits or is killed. This prevents hanging on descendants
+// of the process that inherited its stderr.
+const _waitDelay = 5 * time.Second
+
 // Client sends framed requests and receives framed responses from an external
 // process.
 type Client struct {
+	ctx     context.Context
 	running *atomic.Bool
 	cmd     *exec.Cmd
 	stdout  io.ReadCloser
 	stdin   io.WriteCloser
+	stderr  *tailBuffer
 	client  *frame.Client
+
+	// failed is set if a request to the process failed and the process was
+	// stopped as a result.
+	failed *atomic.Bool
+
+	stopOnce sync.Once
+	stopped  chan struct{} // closed when we stop the process
+	stopErr  error
 }
 
 // NewClient starts up the given external process and communicates with it over
 // stdin and stdout using framed requests and responses.
 //
+// The process is killed if the context is cancelled or its deadline expires
+// before the Client is closed. Requests made to the process fail at that
+// point.
+//
+// Everything the process writes to stderr is forwarded to the Cmd's Stderr,
+// if any. The last few kilobytes are also included in errors that report the
+// failure of the process.
+//
 // The Cmd MUST NOT have Stdout or Stdin set.
-func NewClient(cmd *exec.Cmd) (*Client, error) {
+func NewClient(ctx context.Context, cmd *exec.Cmd) (*Client, error) {
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stdout pipe to %q: %v", cmd.Path, err)
@@ -56,45 +84,134 @@ func NewClient(cmd *exec.Cmd) (*Client, error) {
 		return nil, fmt.Errorf("failed to create stdin pipe to %q: %v", cmd.Path, err)
 	}
 
+	stderr := newTailBuffer(_stderrTailSize)
+	if cmd.Stderr != nil {
+		cmd.Stderr = io.MultiWriter(cmd.Stderr, stderr)
+	} else {
+		cmd.Stderr = stderr
+	}
+	if cmd.WaitDelay == 0 {
+		cmd.WaitDelay = _waitDelay
+	}
+
 	if err := cmd.Start(); err != nil {
 		return nil, fmt.Errorf("failed to start %q: %v", cmd.Path, err)
 	}
 
-	return &Client{
+	c := &Client{
+		ctx:     ctx,
 		stdout:  stdout,
 		stdin:   stdin,
+		stderr:  stderr,
 		running: atomic.NewBool(true),
+		failed:  atomic.NewBool(false),
 		client:  frame.NewClient(stdin, stdout),
 		cmd:     cmd,
-	}, nil
+		stopped: make(chan struct{}),
+	}
+	go c.killOnDone()
+	return c, nil
+}
+
+// killOnDone kills the process if the context finishes before the process
+// is stopped.
+func (c *Client) killOnDone() {
+	select {
+	case <-c.ctx.Done():
+		_ = c.cmd.Process.Kill()
+	case <-c.stopped:
+	}
 }
 
 // Send sends the given frame to the external process and returns the response.
 //
+// If the request fails, the process is killed and the returned error
+// includes the reason the process stopped and its recent stderr output.
+//
 // Panics if Close was already called.
 func (c *Client) Send(data []byte) ([]byte, error) {
 	if !c.running.Load() {
 		panic(fmt.Sprintf("process.Client for %q has been closed", c.cmd.Path))
 	}
 
-	return c.client.Send(data)
+	if c.failed.Load() {
+		return nil, fmt.Errorf("%q is no longer running", c.cmd.Path)
+	}
+
+	res, err := c.client.Send(data)
+	if err == nil {
+		return res, nil
+	}
+
+	c.failed.Store(true)
+	if waitErr := c.stop(true /* kill */); waitErr != nil {
+		err = waitErr
+	}
+	return nil, c.failure(err)
 }
 
 // Close detaches from the external process and waits for it to exit.
+//
+// Close does not report failures that were already returned by Send.
 func (c *Client) Close() error {
 	if !c.running.Swap(false) {
 		return nil // already stopped
 	}
 
-	var errors []error
-	if err := c.stdout.Close(); err != nil {
-		errors = append(errors, fmt.Errorf("failed to detach stdout from %q: %v", c.cmd.Path, err))
+	if c.failed.Load() {
+		return nil // already reported
 	}
-	if err := c.stdin.Close(); err != nil {
-		errors = append(errors, fmt.Errorf("failed to detach stdin from %q: %v", c.cmd.Path, err))
+
+	if err := c.stop(false /* kill */); err != nil {
+		return c.failure(err)
+	}
+	return nil
+}
+
+// stop stops the process and waits for it to exit, returning the error
+// reported by exec.Cmd.Wait. The process is killed if kill is true.
+// Otherwise, it is expected to exit once its stdin is closed.
+//
+// Only the first call to stop has an effect. Later calls return the result
+// of the first call.
+func (c *Client) stop(kill bool) error {
+	c.stopOnce.Do(func() {
+		defer close(c.stopped)
+
+		if kill {
+			_ = c.cmd.Process.Kill()
+		}
+
+		var errors []error
+		if err := c.stdout.Close(); err != nil {
+			errors = append(errors, fmt.Errorf("failed to detach stdout: %v", err))
+		}
+		if err := c.stdin.Close(); err != nil {
+			errors = append(errors, fmt.Errorf("failed to detach stdin: %v", err))
+		}
+		if err := c.cmd.Wait(); err != nil {
+			errors = append(errors, err)
+		}
+		c.stopErr = multierr.Combine(errors...)
+	})
+	return c.stopErr
+}
+
+// failure builds an error reporting that the process failed with the given
+// error.
+func (c *Client) failure(err error) error {
+	var b strings.Builder
+	switch ctxErr := c.ctx.Err(); {
+	case errors.Is(ctxErr, context.DeadlineExceeded):
+		fmt.Fprintf(&b, "%q timed out and was killed", c.cmd.Path)
+	case ctxErr != nil:
+		fmt.Fprintf(&b, "%q was killed: %v", c.cmd.Path, ctxErr)
+	default:
+		fmt.Fprintf(&b, "%q failed with: %v", c.cmd.Path, err)
 	}
-	if err := c.cmd.Wait(); err != nil {
-		errors = append(errors, fmt.Errorf("%q failed with: %v", c.cmd.Path, err))
+
+	if stderr := strings.TrimSpace(c.stderr.String()); stderr != "" {
+		fmt.Fprintf(&b, "\nstderr:\n%v", stderr)
 	}
-	return multierr.Combine(errors...)
+	return errors.New(b.String())
 }
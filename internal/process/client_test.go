@@ -2,16 +2,18 @@ package process
 
 import (
 	"bytes"
+	"context"
 	"os/exec"
 	"testing"
 	"testing/quick"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestCat(t *testing.T) {
-	client, err := NewClient(exec.Command("cat"))
+	client, err := NewClient(context.Background(), exec.Command("cat"))
 	require.NoError(t, err)
 	defer client.Close()
 
@@ -23,7 +25,7 @@ func TestCat(t *testing.T) {
 }
 
 func TestSendAfterStop(t *testing.T) {
-	client, err := NewClient(exec.Command("cat"))
+	client, err := NewClient(context.Background(), exec.Command("cat"))
 	require.NoError(t, err)
 
 	_, err = client.Send([]byte("hello"))
@@ -36,7 +38,7 @@ func TestSendAfterStop(t *testing.T) {
 }
 
 func TestCloseTwice(t *testing.T) {
-	client, err := NewClient(exec.Command("cat"))
+	client, err := NewClient(context.Background(), exec.Command("cat"))
 	require.NoError(t, err)
 	require.NoError(t, client.Close())
 	require.NoError(t, client.Close())
@@ -74,9 +76,85 @@ func TestStartErrors(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		_, err := NewClient(tt.getCommand())
+		_, err := NewClient(context.Background(), tt.getCommand())
 		if assert.Error(t, err) {
 			assert.Contains(t, err.Error(), tt.wantMessageLike)
 		}
 	}
 }
+
+func TestTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("sh", "-c", "echo 'stuck forever' >&2; exec sleep 60")
+	cmd.Stderr = &stderr
+
+	client, err := NewClient(ctx, cmd)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = client.Send([]byte("hello"))
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 30*time.Second, "plugin must be killed")
+	assert.Contains(t, err.Error(), "timed out and was killed")
+	assert.Contains(t, err.Error(), "stderr:\nstuck forever")
+	assert.Equal(t, "stuck forever\n", stderr.String(), "stderr must be forwarded")
+
+	_, err = client.Send([]byte("hello"))
+	assert.ErrorContains(t, err, "is no longer running")
+	assert.NoError(t, client.Close(), "failure must not be reported twice")
+}
+
+func TestCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client, err := NewClient(ctx, exec.Command("sh", "-c", "exec sleep 60"))
+	require.NoError(t, err)
+
+	cancel()
+	_, err = client.Send([]byte("hello"))
+	assert.ErrorContains(t, err, "was killed: context canceled")
+	assert.NoError(t, client.Close())
+}
+
+func TestCrash(t *testing.T) {
+	client, err := NewClient(context.Background(),
+		exec.Command("sh", "-c", "echo 'great sadness' >&2; exit 1"))
+	require.NoError(t, err)
+
+	_, err = client.Send([]byte("hello"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed with: exit status 1")
+	assert.Contains(t, err.Error(), "stderr:\ngreat sadness")
+	assert.NoError(t, client.Close())
+}
+
+func TestCloseReportsExitStatus(t *testing.T) {
+	client, err := NewClient(context.Background(),
+		exec.Command("sh", "-c", "cat >/dev/null; echo 'bad exit' >&2; exit 2"))
+	require.NoError(t, err)
+
+	err = client.Close()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed with: exit status 2")
+	assert.Contains(t, err.Error(), "stderr:\nbad exit")
+}
+
+func TestTailBuffer(t *testing.T) {
+	b := newTailBuffer(8)
+
+	n, err := b.Write([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, "hello", b.String())
+
+	_, err = b.Write([]byte(" world"))
+	require.NoError(t, err)
+	assert.Equal(t, "lo world", b.String())
+
+	n, err = b.Write([]byte("a very long line"))
+	require.NoError(t, err)
+	assert.Equal(t, 16, n, "must report all bytes as written")
+	assert.Equal(t, "ong line", b.String())
+}
@@ -22,12 +22,17 @@ package main // import "go.uber.org/thriftrw"
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"go.uber.org/thriftrw/compile"
 	"go.uber.org/thriftrw/gen"
@@ -41,16 +46,29 @@ import (
 
 type options struct {
 	DisplayVersion bool       `long:"version" short:"v" description:"Show the ThriftRW version number"`
+	ConfigFile     string     `long:"config" value-name:"FILE" description:"Generate code as described by this configuration file. By default, thriftrw.yaml is used from the current directory or its closest parent directory that has one if no Thrift files were provided."`
 	GOpts          genOptions `group:"Generator Options"`
 }
 
 type genOptions struct {
-	OutputDirectory string `long:"out" short:"o" value-name:"DIR" description:"Directory to which the generated files will be written."`
-	PackagePrefix   string `long:"pkg-prefix" value-name:"PREFIX" description:"Prefix for import paths of generated module. By default, this is based on the output directory's location relative to $GOPATH."`
-	ThriftRoot      string `long:"thrift-root" value-name:"DIR" description:"Directory whose descendants contain all Thrift files. The structure of the generated Go packages mirrors the paths to the Thrift files relative to this directory. By default, this is the deepest common ancestor directory of the Thrift files."`
+	OutputDirectory string   `long:"out" short:"o" value-name:"DIR" description:"Directory to which the generated files will be written."`
+	PackagePrefix   string   `long:"pkg-prefix" value-name:"PREFIX" description:"Prefix for import paths of generated module. By default, this is based on the Go module containing the output directory, or its location relative to $GOPATH outside modules."`
+	ThriftDirs      []string `long:"thrift-dir" value-name:"DIR" description:"Generate code for all Thrift files inside this directory and its descendants, in addition to the FILE arguments. This option may be provided multiple times."`
+	ThriftRoot      string   `long:"thrift-root" value-name:"DIR" description:"Directory whose descendants contain all Thrift files. The structure of the generated Go packages mirrors the paths to the Thrift files relative to this directory. By default, this is the deepest common ancestor directory of the Thrift files."`
 
-	NoRecurse bool         `long:"no-recurse" description:"Don't generate code for included Thrift files."`
-	Plugins   plugin.Flags `long:"plugin" short:"p" value-name:"PLUGIN" description:"Code generation plugin for ThriftRW. This option may be provided multiple times to apply multiple plugins."`
+	CacheFile string `long:"cache-file" value-name:"FILE" description:"File recording the code generated by previous runs. If provided, code is regenerated only for Thrift files whose contents, includes, options, or plugins changed since the last run. Files whose contents haven't changed are never rewritten."`
+	Check     bool   `long:"check" description:"Report generated files that are missing, out of date, or no longer generated instead of writing them, and exit with a non-zero status if there are any."`
+	Diff      bool   `long:"diff" description:"Like --check, but also print a unified diff from the current files to the generated code."`
+	Manifest  string `long:"manifest" value-name:"FILE" description:"Write a JSON file listing the Thrift files read, and the generated files and Go packages. If provided, files listed by a previous run that are no longer generated are removed. Files changed since they were generated are never removed."`
+	DepFile   string `long:"depfile" value-name:"FILE" description:"Write a Makefile rule listing the generated files as targets and the Thrift files read as their prerequisites."`
+
+	NoRecurse   bool         `long:"no-recurse" description:"Don't generate code for included Thrift files."`
+	GoNamespace bool         `long:"go-namespace" description:"Use 'namespace go' declarations to determine the packages of Thrift files relative to the package prefix. For example, 'namespace go foo.bar' generates code into the foo/bar package."`
+	Plugins     plugin.Flags `long:"plugin" short:"p" value-name:"PLUGIN" description:"Code generation plugin for ThriftRW. This option may be provided multiple times to apply multiple plugins."`
+
+	PluginOptions []string `long:"plugin-option" value-name:"PLUGIN.NAME=VALUE" description:"Value for an option declared by a plugin. This option may be provided multiple times. Use --help with --plugin to list the options accepted by a plugin."`
+
+	PluginTimeout time.Duration `long:"plugin-timeout" value-name:"DURATION" description:"Maximum time plugins may run for, for example 30s or 2m. Plugins still running after this are killed. By default, there is no limit."`
 
 	GeneratePluginAPI     bool   `long:"generate-plugin-api" hidden:"true" description:"Generates code for the plugin API"`
 	NoVersionCheck        bool   `long:"no-version-check" hidden:"true" description:"Does not add library version checks to generated code."`
@@ -60,7 +78,15 @@ type genOptions struct {
 	NoEmbedIDL            bool   `long:"no-embed-idl" description:"Do not embed IDLs into the generated code."`
 	NoZap                 bool   `long:"no-zap" description:"Do not generate code for Zap logging."`
 	OutputFile            string `long:"output-file" value-name:"FILENAME" description:"Generates a single .go file as an output. Specifying an OutputFile prevents code generation for included Thrift Files."`
+	Layout                string `long:"layout" value-name:"LAYOUT" choice:"single" choice:"definition" description:"How the code for each Thrift file is split into files. 'single' generates one file named after the package. 'definition' generates a file for the constants, one for each type and service, and one for the embedded IDL. Defaults to 'single'."`
+	MaxFileSize           int    `long:"max-file-size" value-name:"BYTES" description:"Start a new file once a generated file reaches this size. Types and service functions are never split across files."`
+	FileNameTemplate      string `long:"file-name-template" value-name:"TEMPLATE" description:"Go template for the names of generated files, with the fields .Package, .Dir, .Kind, .Name, and .Index, and the function lower. See the documentation of gen.FileNameData."`
 	EnumTextMarshalStrict bool   `long:"enum-text-marshal-strict" hidden:"true" description:"Generate code to throw error on trying to marshal unknown enum"`
+	Setters               bool   `long:"setters" description:"Generate chainable Set* methods for all struct fields."`
+	GenericPtr            bool   `long:"generic-ptr" description:"Use the generic helpers from the ptr package in generated getters and default values."`
+	BinaryMarshaler       bool   `long:"binary-marshaler" description:"Generate MarshalBinary, UnmarshalBinary, MarshalThrift, and UnmarshalThrift methods for structs."`
+	ReuseDecode           bool   `long:"reuse-decode" description:"Generate Decode methods that reuse the containers and nested structs of the value being decoded into, and Reset methods for structs."`
+	Iterators             bool   `long:"iterators" description:"Generate All* methods that return iterators over list, set, and map fields of structs."`
 
 	// TODO(abg): Detailed help with examples of --thrift-root, --pkg-prefix,
 	// and --plugin
@@ -81,12 +107,12 @@ func do() (err error) {
 	var opts options
 
 	parser := flags.NewParser(&opts, flags.Default & ^flags.PrintErrors)
-	parser.Usage = "[OPTIONS] FILE"
+	parser.Usage = "[OPTIONS] FILE..."
 
 	args, err := parser.Parse()
 	if ferr, ok := err.(*flags.Error); ok && ferr.Type == flags.ErrHelp {
 		parser.WriteHelp(os.Stdout)
-		return nil
+		return writePluginOptionsHelp(os.Stdout, opts.GOpts.Plugins)
 	} else if err != nil {
 		return err
 	}
@@ -96,21 +122,40 @@ func do() (err error) {
 		return nil
 	}
 
-	if len(args) != 1 {
+	inputFiles, err := thriftFiles(args, opts.GOpts.ThriftDirs)
+	if err != nil {
+		return err
+	}
+
+	configFile := opts.ConfigFile
+	if configFile == "" && len(inputFiles) == 0 {
+		configFile, err = findConfig()
+		if err != nil {
+			return err
+		}
+	}
+
+	if configFile != "" {
+		if len(inputFiles) > 0 {
+			return errors.New("Thrift files cannot be provided with --config: list them in the configuration file instead")
+		}
+		if err := checkConfigFlags(parser); err != nil {
+			return err
+		}
+		return generateFromConfig(configFile, opts.GOpts)
+	}
+
+	if len(inputFiles) == 0 {
 		var buffer bytes.Buffer
 		parser.WriteHelp(&buffer)
 		return errors.New(buffer.String())
 	}
 
-	inputFile := args[0]
-	if _, err := os.Stat(inputFile); err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("File %q does not exist: %v", inputFile, err)
-		}
-		return fmt.Errorf("Could not stat file %q: %v", inputFile, err)
-	}
-	gopts := opts.GOpts
+	return generate(opts.GOpts, inputFiles)
+}
 
+// generate generates code for the given Thrift files.
+func generate(gopts genOptions, inputFiles []string) (err error) {
 	if len(gopts.OutputDirectory) == 0 {
 		gopts.OutputDirectory = "."
 	}
@@ -129,31 +174,36 @@ func do() (err error) {
 		}
 	}
 
-	module, err := compile.Compile(inputFile)
+	modules, err := compile.CompileFiles(inputFiles)
 	if err != nil {
 		// TODO(abg): For nested compile errors, split causal chain across
 		// multiple lines.
-		return fmt.Errorf("Failed to compile %q: %+v", inputFile, err)
+		if len(inputFiles) == 1 {
+			return fmt.Errorf("Failed to compile %q: %+v", inputFiles[0], err)
+		}
+		return fmt.Errorf("Failed to compile Thrift files: %+v", err)
 	}
 
 	if gopts.ThriftRoot == "" {
-		gopts.ThriftRoot, err = findCommonAncestor(module)
+		gopts.ThriftRoot, err = findCommonAncestor(modules...)
 		if err != nil {
 			return fmt.Errorf(
 				"Could not find a common parent directory for %q and the Thrift files "+
-					"imported by it.\nThis directory is required to generate a consistent "+
+					"imported by them.\nThis directory is required to generate a consistent "+
 					"hierarchy for generated packages.\nUse the --thrift-root option to "+
-					"provide this path.\n\t%v", inputFile, err)
+					"provide this path.\n\t%v", strings.Join(inputFiles, ", "), err)
 		}
 	} else {
 		gopts.ThriftRoot, err = filepath.Abs(gopts.ThriftRoot)
 		if err != nil {
 			return fmt.Errorf("Unable to resolve absolute path for %q: %v", gopts.ThriftRoot, err)
 		}
-		if err := verifyAncestry(module, gopts.ThriftRoot); err != nil {
-			return fmt.Errorf(
-				"An included Thrift file is not contained in the %q directory tree: %v",
-				gopts.ThriftRoot, err)
+		for _, module := range modules {
+			if err := verifyAncestry(module, gopts.ThriftRoot); err != nil {
+				return fmt.Errorf(
+					"An included Thrift file is not contained in the %q directory tree: %v",
+					gopts.ThriftRoot, err)
+			}
 		}
 	}
 
@@ -161,7 +211,18 @@ func do() (err error) {
 		return fmt.Errorf("output-file value: %q invalid. A {FILENAME}.go name must be provided", gopts.OutputFile)
 	}
 
-	pluginHandle, err := gopts.Plugins.Handle()
+	if err := gopts.Plugins.SetOptions(gopts.PluginOptions); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if gopts.PluginTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, gopts.PluginTimeout)
+		defer cancel()
+	}
+
+	pluginHandle, err := gopts.Plugins.Handle(ctx)
 	if err != nil {
 		return fmt.Errorf("Failed to initialize plugins: %+v", err)
 	}
@@ -174,14 +235,51 @@ func do() (err error) {
 		err = multierr.Append(err, pluginHandle.Close())
 	}()
 
+	var layout gen.Layout
+	switch gopts.Layout {
+	case "", "single":
+		layout = gen.SingleFileLayout
+	case "definition":
+		layout = gen.DefinitionLayout
+	default:
+		return fmt.Errorf("unknown layout %q", gopts.Layout)
+	}
+
 	codeGenerator := gen.CodeGenerator{
 		ServiceGenerator: pluginHandle.ServiceGenerator(),
 	}
+	if gopts.Manifest != "" {
+		gopts.Manifest, err = filepath.Abs(gopts.Manifest)
+		if err != nil {
+			return fmt.Errorf("Unable to resolve absolute path for %q: %v", gopts.Manifest, err)
+		}
+	}
+	if gopts.DepFile != "" {
+		gopts.DepFile, err = filepath.Abs(gopts.DepFile)
+		if err != nil {
+			return fmt.Errorf("Unable to resolve absolute path for %q: %v", gopts.DepFile, err)
+		}
+	}
+	if gopts.CacheFile != "" {
+		gopts.CacheFile, err = filepath.Abs(gopts.CacheFile)
+		if err != nil {
+			return fmt.Errorf("Unable to resolve absolute path for %q: %v", gopts.CacheFile, err)
+		}
+
+		codeGenerator.ID, err = gopts.Plugins.ID()
+		if err != nil {
+			return fmt.Errorf("Failed to identify plugins: %v", err)
+		}
+		if gopts.GeneratePluginAPI {
+			codeGenerator.ID += " pluginapigen"
+		}
+	}
 	generatorOptions := gen.Options{
 		OutputDir:             gopts.OutputDirectory,
 		PackagePrefix:         gopts.PackagePrefix,
 		ThriftRoot:            gopts.ThriftRoot,
 		NoRecurse:             gopts.NoRecurse,
+		GoNamespace:           gopts.GoNamespace,
 		NoVersionCheck:        gopts.NoVersionCheck,
 		Plugin:                codeGenerator,
 		NoTypes:               gopts.NoTypes,
@@ -191,13 +289,138 @@ func do() (err error) {
 		NoZap:                 gopts.NoZap,
 		OutputFile:            gopts.OutputFile,
 		EnumTextMarshalStrict: gopts.EnumTextMarshalStrict,
+		Setters:               gopts.Setters,
+		GenericPtr:            gopts.GenericPtr,
+		BinaryMarshaler:       gopts.BinaryMarshaler,
+		ReuseDecode:           gopts.ReuseDecode,
+		Iterators:             gopts.Iterators,
+		CacheFile:             gopts.CacheFile,
+		Check:                 gopts.Check || gopts.Diff,
+		Diff:                  gopts.Diff,
+		ManifestFile:          gopts.Manifest,
+		DepFile:               gopts.DepFile,
+		Layout:                layout,
+		MaxFileSize:           gopts.MaxFileSize,
+		FileNameTemplate:      gopts.FileNameTemplate,
 	}
-	if err := gen.Generate(module, &generatorOptions); err != nil {
+	if err := gen.GenerateModules(modules, &generatorOptions); err != nil {
+		var checkErr *gen.CheckError
+		if errors.As(err, &checkErr) {
+			if _, werr := io.WriteString(os.Stdout, checkErr.Diff); werr != nil {
+				return multierr.Append(err, werr)
+			}
+			return err
+		}
 		return fmt.Errorf("Failed to generate code: %+v", err)
 	}
 	return nil
 }
 
+// thriftFiles returns the Thrift files to generate code for, given the
+// positional arguments and the --thrift-dir directories.
+//
+// Arguments may be paths or glob patterns. Files matched more than once are
+// returned only once.
+func thriftFiles(args, dirs []string) ([]string, error) {
+	var (
+		files []string
+		seen  = make(map[string]struct{})
+	)
+	add := func(path string) error {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return fmt.Errorf("Unable to resolve absolute path for %q: %v", path, err)
+		}
+		if _, ok := seen[abs]; !ok {
+			seen[abs] = struct{}{}
+			files = append(files, path)
+		}
+		return nil
+	}
+
+	for _, arg := range args {
+		if strings.ContainsAny(arg, "*?[") {
+			matches, err := filepath.Glob(arg)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid pattern %q: %v", arg, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("No files match %q", arg)
+			}
+			for _, m := range matches {
+				if err := add(m); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		if _, err := os.Stat(arg); err != nil {
+			if os.IsNotExist(err) {
+				return nil, fmt.Errorf("File %q does not exist: %v", arg, err)
+			}
+			return nil, fmt.Errorf("Could not stat file %q: %v", arg, err)
+		}
+		if err := add(arg); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, dir := range dirs {
+		var found bool
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || filepath.Ext(path) != ".thrift" {
+				return nil
+			}
+			found = true
+			return add(path)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Could not search %q for Thrift files: %v", dir, err)
+		}
+		if !found {
+			return nil, fmt.Errorf("No Thrift files found in %q", dir)
+		}
+	}
+
+	return files, nil
+}
+
+// writePluginOptionsHelp writes help for the options declared by the given
+// plugins.
+func writePluginOptionsHelp(w io.Writer, plugins plugin.Flags) error {
+	if len(plugins) == 0 {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "\nPlugin Options:")
+	for i := range plugins {
+		p := &plugins[i]
+		options, err := p.DescribeOptions(context.Background())
+		if err != nil {
+			return err
+		}
+
+		if len(options) == 0 {
+			fmt.Fprintf(tw, "  %v accepts no options\n", p.Name)
+			continue
+		}
+
+		for _, opt := range options {
+			help := opt.GetHelp()
+			if opt.DefaultValue != nil {
+				help = strings.TrimSpace(fmt.Sprintf("%v (default: %v)", help, *opt.DefaultValue))
+			}
+			fmt.Fprintf(tw, "  --plugin-option=%v.%v=%v\t%v\n", p.Name, opt.Name, opt.Type, help)
+		}
+	}
+	return tw.Flush()
+}
+
 // verifyAncestry verifies that the Thrift file for the given module and the
 // Thrift files for all imported modules are contained within the directory
 // tree rooted at the given path.
@@ -221,11 +444,11 @@ func verifyAncestry(m *compile.Module, root string) error {
 
 // findCommonAncestor finds the deepest common ancestor for the given module
 // and all modules imported by it.
-func findCommonAncestor(m *compile.Module) (string, error) {
+func findCommonAncestor(ms ...*compile.Module) (string, error) {
 	var result []string
 	var lastString string
 
-	err := m.Walk(func(m *compile.Module) error {
+	visit := func(m *compile.Module) error {
 		thriftPath := m.ThriftPath
 		if !filepath.IsAbs(thriftPath) {
 			return fmt.Errorf(
@@ -251,9 +474,12 @@ func findCommonAncestor(m *compile.Module) (string, error) {
 
 		lastString = thriftPath
 		return nil
-	})
-	if err != nil {
-		return "", err
+	}
+
+	for _, m := range ms {
+		if err := m.Walk(visit); err != nil {
+			return "", err
+		}
 	}
 
 	return strings.Join(result, string(filepath.Separator)), nil
@@ -271,28 +497,3 @@ func commonPrefix(l, r []string) []string {
 	}
 	return l[:i]
 }
-
-// determinePackagePrefix determines the package prefix for Go packages
-// generated in this file.
-//
-// dir must be an absolute path.
-func determinePackagePrefix(dir string) (string, error) {
-	gopathList := os.Getenv("GOPATH")
-	if gopathList == "" {
-		return "", errors.New("$GOPATH is not set")
-	}
-
-	for _, gopath := range filepath.SplitList(gopathList) {
-		packagePath, err := filepath.Rel(filepath.Join(gopath, "src"), dir)
-		if err != nil {
-			return "", err
-		}
-
-		// The match is valid only if it's within the directory tree.
-		if !strings.HasPrefix(packagePath, "..") {
-			return packagePath, nil
-		}
-	}
-
-	return "", fmt.Errorf("directory %q is not inside $GOPATH/src", dir)
-}
@@ -21,93 +21,19 @@
 package main
 
 import (
+	"bytes"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 
 	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/internal/plugin"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func TestDeterminePackagePrefix(t *testing.T) {
-	gopath, err := os.MkdirTemp("", "thriftrw-main-test")
-	require.NoError(t, err)
-	defer os.RemoveAll(gopath)
-
-	gopath2, err := os.MkdirTemp("", "thriftrw-main-test")
-	require.NoError(t, err)
-	defer os.RemoveAll(gopath2)
-
-	genDir := filepath.Join(gopath, "src/go.uber.org/thriftrw/gen")
-
-	tests := []struct {
-		desc   string
-		gopath string // GOPATH to use
-		dir    string // directory we're checking
-		result string // expected package prefix
-		errMsg string // error message, if any
-	}{
-		{
-			desc:   "no GOPATH",
-			dir:    filepath.Join(gopath, "src/go.uber.org/thriftrw"),
-			errMsg: "$GOPATH is not set",
-		},
-		{
-			desc:   "not inside GOPATH",
-			gopath: gopath,
-			dir:    filepath.Join(gopath2, "src/go.uber.org/yarpc"),
-			errMsg: "not inside $GOPATH",
-		},
-		{
-			desc:   "GOPATH is set",
-			gopath: gopath,
-			dir:    genDir,
-			result: "go.uber.org/thriftrw/gen",
-		},
-		{
-			desc:   "multiple GOPATH entries/first",
-			gopath: gopath + ":" + gopath2,
-			dir:    genDir,
-			result: "go.uber.org/thriftrw/gen",
-		},
-		{
-			desc:   "multiple GOPATH entries/second",
-			gopath: gopath + ":" + gopath2,
-			dir:    filepath.Join(gopath2, "src/go.uber.org/yarpc"),
-			result: "go.uber.org/yarpc",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.desc, func(t *testing.T) {
-
-			oldGopath := os.Getenv("GOPATH")
-			if len(tt.gopath) == 0 {
-				require.NoError(t, os.Unsetenv("GOPATH"))
-			} else {
-				require.NoError(t, os.Setenv("GOPATH", tt.gopath))
-			}
-			defer os.Setenv("GOPATH", oldGopath)
-
-			result, err := determinePackagePrefix(tt.dir)
-
-			if tt.result != "" {
-				if assert.NoError(t, err) {
-					assert.Equal(t, tt.result, result)
-				}
-			}
-
-			if tt.errMsg != "" {
-				if assert.Error(t, err, "determinePackagePrefix(%q) should fail", tt.dir) {
-					assert.Contains(t, err.Error(), tt.errMsg)
-				}
-			}
-		})
-	}
-}
-
 func TestCommonPrefix(t *testing.T) {
 	tests := []struct {
 		left     []string
@@ -436,3 +362,101 @@ func TestFindCommonAncestor(t *testing.T) {
 		}
 	}
 }
+
+func TestWritePluginOptionsHelp(t *testing.T) {
+	testdata := func(name string) *exec.Cmd {
+		return exec.Command(filepath.Join("internal", "plugin", "testdata", name))
+	}
+
+	var buf bytes.Buffer
+	err := writePluginOptionsHelp(&buf, plugin.Flags{
+		{Name: "options", Command: testdata("thriftrw-plugin-options")},
+		{Name: "empty", Command: testdata("thriftrw-plugin-empty")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `
+Plugin Options:
+  --plugin-option=options.verbose=BOOL  Log more information.
+  --plugin-option=options.limit=INT     Maximum number of files. (default: 10)
+  empty accepts no options
+`, buf.String())
+}
+
+func TestThriftFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"a.thrift", "b.thrift", "c.txt", "sub/d.thrift", "sub/deeper/e.thrift", "empty/f.txt"} {
+		p := filepath.Join(dir, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, nil, 0o644))
+	}
+	join := func(p string) string { return filepath.Join(dir, p) }
+
+	tests := []struct {
+		desc string
+		args []string
+		dirs []string
+
+		want    []string
+		wantErr string
+	}{
+		{desc: "empty"},
+		{
+			desc: "files",
+			args: []string{join("b.thrift"), join("a.thrift")},
+			want: []string{join("b.thrift"), join("a.thrift")},
+		},
+		{
+			desc: "glob",
+			args: []string{join("*.thrift")},
+			want: []string{join("a.thrift"), join("b.thrift")},
+		},
+		{
+			desc: "directory",
+			dirs: []string{join("sub")},
+			want: []string{join("sub/d.thrift"), join("sub/deeper/e.thrift")},
+		},
+		{
+			desc: "duplicates",
+			args: []string{join("a.thrift"), join("*.thrift")},
+			dirs: []string{dir},
+			want: []string{
+				join("a.thrift"),
+				join("b.thrift"),
+				join("sub/d.thrift"),
+				join("sub/deeper/e.thrift"),
+			},
+		},
+		{
+			desc:    "missing file",
+			args:    []string{join("x.thrift")},
+			wantErr: "does not exist",
+		},
+		{
+			desc:    "glob without matches",
+			args:    []string{join("*.proto")},
+			wantErr: "No files match",
+		},
+		{
+			desc:    "directory without Thrift files",
+			dirs:    []string{join("empty")},
+			wantErr: "No Thrift files found",
+		},
+		{
+			desc:    "missing directory",
+			dirs:    []string{join("missing")},
+			wantErr: "Could not search",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := thriftFiles(tt.args, tt.dirs)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
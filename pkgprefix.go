@@ -0,0 +1,227 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// determinePackagePrefix determines the package prefix for Go packages
+// generated in this directory.
+//
+// The import path is based on the Go module containing the directory,
+// taking go.work workspaces into account. Directories outside Go modules
+// fall back to their location inside $GOPATH/src.
+//
+// dir must be an absolute path.
+func determinePackagePrefix(dir string) (string, error) {
+	prefix, modErr := modulePackagePrefix(dir)
+	if modErr == nil {
+		return prefix, nil
+	}
+
+	// Fall back to GOPATH only if there's no module. Broken go.mod or
+	// go.work files should be reported as-is.
+	var notInModule errNotInModule
+	if !errors.As(modErr, &notInModule) {
+		return "", fmt.Errorf("could not use Go modules: %v", modErr)
+	}
+
+	prefix, gopathErr := gopathPackagePrefix(dir)
+	if gopathErr != nil {
+		return "", fmt.Errorf(
+			"could not use Go modules: %v\ncould not use $GOPATH: %v", modErr, gopathErr)
+	}
+	return prefix, nil
+}
+
+type errNotInModule struct {
+	Dir string
+}
+
+func (e errNotInModule) Error() string {
+	return fmt.Sprintf("could not find a go.mod file in %q or any of its parents", e.Dir)
+}
+
+// modulePackagePrefix determines the package prefix for dir from the Go
+// module containing it.
+func modulePackagePrefix(dir string) (string, error) {
+	root, err := moduleRoot(dir)
+	if err != nil {
+		return "", err
+	}
+
+	gomod := filepath.Join(root, "go.mod")
+	data, err := os.ReadFile(gomod)
+	if err != nil {
+		return "", err
+	}
+
+	modPath := modfile.ModulePath(data)
+	if modPath == "" {
+		return "", fmt.Errorf("%q does not declare a module path", gomod)
+	}
+
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(modPath, filepath.ToSlash(rel)), nil
+}
+
+// moduleRoot returns the directory of the Go module containing dir.
+//
+// This is the directory of the closest go.mod file above dir. If dir is
+// inside a go.work workspace, that module must be one of the modules used by
+// the workspace, matching the behavior of the go command.
+func moduleRoot(dir string) (string, error) {
+	gomod, err := findUp(dir, "go.mod")
+	if err != nil {
+		return "", err
+	}
+
+	gowork, err := findWorkspace(dir)
+	if err != nil {
+		return "", err
+	}
+
+	if gowork == "" {
+		if gomod == "" {
+			return "", errNotInModule{Dir: dir}
+		}
+		return filepath.Dir(gomod), nil
+	}
+
+	roots, err := workspaceModules(gowork)
+	if err != nil {
+		return "", err
+	}
+
+	// Use the innermost workspace module containing dir.
+	var root string
+	for _, r := range roots {
+		if isWithin(r, dir) && len(r) > len(root) {
+			root = r
+		}
+	}
+
+	switch {
+	case root == "" && gomod == "":
+		return "", fmt.Errorf("directory %q is not inside any of the modules used by %q", dir, gowork)
+	case gomod != "" && filepath.Dir(gomod) != root:
+		return "", fmt.Errorf("%q is not one of the modules used by %q", gomod, gowork)
+	}
+	return root, nil
+}
+
+// findWorkspace returns the path to the go.work file in effect for dir, or
+// an empty string if dir is not part of a workspace.
+func findWorkspace(dir string) (string, error) {
+	switch gowork := os.Getenv("GOWORK"); gowork {
+	case "off":
+		return "", nil
+	case "":
+		return findUp(dir, "go.work")
+	default:
+		return gowork, nil
+	}
+}
+
+// workspaceModules returns the absolute paths of the module directories used
+// by the given go.work file.
+func workspaceModules(gowork string) ([]string, error) {
+	data, err := os.ReadFile(gowork)
+	if err != nil {
+		return nil, err
+	}
+
+	work, err := modfile.ParseWork(gowork, data, nil /* fix */)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := make([]string, len(work.Use))
+	for i, use := range work.Use {
+		root := filepath.FromSlash(use.Path)
+		if !filepath.IsAbs(root) {
+			root = filepath.Join(filepath.Dir(gowork), root)
+		}
+		roots[i] = filepath.Clean(root)
+	}
+	return roots, nil
+}
+
+// findUp searches dir and its parents for a file with the given name. It
+// returns an empty string if the file was not found.
+func findUp(dir, name string) (string, error) {
+	for {
+		p := filepath.Join(dir, name)
+		info, err := os.Stat(p)
+		switch {
+		case err == nil && !info.IsDir():
+			return p, nil
+		case err != nil && !os.IsNotExist(err):
+			return "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// isWithin reports whether path is root or a descendant of it.
+func isWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// gopathPackagePrefix determines the package prefix for dir from its
+// location inside $GOPATH/src.
+func gopathPackagePrefix(dir string) (string, error) {
+	gopathList := os.Getenv("GOPATH")
+	if gopathList == "" {
+		return "", errors.New("$GOPATH is not set")
+	}
+
+	for _, gopath := range filepath.SplitList(gopathList) {
+		packagePath, err := filepath.Rel(filepath.Join(gopath, "src"), dir)
+		if err != nil {
+			return "", err
+		}
+
+		// The match is valid only if it's within the directory tree.
+		if !strings.HasPrefix(packagePath, "..") {
+			return packagePath, nil
+		}
+	}
+
+	return "", fmt.Errorf("directory %q is not inside $GOPATH/src", dir)
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeterminePackagePrefix(t *testing.T) {
+	gopath, err := os.MkdirTemp("", "thriftrw-main-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(gopath)
+
+	gopath2, err := os.MkdirTemp("", "thriftrw-main-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(gopath2)
+
+	genDir := filepath.Join(gopath, "src/go.uber.org/thriftrw/gen")
+
+	tests := []struct {
+		desc   string
+		gopath string // GOPATH to use
+		dir    string // directory we're checking
+		result string // expected package prefix
+		errMsg string // error message, if any
+	}{
+		{
+			desc:   "no GOPATH",
+			dir:    filepath.Join(gopath, "src/go.uber.org/thriftrw"),
+			errMsg: "$GOPATH is not set",
+		},
+		{
+			desc:   "not inside GOPATH",
+			gopath: gopath,
+			dir:    filepath.Join(gopath2, "src/go.uber.org/yarpc"),
+			errMsg: "not inside $GOPATH",
+		},
+		{
+			desc:   "GOPATH is set",
+			gopath: gopath,
+			dir:    genDir,
+			result: "go.uber.org/thriftrw/gen",
+		},
+		{
+			desc:   "multiple GOPATH entries/first",
+			gopath: gopath + ":" + gopath2,
+			dir:    genDir,
+			result: "go.uber.org/thriftrw/gen",
+		},
+		{
+			desc:   "multiple GOPATH entries/second",
+			gopath: gopath + ":" + gopath2,
+			dir:    filepath.Join(gopath2, "src/go.uber.org/yarpc"),
+			result: "go.uber.org/yarpc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Setenv("GOWORK", "")
+
+			oldGopath := os.Getenv("GOPATH")
+			if len(tt.gopath) == 0 {
+				require.NoError(t, os.Unsetenv("GOPATH"))
+			} else {
+				require.NoError(t, os.Setenv("GOPATH", tt.gopath))
+			}
+			defer os.Setenv("GOPATH", oldGopath)
+
+			result, err := determinePackagePrefix(tt.dir)
+
+			if tt.result != "" {
+				if assert.NoError(t, err) {
+					assert.Equal(t, tt.result, result)
+				}
+			}
+
+			if tt.errMsg != "" {
+				if assert.Error(t, err, "determinePackagePrefix(%q) should fail", tt.dir) {
+					assert.Contains(t, err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestDeterminePackagePrefixModules(t *testing.T) {
+	// writeFiles writes the given files relative to dir.
+	writeFiles := func(t *testing.T, dir string, files map[string]string) {
+		for name, body := range files {
+			p := filepath.Join(dir, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+			require.NoError(t, os.WriteFile(p, []byte(body), 0o644))
+		}
+	}
+
+	tests := []struct {
+		desc   string
+		files  map[string]string
+		gowork string // GOWORK to use, relative to the test directory
+		dir    string // directory we're checking
+		result string // expected package prefix
+		errMsg string // error message, if any
+	}{
+		{
+			desc:   "module root",
+			files:  map[string]string{"go.mod": "module example.com/foo\n"},
+			result: "example.com/foo",
+		},
+		{
+			desc:   "module subdirectory",
+			files:  map[string]string{"go.mod": "module example.com/foo\n"},
+			dir:    "gen/thrift",
+			result: "example.com/foo/gen/thrift",
+		},
+		{
+			desc: "nested module",
+			files: map[string]string{
+				"go.mod":     "module example.com/foo\n",
+				"bar/go.mod": "module example.com/bar/v2\n",
+			},
+			dir:    "bar/gen",
+			result: "example.com/bar/v2/gen",
+		},
+		{
+			desc: "workspace",
+			files: map[string]string{
+				"go.work":    "go 1.22\n\nuse (\n\t./foo\n\t./bar\n)\n",
+				"foo/go.mod": "module example.com/foo\n",
+				"bar/go.mod": "module example.com/bar\n",
+			},
+			dir:    "bar/gen",
+			result: "example.com/bar/gen",
+		},
+		{
+			desc: "workspace from GOWORK",
+			files: map[string]string{
+				"work/go.work": "go 1.22\n\nuse ../foo\n",
+				"foo/go.mod":   "module example.com/foo\n",
+			},
+			gowork: "work/go.work",
+			dir:    "foo/gen",
+			result: "example.com/foo/gen",
+		},
+		{
+			desc: "GOWORK=off",
+			files: map[string]string{
+				"go.work":    "go 1.22\n\nuse ./bar\n",
+				"bar/go.mod": "module example.com/bar\n",
+				"foo/go.mod": "module example.com/foo\n",
+			},
+			gowork: "off",
+			dir:    "foo/gen",
+			result: "example.com/foo/gen",
+		},
+		{
+			desc: "module not in workspace",
+			files: map[string]string{
+				"go.work":    "go 1.22\n\nuse ./bar\n",
+				"bar/go.mod": "module example.com/bar\n",
+				"foo/go.mod": "module example.com/foo\n",
+			},
+			dir:    "foo/gen",
+			errMsg: "is not one of the modules used by",
+		},
+		{
+			desc: "outside workspace modules",
+			files: map[string]string{
+				"go.work":    "go 1.22\n\nuse ./bar\n",
+				"bar/go.mod": "module example.com/bar\n",
+			},
+			dir:    "foo/gen",
+			errMsg: "is not inside any of the modules used by",
+		},
+		{
+			desc:   "invalid go.work",
+			files:  map[string]string{"go.work": "use (\n"},
+			errMsg: "could not use Go modules:",
+		},
+		{
+			desc:   "missing module path",
+			files:  map[string]string{"go.mod": "go 1.22\n"},
+			errMsg: "does not declare a module path",
+		},
+		{
+			desc:   "no module or GOPATH",
+			errMsg: "could not find a go.mod file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, tt.files)
+
+			gowork := tt.gowork
+			if gowork != "" && gowork != "off" {
+				gowork = filepath.Join(root, gowork)
+			}
+			t.Setenv("GOWORK", gowork)
+			t.Setenv("GOPATH", "")
+
+			dir := filepath.Join(root, tt.dir)
+			result, err := determinePackagePrefix(dir)
+
+			if tt.errMsg != "" {
+				if assert.Error(t, err, "determinePackagePrefix(%q) should fail", dir) {
+					assert.Contains(t, err.Error(), tt.errMsg)
+				}
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.result, result)
+			}
+		})
+	}
+}
@@ -285,6 +285,58 @@ enum Feature {
     // TODO: TAGGER for struct-tagging plugins
 }
 
+/**
+ * OptionType is the type of the value accepted by a plugin Option.
+ */
+enum OptionType {
+    /**
+     * STRING options accept arbitrary strings.
+     */
+    STRING = 1,
+    /**
+     * BOOL options accept "true" or "false". ThriftRW also accepts the other
+     * spellings supported by strconv.ParseBool but always sends "true" or
+     * "false" to the plugin.
+     */
+    BOOL = 2,
+    /**
+     * INT options accept base 10 64-bit signed integers.
+     */
+    INT = 3,
+}
+
+/**
+ * Option is an option accepted by a plugin.
+ *
+ * Users provide values for options with the --plugin-option flag. ThriftRW
+ * validates these values before sending them to the plugin in
+ * GenerateServiceRequest.options.
+ */
+struct Option {
+    /**
+     * Name of the option. This MUST be unique for the plugin and MUST NOT
+     * contain the "=" character.
+     */
+    1: required string name
+    /**
+     * Type of values accepted by the option.
+     */
+    2: required OptionType type
+    /**
+     * Help text describing the option. This is shown to users in
+     * "thriftrw --help".
+     */
+    3: optional string help
+    /**
+     * Value used for this option if the user did not provide one. This MUST
+     * be valid for the type of the option.
+     *
+     * If a default value is not provided, the option is omitted from
+     * GenerateServiceRequest.options unless the user provides a value.
+     */
+    4: optional string defaultValue
+}
+
 /**
  * HandshakeRequest is the initial request sent to the plugin as part of
  * establishing communication and feature negotiation.
@@ -318,6 +370,12 @@ struct HandshakeResponse {
      * explicitly.
      */
     4: optional string libraryVersion
+    /**
+     * Options accepted by the plugin.
+     *
+     * ThriftRW rejects values for options that are not listed here.
+     */
+    5: optional list<Option> options
 }
 
 service Plugin {
@@ -384,6 +442,14 @@ struct GenerateServiceRequest {
      *  only be generated for module IDs listed here.
      */
     6: optional list<ModuleID> rootModules
+    /**
+     * Values of options declared by the plugin in HandshakeResponse.options,
+     * keyed by option name.
+     *
+     * Values have been validated against the type of the option. Defaults
+     * have been filled in for options that the user did not provide.
+     */
+    7: optional map<string, string> options
 }
 
 /**
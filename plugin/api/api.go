@@ -1533,6 +1533,12 @@ type GenerateServiceRequest struct {
 	// modules being generated and their transitive dependencies. Code should
 	// only be generated for module IDs listed here.
 	RootModules []ModuleID `json:"rootModules,omitempty"`
+	// Values of options declared by the plugin in HandshakeResponse.options,
+	// keyed by option name.
+	//
+	// Values have been validated against the type of the option. Defaults
+	// have been filled in for options that the user did not provide.
+	Options map[string]string `json:"options,omitempty"`
 }
 
 type _List_ServiceID_ValueList []ServiceID
@@ -1680,7 +1686,7 @@ func (_List_ModuleID_ValueList) Close() {}
 //	}
 func (v *GenerateServiceRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -1732,6 +1738,14 @@ func (v *GenerateServiceRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 6, Value: w}
 		i++
 	}
+	if v.Options != nil {
+		w, err = wire.NewValueMap(_Map_String_String_MapItemList(v.Options)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 7, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -1927,6 +1941,14 @@ func (v *GenerateServiceRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 7:
+			if field.Value.Type() == wire.TMap {
+				v.Options, err = _Map_String_String_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -2119,6 +2141,18 @@ func (v *GenerateServiceRequest) Encode(sw stream.Writer) error {
 		}
 	}
 
+	if v.Options != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 7, Type: wire.TMap}); err != nil {
+			return err
+		}
+		if err := _Map_String_String_Encode(v.Options, sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
 	return sw.WriteStructEnd()
 }
 
@@ -2346,6 +2380,12 @@ func (v *GenerateServiceRequest) Decode(sr stream.Reader) error {
 				return err
 			}
 
+		case fh.ID == 7 && fh.Type == wire.TMap:
+			v.Options, err = _Map_String_String_Decode(sr)
+			if err != nil {
+				return err
+			}
+
 		default:
 			if err := sr.Skip(fh.Type); err != nil {
 				return err
@@ -2395,7 +2435,7 @@ func (v *GenerateServiceRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [6]string
+	var fields [7]string
 	i := 0
 	fields[i] = fmt.Sprintf("RootServices: %v", v.RootServices)
 	i++
@@ -2411,6 +2451,10 @@ func (v *GenerateServiceRequest) String() string {
 		fields[i] = fmt.Sprintf("RootModules: %v", v.RootModules)
 		i++
 	}
+	if v.Options != nil {
+		fields[i] = fmt.Sprintf("Options: %v", v.Options)
+		i++
+	}
 
 	return fmt.Sprintf("GenerateServiceRequest{%v}", strings.Join(fields[:i], ", "))
 }
@@ -2507,6 +2551,9 @@ func (v *GenerateServiceRequest) Equals(rhs *GenerateServiceRequest) bool {
 	if !((v.RootModules == nil && rhs.RootModules == nil) || (v.RootModules != nil && rhs.RootModules != nil && _List_ModuleID_Equals(v.RootModules, rhs.RootModules))) {
 		return false
 	}
+	if !((v.Options == nil && rhs.Options == nil) || (v.Options != nil && rhs.Options != nil && _Map_String_String_Equals(v.Options, rhs.Options))) {
+		return false
+	}
 
 	return true
 }
@@ -2595,6 +2642,9 @@ func (v *GenerateServiceRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (er
 	if v.RootModules != nil {
 		err = multierr.Append(err, enc.AddArray("rootModules", (_List_ModuleID_Zapper)(v.RootModules)))
 	}
+	if v.Options != nil {
+		err = multierr.Append(err, enc.AddObject("options", (_Map_String_String_Zapper)(v.Options)))
+	}
 	return err
 }
 
@@ -2673,6 +2723,21 @@ func (v *GenerateServiceRequest) IsSetRootModules() bool {
 	return v != nil && v.RootModules != nil
 }
 
+// GetOptions returns the value of Options if it is set or its
+// zero value if it is unset.
+func (v *GenerateServiceRequest) GetOptions() (o map[string]string) {
+	if v != nil && v.Options != nil {
+		return v.Options
+	}
+
+	return
+}
+
+// IsSetOptions returns true if Options is not nil.
+func (v *GenerateServiceRequest) IsSetOptions() bool {
+	return v != nil && v.Options != nil
+}
+
 // GenerateServiceResponse is response to a GenerateServiceRequest.
 type GenerateServiceResponse struct {
 	// Map of file path to file contents.
@@ -3207,6 +3272,10 @@ type HandshakeResponse struct {
 	// This MUST be set to go.uber.org/thriftrw/version.Version by the plugin
 	// explicitly.
 	LibraryVersion *string `json:"libraryVersion,omitempty"`
+	// Options accepted by the plugin.
+	//
+	// ThriftRW rejects values for options that are not listed here.
+	Options []*Option `json:"options,omitempty"`
 }
 
 type _List_Feature_ValueList []Feature
@@ -3235,6 +3304,35 @@ func (_List_Feature_ValueList) ValueType() wire.Type {
 
 func (_List_Feature_ValueList) Close() {}
 
+type _List_Option_ValueList []*Option
+
+func (v _List_Option_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid list '[]*Option', index [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_Option_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_Option_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_Option_ValueList) Close() {}
+
 // ToWire translates a HandshakeResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -3252,7 +3350,7 @@ func (_List_Feature_ValueList) Close() {}
 //	}
 func (v *HandshakeResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -3286,6 +3384,14 @@ func (v *HandshakeResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 4, Value: w}
 		i++
 	}
+	if v.Options != nil {
+		w, err = wire.NewValueList(_List_Option_ValueList(v.Options)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -3314,6 +3420,30 @@ func _List_Feature_Read(l wire.ValueList) ([]Feature, error) {
 	return o, err
 }
 
+func _Option_Read(w wire.Value) (*Option, error) {
+	var v Option
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_Option_Read(l wire.ValueList) ([]*Option, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*Option, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _Option_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
 // FromWire deserializes a HandshakeResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -3373,6 +3503,14 @@ func (v *HandshakeResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 5:
+			if field.Value.Type() == wire.TList {
+				v.Options, err = _List_Option_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -3410,6 +3548,27 @@ func _List_Feature_Encode(val []Feature, sw stream.Writer) error {
 	return sw.WriteListEnd()
 }
 
+func _List_Option_Encode(val []*Option, sw stream.Writer) error {
+
+	lh := stream.ListHeader{
+		Type:   wire.TStruct,
+		Length: len(val),
+	}
+	if err := sw.WriteListBegin(lh); err != nil {
+		return err
+	}
+
+	for i, v := range val {
+		if v == nil {
+			return fmt.Errorf("invalid list '[]*Option', index [%v]: value is nil", i)
+		}
+		if err := v.Encode(sw); err != nil {
+			return err
+		}
+	}
+	return sw.WriteListEnd()
+}
+
 // Encode serializes a HandshakeResponse struct directly into bytes, without going
 // through an intermediary type.
 //
@@ -3461,6 +3620,18 @@ func (v *HandshakeResponse) Encode(sw stream.Writer) error {
 		}
 	}
 
+	if v.Options != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 5, Type: wire.TList}); err != nil {
+			return err
+		}
+		if err := _List_Option_Encode(v.Options, sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
 	return sw.WriteStructEnd()
 }
 
@@ -3500,6 +3671,42 @@ func _List_Feature_Decode(sr stream.Reader) ([]Feature, error) {
 	return o, err
 }
 
+func _Option_Decode(sr stream.Reader) (*Option, error) {
+	var v Option
+	err := v.Decode(sr)
+	return &v, err
+}
+
+func _List_Option_Decode(sr stream.Reader) ([]*Option, error) {
+	lh, err := sr.ReadListBegin()
+	if err != nil {
+		return nil, err
+	}
+
+	if lh.Type != wire.TStruct {
+		for i := 0; i < lh.Length; i++ {
+			if err := sr.Skip(lh.Type); err != nil {
+				return nil, err
+			}
+		}
+		return nil, sr.ReadListEnd()
+	}
+
+	o := make([]*Option, 0, lh.Length)
+	for i := 0; i < lh.Length; i++ {
+		v, err := _Option_Decode(sr)
+		if err != nil {
+			return nil, err
+		}
+		o = append(o, v)
+	}
+
+	if err = sr.ReadListEnd(); err != nil {
+		return nil, err
+	}
+	return o, err
+}
+
 // Decode deserializes a HandshakeResponse struct directly from its Thrift-level
 // representation, without going through an intemediary type.
 //
@@ -3548,6 +3755,12 @@ func (v *HandshakeResponse) Decode(sr stream.Reader) error {
 				return err
 			}
 
+		case fh.ID == 5 && fh.Type == wire.TList:
+			v.Options, err = _List_Option_Decode(sr)
+			if err != nil {
+				return err
+			}
+
 		default:
 			if err := sr.Skip(fh.Type); err != nil {
 				return err
@@ -3589,7 +3802,7 @@ func (v *HandshakeResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [5]string
 	i := 0
 	fields[i] = fmt.Sprintf("Name: %v", v.Name)
 	i++
@@ -3601,6 +3814,10 @@ func (v *HandshakeResponse) String() string {
 		fields[i] = fmt.Sprintf("LibraryVersion: %v", *(v.LibraryVersion))
 		i++
 	}
+	if v.Options != nil {
+		fields[i] = fmt.Sprintf("Options: %v", v.Options)
+		i++
+	}
 
 	return fmt.Sprintf("HandshakeResponse{%v}", strings.Join(fields[:i], ", "))
 }
@@ -3630,6 +3847,21 @@ func _String_EqualsPtr(lhs, rhs *string) bool {
 	return lhs == nil && rhs == nil
 }
 
+func _List_Option_Equals(lhs, rhs []*Option) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Equals returns true if all the fields of this HandshakeResponse match the
 // provided HandshakeResponse.
 //
@@ -3652,6 +3884,9 @@ func (v *HandshakeResponse) Equals(rhs *HandshakeResponse) bool {
 	if !_String_EqualsPtr(v.LibraryVersion, rhs.LibraryVersion) {
 		return false
 	}
+	if !((v.Options == nil && rhs.Options == nil) || (v.Options != nil && rhs.Options != nil && _List_Option_Equals(v.Options, rhs.Options))) {
+		return false
+	}
 
 	return true
 }
@@ -3667,6 +3902,17 @@ func (l _List_Feature_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err err
 	return err
 }
 
+type _List_Option_Zapper []*Option
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_Option_Zapper.
+func (l _List_Option_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
 // fast logging of HandshakeResponse.
 func (v *HandshakeResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
@@ -3679,6 +3925,9 @@ func (v *HandshakeResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err err
 	if v.LibraryVersion != nil {
 		enc.AddString("libraryVersion", *v.LibraryVersion)
 	}
+	if v.Options != nil {
+		err = multierr.Append(err, enc.AddArray("options", (_List_Option_Zapper)(v.Options)))
+	}
 	return err
 }
 
@@ -3729,6 +3978,21 @@ func (v *HandshakeResponse) IsSetLibraryVersion() bool {
 	return v != nil && v.LibraryVersion != nil
 }
 
+// GetOptions returns the value of Options if it is set or its
+// zero value if it is unset.
+func (v *HandshakeResponse) GetOptions() (o []*Option) {
+	if v != nil && v.Options != nil {
+		return v.Options
+	}
+
+	return
+}
+
+// IsSetOptions returns true if Options is not nil.
+func (v *HandshakeResponse) IsSetOptions() bool {
+	return v != nil && v.Options != nil
+}
+
 // Module is a module generated from a single Thrift file. Each module
 // corresponds to exactly one Thrift file and contains all the types and
 // constants defined in that Thrift file.
@@ -4108,6 +4372,640 @@ func (lhs ModuleID) Equals(rhs ModuleID) bool {
 	return ((int32)(lhs) == (int32)(rhs))
 }
 
+// Option is an option accepted by a plugin.
+//
+// Users provide values for options with the --plugin-option flag. ThriftRW
+// validates these values before sending them to the plugin in
+// GenerateServiceRequest.options.
+type Option struct {
+	// Name of the option. This MUST be unique for the plugin and MUST NOT
+	// contain the "=" character.
+	Name string `json:"name,required"`
+	// Type of values accepted by the option.
+	Type OptionType `json:"type,required"`
+	// Help text describing the option. This is shown to users in
+	// "thriftrw --help".
+	Help *string `json:"help,omitempty"`
+	// Value used for this option if the user did not provide one. This MUST
+	// be valid for the type of the option.
+	//
+	// If a default value is not provided, the option is omitted from
+	// GenerateServiceRequest.options unless the user provides a value.
+	DefaultValue *string `json:"defaultValue,omitempty"`
+}
+
+// ToWire translates a Option struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//	x, err := v.ToWire()
+//	if err != nil {
+//		return err
+//	}
+//
+//	if err := binaryProtocol.Encode(x, writer); err != nil {
+//		return err
+//	}
+func (v *Option) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	w, err = wire.NewValueString(v.Name), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+
+	w, err = v.Type.ToWire()
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 2, Value: w}
+	i++
+	if v.Help != nil {
+		w, err = wire.NewValueString(*(v.Help)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.DefaultValue != nil {
+		w, err = wire.NewValueString(*(v.DefaultValue)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _OptionType_Read(w wire.Value) (OptionType, error) {
+	var v OptionType
+	err := v.FromWire(w)
+	return v, err
+}
+
+// FromWire deserializes a Option struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a Option struct
+// from the provided intermediate representation.
+//
+//	x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//	if err != nil {
+//		return nil, err
+//	}
+//
+//	var v Option
+//	if err := v.FromWire(x); err != nil {
+//		return nil, err
+//	}
+//	return &v, nil
+func (v *Option) FromWire(w wire.Value) error {
+	var err error
+
+	nameIsSet := false
+	typeIsSet := false
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.Name, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					return err
+				}
+				nameIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TI32 {
+				v.Type, err = _OptionType_Read(field.Value)
+				if err != nil {
+					return err
+				}
+				typeIsSet = true
+			}
+		case 3:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Help = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DefaultValue = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	if !nameIsSet {
+		return errors.New("field Name of Option is required")
+	}
+
+	if !typeIsSet {
+		return errors.New("field Type of Option is required")
+	}
+
+	return nil
+}
+
+// Encode serializes a Option struct directly into bytes, without going
+// through an intermediary type.
+//
+// An error is returned if a Option struct could not be encoded.
+func (v *Option) Encode(sw stream.Writer) error {
+	if err := sw.WriteStructBegin(); err != nil {
+		return err
+	}
+
+	if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 1, Type: wire.TBinary}); err != nil {
+		return err
+	}
+	if err := sw.WriteString(v.Name); err != nil {
+		return err
+	}
+	if err := sw.WriteFieldEnd(); err != nil {
+		return err
+	}
+
+	if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 2, Type: wire.TI32}); err != nil {
+		return err
+	}
+	if err := v.Type.Encode(sw); err != nil {
+		return err
+	}
+	if err := sw.WriteFieldEnd(); err != nil {
+		return err
+	}
+
+	if v.Help != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 3, Type: wire.TBinary}); err != nil {
+			return err
+		}
+		if err := sw.WriteString(*(v.Help)); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.DefaultValue != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 4, Type: wire.TBinary}); err != nil {
+			return err
+		}
+		if err := sw.WriteString(*(v.DefaultValue)); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	return sw.WriteStructEnd()
+}
+
+func _OptionType_Decode(sr stream.Reader) (OptionType, error) {
+	var v OptionType
+	err := v.Decode(sr)
+	return v, err
+}
+
+// Decode deserializes a Option struct directly from its Thrift-level
+// representation, without going through an intemediary type.
+//
+// An error is returned if a Option struct could not be generated from the wire
+// representation.
+func (v *Option) Decode(sr stream.Reader) error {
+
+	nameIsSet := false
+	typeIsSet := false
+
+	if err := sr.ReadStructBegin(); err != nil {
+		return err
+	}
+
+	fh, ok, err := sr.ReadFieldBegin()
+	if err != nil {
+		return err
+	}
+
+	for ok {
+		switch {
+		case fh.ID == 1 && fh.Type == wire.TBinary:
+			v.Name, err = sr.ReadString()
+			if err != nil {
+				return err
+			}
+			nameIsSet = true
+		case fh.ID == 2 && fh.Type == wire.TI32:
+			v.Type, err = _OptionType_Decode(sr)
+			if err != nil {
+				return err
+			}
+			typeIsSet = true
+		case fh.ID == 3 && fh.Type == wire.TBinary:
+			var x string
+			x, err = sr.ReadString()
+			v.Help = &x
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 4 && fh.Type == wire.TBinary:
+			var x string
+			x, err = sr.ReadString()
+			v.DefaultValue = &x
+			if err != nil {
+				return err
+			}
+
+		default:
+			if err := sr.Skip(fh.Type); err != nil {
+				return err
+			}
+		}
+
+		if err := sr.ReadFieldEnd(); err != nil {
+			return err
+		}
+
+		if fh, ok, err = sr.ReadFieldBegin(); err != nil {
+			return err
+		}
+	}
+
+	if err := sr.ReadStructEnd(); err != nil {
+		return err
+	}
+
+	if !nameIsSet {
+		return errors.New("field Name of Option is required")
+	}
+
+	if !typeIsSet {
+		return errors.New("field Type of Option is required")
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a Option
+// struct.
+func (v *Option) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	fields[i] = fmt.Sprintf("Name: %v", v.Name)
+	i++
+	fields[i] = fmt.Sprintf("Type: %v", v.Type)
+	i++
+	if v.Help != nil {
+		fields[i] = fmt.Sprintf("Help: %v", *(v.Help))
+		i++
+	}
+	if v.DefaultValue != nil {
+		fields[i] = fmt.Sprintf("DefaultValue: %v", *(v.DefaultValue))
+		i++
+	}
+
+	return fmt.Sprintf("Option{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this Option match the
+// provided Option.
+//
+// This function performs a deep comparison.
+func (v *Option) Equals(rhs *Option) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !(v.Name == rhs.Name) {
+		return false
+	}
+	if !v.Type.Equals(rhs.Type) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Help, rhs.Help) {
+		return false
+	}
+	if !_String_EqualsPtr(v.DefaultValue, rhs.DefaultValue) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of Option.
+func (v *Option) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	enc.AddString("name", v.Name)
+	err = multierr.Append(err, enc.AddObject("type", v.Type))
+	if v.Help != nil {
+		enc.AddString("help", *v.Help)
+	}
+	if v.DefaultValue != nil {
+		enc.AddString("defaultValue", *v.DefaultValue)
+	}
+	return err
+}
+
+// GetName returns the value of Name if it is set or its
+// zero value if it is unset.
+func (v *Option) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
+}
+
+// GetType returns the value of Type if it is set or its
+// zero value if it is unset.
+func (v *Option) GetType() (o OptionType) {
+	if v != nil {
+		o = v.Type
+	}
+	return
+}
+
+// GetHelp returns the value of Help if it is set or its
+// zero value if it is unset.
+func (v *Option) GetHelp() (o string) {
+	if v != nil && v.Help != nil {
+		return *v.Help
+	}
+
+	return
+}
+
+// IsSetHelp returns true if Help is not nil.
+func (v *Option) IsSetHelp() bool {
+	return v != nil && v.Help != nil
+}
+
+// GetDefaultValue returns the value of DefaultValue if it is set or its
+// zero value if it is unset.
+func (v *Option) GetDefaultValue() (o string) {
+	if v != nil && v.DefaultValue != nil {
+		return *v.DefaultValue
+	}
+
+	return
+}
+
+// IsSetDefaultValue returns true if DefaultValue is not nil.
+func (v *Option) IsSetDefaultValue() bool {
+	return v != nil && v.DefaultValue != nil
+}
+
+// OptionType is the type of the value accepted by a plugin Option.
+type OptionType int32
+
+const (
+	// STRING options accept arbitrary strings.
+	OptionTypeString OptionType = 1
+	// BOOL options accept "true" or "false". ThriftRW also accepts the other
+	// spellings supported by strconv.ParseBool but always sends "true" or
+	// "false" to the plugin.
+	OptionTypeBool OptionType = 2
+	// INT options accept base 10 64-bit signed integers.
+	OptionTypeInt OptionType = 3
+)
+
+// OptionType_Values returns all recognized values of OptionType.
+func OptionType_Values() []OptionType {
+	return []OptionType{
+		OptionTypeString,
+		OptionTypeBool,
+		OptionTypeInt,
+	}
+}
+
+// UnmarshalText tries to decode OptionType from a byte slice
+// containing its name.
+//
+//	var v OptionType
+//	err := v.UnmarshalText([]byte("STRING"))
+func (v *OptionType) UnmarshalText(value []byte) error {
+	switch s := string(value); s {
+	case "STRING":
+		*v = OptionTypeString
+		return nil
+	case "BOOL":
+		*v = OptionTypeBool
+		return nil
+	case "INT":
+		*v = OptionTypeInt
+		return nil
+	default:
+		val, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", s, "OptionType", err)
+		}
+		*v = OptionType(val)
+		return nil
+	}
+}
+
+// MarshalText encodes OptionType to text.
+//
+// If the enum value is recognized, its name is returned.
+// Otherwise, its integer value is returned.
+//
+// This implements the TextMarshaler interface.
+func (v OptionType) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 1:
+		return []byte("STRING"), nil
+	case 2:
+		return []byte("BOOL"), nil
+	case 3:
+		return []byte("INT"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of OptionType.
+// Enums are logged as objects, where the value is logged with key "value", and
+// if this value's name is known, the name is logged with key "name".
+func (v OptionType) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt32("value", int32(v))
+	switch int32(v) {
+	case 1:
+		enc.AddString("name", "STRING")
+	case 2:
+		enc.AddString("name", "BOOL")
+	case 3:
+		enc.AddString("name", "INT")
+	}
+	return nil
+}
+
+// Ptr returns a pointer to this enum value.
+func (v OptionType) Ptr() *OptionType {
+	return &v
+}
+
+// Encode encodes OptionType directly to bytes.
+//
+//	sWriter := BinaryStreamer.Writer(writer)
+//
+//	var v OptionType
+//	return v.Encode(sWriter)
+func (v OptionType) Encode(sw stream.Writer) error {
+	return sw.WriteInt32(int32(v))
+}
+
+// ToWire translates OptionType into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// Enums are represented as 32-bit integers over the wire.
+func (v OptionType) ToWire() (wire.Value, error) {
+	return wire.NewValueI32(int32(v)), nil
+}
+
+// FromWire deserializes OptionType from its Thrift-level
+// representation.
+//
+//	x, err := binaryProtocol.Decode(reader, wire.TI32)
+//	if err != nil {
+//	    return OptionType(0), err
+//	}
+//
+//	var v OptionType
+//	if err := v.FromWire(x); err != nil {
+//	    return OptionType(0), err
+//	}
+//	return v, nil
+func (v *OptionType) FromWire(w wire.Value) error {
+	*v = (OptionType)(w.GetI32())
+	return nil
+}
+
+// Decode reads off the encoded OptionType directly off of the wire.
+//
+//	sReader := BinaryStreamer.Reader(reader)
+//
+//	var v OptionType
+//	if err := v.Decode(sReader); err != nil {
+//	    return OptionType(0), err
+//	}
+//	return v, nil
+func (v *OptionType) Decode(sr stream.Reader) error {
+	i, err := sr.ReadInt32()
+	if err != nil {
+		return err
+	}
+	*v = (OptionType)(i)
+	return nil
+}
+
+// String returns a readable string representation of OptionType.
+func (v OptionType) String() string {
+	w := int32(v)
+	switch w {
+	case 1:
+		return "STRING"
+	case 2:
+		return "BOOL"
+	case 3:
+		return "INT"
+	}
+	return fmt.Sprintf("OptionType(%d)", w)
+}
+
+// Equals returns true if this OptionType value matches the provided
+// value.
+func (v OptionType) Equals(rhs OptionType) bool {
+	return v == rhs
+}
+
+// MarshalJSON serializes OptionType into JSON.
+//
+// If the enum value is recognized, its name is returned.
+// Otherwise, its integer value is returned.
+//
+// This implements json.Marshaler.
+func (v OptionType) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 1:
+		return ([]byte)("\"STRING\""), nil
+	case 2:
+		return ([]byte)("\"BOOL\""), nil
+	case 3:
+		return ([]byte)("\"INT\""), nil
+	}
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// UnmarshalJSON attempts to decode OptionType from its JSON
+// representation.
+//
+// This implementation supports both, numeric and string inputs. If a
+// string is provided, it must be a known enum name.
+//
+// This implements json.Unmarshaler.
+func (v *OptionType) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	switch w := t.(type) {
+	case json.Number:
+		x, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "OptionType")
+		}
+		if x < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "OptionType")
+		}
+		*v = (OptionType)(x)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "OptionType")
+	}
+}
+
 // Service is a service defined by the user in the Thrift file.
 type Service struct {
 	// Name of the Thrift service in Go code.
@@ -6451,11 +7349,11 @@ var ThriftModule = &thriftreflect.ThriftModule{
 	Name:     "api",
 	Package:  "go.uber.org/thriftrw/plugin/api",
 	FilePath: "api.thrift",
-	SHA1:     "fb81bce21306d445cc7f1967be37412ee33a6098",
+	SHA1:     "8aeec2bae8ed593fe1b022243ceb7d08d25833bb",
 	Raw:      rawIDL,
 }
 
-const rawIDL = "/**\n * API_VERSION is the version of the plugin API.\n *\n * This MUST be provided in the HandshakeResponse.\n */\nconst i32 API_VERSION = 4\n\n/**\n * ServiceID is an arbitrary unique identifier to reference the different\n * services in this request.\n */\ntypedef i32 ServiceID\n\n/**\n * ModuleID is an arbitrary unique identifier to reference the different\n * modules in this request.\n */\ntypedef i32 ModuleID\n\n/**\n * TypeReference is a reference to a user-defined type.\n */\nstruct TypeReference {\n    1: required string name\n    /**\n     * Import path for the package defining this type.\n     */\n    2: required string importPath\n\n    /**\n     * Annotations defined on this type.\n     *\n     * Note that these are the Thrift annotations listed after the type\n     * declaration in the Thrift file.\n     *\n     * Given,\n     *\n     *   struct User {\n     *     1: required i32 id\n     *     2: required string name\n     *   } (key = \"id\", validate)\n     *\n     * The annotations will be,\n     *\n     *   {\n     *     \"key\": \"id\",\n     *     \"validate\": \"\",\n     *   }\n     */\n    3: optional map<string, string> annotations\n\n    // TODO(abg): Should this just be using ModuleID instead of a package?\n}\n\n/**\n * SimpleType is a standalone native Go type.\n */\nenum SimpleType {\n    BOOL = 1,     // bool\n    BYTE,         // byte\n    INT8,         // int8\n    INT16,        // int16\n    INT32,        // int32\n    INT64,        // int64\n    FLOAT64,      // float64\n    STRING,       // string\n    STRUCT_EMPTY, // struct{}\n}\n\n/**\n * TypePair is a pair of two types.\n */\nstruct TypePair {\n    1: required Type left\n    2: required Type right\n    3: optional map<string, string> annotations\n}\n\n/**\n * Type is a reference to a Go type which may be native or user defined.\n */\nunion Type {\n    1: SimpleType simpleType\n    /**\n     * Slice of a type\n     *\n     * []$sliceType\n     */\n    2: Type sliceType\n    /**\n     * Slice of key-value pairs of a pair of types.\n     *\n     * []struct{Key $left, Value $right}\n     */\n    3: TypePair keyValueSliceType\n    /**\n     * Map of a pair of types.\n     *\n     * map[$left]$right\n     */\n    4: TypePair mapType\n    /**\n     * Reference to a user-defined type.\n     */\n    5: TypeReference referenceType\n    /**\n     * Pointer to a type.\n     */\n    6: Type pointerType\n}\n\n/**\n * Argument is a single Argument inside a Function.\n * For,\n *\n *      void setValue(1: string key, 2: string value)\n *\n * You get the arguments,\n *\n *      Argument{Name: \"Key\", Type: Type{SimpleType: SimpleTypeString}}\n *\n *      Argument{Name: \"Value\", Type: Type{SimpleType: SimpleTypeString}}\n */\nstruct Argument {\n    /**\n     * Name of the argument. This is also the name of the argument field\n     * inside the args/result struct for that function.\n     */\n    1: required string name\n    /**\n     * Argument type.\n     */\n    2: required Type type\n    /**\n     * Annotations defined on this argument.\n     *\n     * Given,\n     *\n     *   void setValue(\n     *     1: SetValueRequest req\n     *   ) throws (\n     *     1: BadRequestError badRequestError (cache = \"false\")\n     *   )\n     *\n     * The annotations for the Argument representing badRequestError will be,\n     *\n     *  {\n     *    \"cache\": \"false\",\n     *  }\n     */\n    3: optional map<string, string> annotations;\n}\n\n/**\n * Function is a single function on a Thrift service.\n */\nstruct Function {\n    /**\n     * Name of the Go function.\n     */\n    1: required string name\n    /**\n     * Name of the function as defined in the Thrift file.\n     */\n    2: required string thriftName\n    /**\n     * List of arguments accepted by the function.\n     *\n     * This list is in the order specified by the user in the Thrift file.\n     */\n    3: required list<Argument> arguments\n    /**\n     * Return type of the function, if any. If this is not set, the function\n     * is a void function.\n     */\n    4: optional Type returnType\n    /**\n     * List of exceptions raised by the function.\n     *\n     * This list is in the order specified by the user in the Thrift file.\n     */\n    5: optional list<Argument> exceptions\n    /**\n     * Whether this function is oneway or not. This should be assumed to be\n     * false unless explicitly stated otherwise. If this is true, the\n     * returnType and exceptions will be null or empty.\n     */\n    6: optional bool oneWay\n    /**\n     * Annotations defined on this function.\n     *\n     * Given,\n     *\n     *   void setValue(1: SetValueRequest req) (cache = \"false\")\n     *\n     * The annotations will be,\n     *\n     *  {\n     *    \"cache\": \"false\",\n     *  }\n     */\n    7: optional map<string, string> annotations;\n}\n\n/**\n * Service is a service defined by the user in the Thrift file.\n */\nstruct Service {\n    /**\n     * Name of the Thrift service in Go code.\n     */\n    7: required string name\n    /**\n     * Name of the service as defined in the Thrift file.\n     */\n    1: required string thriftName\n    /**\n     * ID of the parent service.\n     */\n    4: optional ServiceID parentID\n    /**\n     * List of functions defined for this service.\n     */\n    5: required list<Function> functions\n    /**\n     * ID of the module where this service was declared.\n     */\n    6: required ModuleID moduleID\n    /**\n     * Annotations defined on this service.\n     *\n     * Given,\n     *\n     *   service KeyValue {\n     *   } (private = \"true\")\n     *\n     * The annotations will be,\n     *\n     *  {\n     *    \"private\": \"true\",\n     *  }\n     */\n    8: optional map<string, string> annotations;\n}\n\n/**\n * Module is a module generated from a single Thrift file. Each module\n * corresponds to exactly one Thrift file and contains all the types and\n * constants defined in that Thrift file.\n */\nstruct Module {\n    /**\n     * Import path for the package defining the types for this module.\n     */\n    1: required string importPath\n    /**\n     * Path to the directory containing the code for this module.\n     *\n     * The path is relative to the output directory into which ThriftRW is\n     * generating code. Plugins SHOULD NOT make any assumptions about the\n     * absolute location of the directory.\n     */\n    2: required string directory\n    /**\n     * Path to the Thrift file from which this module was generated.\n     */\n    3: required string thriftFilePath\n}\n\n//////////////////////////////////////////////////////////////////////////////\n\n/**\n * Feature is a functionality offered by a ThriftRW plugin.\n */\nenum Feature {\n    /**\n     * SERVICE_GENERATOR specifies that the plugin may generate arbitrary code\n     * for services defined in the Thrift file.\n     *\n     * If a plugin provides this, it MUST implement the ServiceGenerator\n     * service.\n     */\n    SERVICE_GENERATOR = 1,\n\n    // TODO: TAGGER for struct-tagging plugins\n}\n\n/**\n * HandshakeRequest is the initial request sent to the plugin as part of\n * establishing communication and feature negotiation.\n */\nstruct HandshakeRequest {\n}\n\n/**\n * HandshakeResponse is the response from the plugin for a HandshakeRequest.\n */\nstruct HandshakeResponse {\n    /**\n     * Name of the plugin. This MUST match the name of the plugin specified\n     * over the command line or the program will fail.\n     */\n    1: required string name\n    /**\n     * Version of the plugin API.\n     *\n     * This MUST be set to API_VERSION by the plugin.\n     */\n    2: required i32 apiVersion (go.name = \"APIVersion\")\n    /**\n     * List of features the plugin provides.\n     */\n    3: required list<Feature> features\n    /**\n     * Version of ThriftRW with which the plugin was built.\n     *\n     * This MUST be set to go.uber.org/thriftrw/version.Version by the plugin\n     * explicitly.\n     */\n    4: optional string libraryVersion\n}\n\nservice Plugin {\n    /**\n     * handshake performs a handshake with the plugin to negotiate the\n     * features provided by it and the version of the plugin API it expects.\n     */\n    HandshakeResponse handshake(1: HandshakeRequest request)\n\n    /**\n     * Informs the plugin process that it will not receive any more requests\n     * and it is safe for it to exit.\n     */\n    void goodbye()\n}\n\n//////////////////////////////////////////////////////////////////////////////\n\n/**\n * GenerateServiceRequest is a request to generate code for zero or more\n * Thrift services.\n */\nstruct GenerateServiceRequest {\n    /**\n     * IDs of services for which code should be generated.\n     *\n     * Note that the services map contains information about both, the\n     * services being generated and their transitive dependencies. Code should\n     * only be generated for service IDs listed here.\n     */\n    1: required list<ServiceID> rootServices\n    /**\n     * Map of service ID to service.\n     *\n     * Any service IDs present in this request will have a corresponding\n     * service definition in this map, including services for which code does\n     * not need to be generated.\n     */\n    2: required map<ServiceID, Service> services\n    /**\n     * Map of module ID to module.\n     *\n     * Any module IDs present in the request will have a corresponding module\n     * definition in this map.\n     */\n    3: required map<ModuleID, Module> modules\n    /**\n     * Prefix for import paths of generated module. In general, plugins should\n     * not need to use the package prefix unless instantiating a new\n     * Generator for more custom plugin generation.\n     */\n    4: required string packagePrefix\n    /**\n     * Directory whose descendants contain all Thrift files. In general,\n     * plugins should not need to use the thrift root unless instantiating a\n     * new Generator for more custom plugin generation.\n     */\n    5: required string thriftRoot\n    /**\n     *  IDs of Modules for which code should be generated.\n     *\n     *  Note that the modules map contains information about both, the\n     *  modules being generated and their transitive dependencies. Code should\n     *  only be generated for module IDs listed here.\n     */\n    6: optional list<ModuleID> rootModules\n}\n\n/**\n * GenerateServiceResponse is response to a GenerateServiceRequest.\n */\nstruct GenerateServiceResponse {\n    /**\n     * Map of file path to file contents.\n     *\n     * All paths MUST be relative to the output directory into which ThriftRW\n     * is generating code. Plugins SHOULD NOT make any assumptions about the\n     * absolute location of the directory.\n     *\n     * The paths MUST NOT contain the string \"..\" or the request will fail.\n     */\n    1: optional map<string, binary> files\n}\n\n/**\n * ServiceGenerator generates arbitrary code for services.\n *\n * This MUST be implemented if the SERVICE_GENERATOR feature is enabled.\n */\nservice ServiceGenerator {\n    /**\n     * Generates code for requested services.\n     */\n    GenerateServiceResponse generate(1: GenerateServiceRequest request)\n}\n"
+const rawIDL = "/**\n * API_VERSION is the version of the plugin API.\n *\n * This MUST be provided in the HandshakeResponse.\n */\nconst i32 API_VERSION = 4\n\n/**\n * ServiceID is an arbitrary unique identifier to reference the different\n * services in this request.\n */\ntypedef i32 ServiceID\n\n/**\n * ModuleID is an arbitrary unique identifier to reference the different\n * modules in this request.\n */\ntypedef i32 ModuleID\n\n/**\n * TypeReference is a reference to a user-defined type.\n */\nstruct TypeReference {\n    1: required string name\n    /**\n     * Import path for the package defining this type.\n     */\n    2: required string importPath\n\n    /**\n     * Annotations defined on this type.\n     *\n     * Note that these are the Thrift annotations listed after the type\n     * declaration in the Thrift file.\n     *\n     * Given,\n     *\n     *   struct User {\n     *     1: required i32 id\n     *     2: required string name\n     *   } (key = \"id\", validate)\n     *\n     * The annotations will be,\n     *\n     *   {\n     *     \"key\": \"id\",\n     *     \"validate\": \"\",\n     *   }\n     */\n    3: optional map<string, string> annotations\n\n    // TODO(abg): Should this just be using ModuleID instead of a package?\n}\n\n/**\n * SimpleType is a standalone native Go type.\n */\nenum SimpleType {\n    BOOL = 1,     // bool\n    BYTE,         // byte\n    INT8,         // int8\n    INT16,        // int16\n    INT32,        // int32\n    INT64,        // int64\n    FLOAT64,      // float64\n    STRING,       // string\n    STRUCT_EMPTY, // struct{}\n}\n\n/**\n * TypePair is a pair of two types.\n */\nstruct TypePair {\n    1: required Type left\n    2: required Type right\n    3: optional map<string, string> annotations\n}\n\n/**\n * Type is a reference to a Go type which may be native or user defined.\n */\nunion Type {\n    1: SimpleType simpleType\n    /**\n     * Slice of a type\n     *\n     * []$sliceType\n     */\n    2: Type sliceType\n    /**\n     * Slice of key-value pairs of a pair of types.\n     *\n     * []struct{Key $left, Value $right}\n     */\n    3: TypePair keyValueSliceType\n    /**\n     * Map of a pair of types.\n     *\n     * map[$left]$right\n     */\n    4: TypePair mapType\n    /**\n     * Reference to a user-defined type.\n     */\n    5: TypeReference referenceType\n    /**\n     * Pointer to a type.\n     */\n    6: Type pointerType\n}\n\n/**\n * Argument is a single Argument inside a Function.\n * For,\n *\n *      void setValue(1: string key, 2: string value)\n *\n * You get the arguments,\n *\n *      Argument{Name: \"Key\", Type: Type{SimpleType: SimpleTypeString}}\n *\n *      Argument{Name: \"Value\", Type: Type{SimpleType: SimpleTypeString}}\n */\nstruct Argument {\n    /**\n     * Name of the argument. This is also the name of the argument field\n     * inside the args/result struct for that function.\n     */\n    1: required string name\n    /**\n     * Argument type.\n     */\n    2: required Type type\n    /**\n     * Annotations defined on this argument.\n     *\n     * Given,\n     *\n     *   void setValue(\n     *     1: SetValueRequest req\n     *   ) throws (\n     *     1: BadRequestError badRequestError (cache = \"false\")\n     *   )\n     *\n     * The annotations for the Argument representing badRequestError will be,\n     *\n     *  {\n     *    \"cache\": \"false\",\n     *  }\n     */\n    3: optional map<string, string> annotations;\n}\n\n/**\n * Function is a single function on a Thrift service.\n */\nstruct Function {\n    /**\n     * Name of the Go function.\n     */\n    1: required string name\n    /**\n     * Name of the function as defined in the Thrift file.\n     */\n    2: required string thriftName\n    /**\n     * List of arguments accepted by the function.\n     *\n     * This list is in the order specified by the user in the Thrift file.\n     */\n    3: required list<Argument> arguments\n    /**\n     * Return type of the function, if any. If this is not set, the function\n     * is a void function.\n     */\n    4: optional Type returnType\n    /**\n     * List of exceptions raised by the function.\n     *\n     * This list is in the order specified by the user in the Thrift file.\n     */\n    5: optional list<Argument> exceptions\n    /**\n     * Whether this function is oneway or not. This should be assumed to be\n     * false unless explicitly stated otherwise. If this is true, the\n     * returnType and exceptions will be null or empty.\n     */\n    6: optional bool oneWay\n    /**\n     * Annotations defined on this function.\n     *\n     * Given,\n     *\n     *   void setValue(1: SetValueRequest req) (cache = \"false\")\n     *\n     * The annotations will be,\n     *\n     *  {\n     *    \"cache\": \"false\",\n     *  }\n     */\n    7: optional map<string, string> annotations;\n}\n\n/**\n * Service is a service defined by the user in the Thrift file.\n */\nstruct Service {\n    /**\n     * Name of the Thrift service in Go code.\n     */\n    7: required string name\n    /**\n     * Name of the service as defined in the Thrift file.\n     */\n    1: required string thriftName\n    /**\n     * ID of the parent service.\n     */\n    4: optional ServiceID parentID\n    /**\n     * List of functions defined for this service.\n     */\n    5: required list<Function> functions\n    /**\n     * ID of the module where this service was declared.\n     */\n    6: required ModuleID moduleID\n    /**\n     * Annotations defined on this service.\n     *\n     * Given,\n     *\n     *   service KeyValue {\n     *   } (private = \"true\")\n     *\n     * The annotations will be,\n     *\n     *  {\n     *    \"private\": \"true\",\n     *  }\n     */\n    8: optional map<string, string> annotations;\n}\n\n/**\n * Module is a module generated from a single Thrift file. Each module\n * corresponds to exactly one Thrift file and contains all the types and\n * constants defined in that Thrift file.\n */\nstruct Module {\n    /**\n     * Import path for the package defining the types for this module.\n     */\n    1: required string importPath\n    /**\n     * Path to the directory containing the code for this module.\n     *\n     * The path is relative to the output directory into which ThriftRW is\n     * generating code. Plugins SHOULD NOT make any assumptions about the\n     * absolute location of the directory.\n     */\n    2: required string directory\n    /**\n     * Path to the Thrift file from which this module was generated.\n     */\n    3: required string thriftFilePath\n}\n\n//////////////////////////////////////////////////////////////////////////////\n\n/**\n * Feature is a functionality offered by a ThriftRW plugin.\n */\nenum Feature {\n    /**\n     * SERVICE_GENERATOR specifies that the plugin may generate arbitrary code\n     * for services defined in the Thrift file.\n     *\n     * If a plugin provides this, it MUST implement the ServiceGenerator\n     * service.\n     */\n    SERVICE_GENERATOR = 1,\n\n    // TODO: TAGGER for struct-tagging plugins\n}\n\n/**\n * OptionType is the type of the value accepted by a plugin Option.\n */\nenum OptionType {\n    /**\n     * STRING options accept arbitrary strings.\n     */\n    STRING = 1,\n    /**\n     * BOOL options accept \"true\" or \"false\". ThriftRW also accepts the other\n     * spellings supported by strconv.ParseBool but always sends \"true\" or\n     * \"false\" to the plugin.\n     */\n    BOOL = 2,\n    /**\n     * INT options accept base 10 64-bit signed integers.\n     */\n    INT = 3,\n}\n\n/**\n * Option is an option accepted by a plugin.\n *\n * Users provide values for options with the --plugin-option flag. ThriftRW\n * validates these values before sending them to the plugin in\n * GenerateServiceRequest.options.\n */\nstruct Option {\n    /**\n     * Name of the option. This MUST be unique for the plugin and MUST NOT\n     * contain the \"=\" character.\n     */\n    1: required string name\n    /**\n     * Type of values accepted by the option.\n     */\n    2: required OptionType type\n    /**\n     * Help text describing the option. This is shown to users in\n     * \"thriftrw --help\".\n     */\n    3: optional string help\n    /**\n     * Value used for this option if the user did not provide one. This MUST\n     * be valid for the type of the option.\n     *\n     * If a default value is not provided, the option is omitted from\n     * GenerateServiceRequest.options unless the user provides a value.\n     */\n    4: optional string defaultValue\n}\n\n/**\n * HandshakeRequest is the initial request sent to the plugin as part of\n * establishing communication and feature negotiation.\n */\nstruct HandshakeRequest {\n}\n\n/**\n * HandshakeResponse is the response from the plugin for a HandshakeRequest.\n */\nstruct HandshakeResponse {\n    /**\n     * Name of the plugin. This MUST match the name of the plugin specified\n     * over the command line or the program will fail.\n     */\n    1: required string name\n    /**\n     * Version of the plugin API.\n     *\n     * This MUST be set to API_VERSION by the plugin.\n     */\n    2: required i32 apiVersion (go.name = \"APIVersion\")\n    /**\n     * List of features the plugin provides.\n     */\n    3: required list<Feature> features\n    /**\n     * Version of ThriftRW with which the plugin was built.\n     *\n     * This MUST be set to go.uber.org/thriftrw/version.Version by the plugin\n     * explicitly.\n     */\n    4: optional string libraryVersion\n    /**\n     * Options accepted by the plugin.\n     *\n     * ThriftRW rejects values for options that are not listed here.\n     */\n    5: optional list<Option> options\n}\n\nservice Plugin {\n    /**\n     * handshake performs a handshake with the plugin to negotiate the\n     * features provided by it and the version of the plugin API it expects.\n     */\n    HandshakeResponse handshake(1: HandshakeRequest request)\n\n    /**\n     * Informs the plugin process that it will not receive any more requests\n     * and it is safe for it to exit.\n     */\n    void goodbye()\n}\n\n//////////////////////////////////////////////////////////////////////////////\n\n/**\n * GenerateServiceRequest is a request to generate code for zero or more\n * Thrift services.\n */\nstruct GenerateServiceRequest {\n    /**\n     * IDs of services for which code should be generated.\n     *\n     * Note that the services map contains information about both, the\n     * services being generated and their transitive dependencies. Code should\n     * only be generated for service IDs listed here.\n     */\n    1: required list<ServiceID> rootServices\n    /**\n     * Map of service ID to service.\n     *\n     * Any service IDs present in this request will have a corresponding\n     * service definition in this map, including services for which code does\n     * not need to be generated.\n     */\n    2: required map<ServiceID, Service> services\n    /**\n     * Map of module ID to module.\n     *\n     * Any module IDs present in the request will have a corresponding module\n     * definition in this map.\n     */\n    3: required map<ModuleID, Module> modules\n    /**\n     * Prefix for import paths of generated module. In general, plugins should\n     * not need to use the package prefix unless instantiating a new\n     * Generator for more custom plugin generation.\n     */\n    4: required string packagePrefix\n    /**\n     * Directory whose descendants contain all Thrift files. In general,\n     * plugins should not need to use the thrift root unless instantiating a\n     * new Generator for more custom plugin generation.\n     */\n    5: required string thriftRoot\n    /**\n     *  IDs of Modules for which code should be generated.\n     *\n     *  Note that the modules map contains information about both, the\n     *  modules being generated and their transitive dependencies. Code should\n     *  only be generated for module IDs listed here.\n     */\n    6: optional list<ModuleID> rootModules\n    /**\n     * Values of options declared by the plugin in HandshakeResponse.options,\n     * keyed by option name.\n     *\n     * Values have been validated against the type of the option. Defaults\n     * have been filled in for options that the user did not provide.\n     */\n    7: optional map<string, string> options\n}\n\n/**\n * GenerateServiceResponse is response to a GenerateServiceRequest.\n */\nstruct GenerateServiceResponse {\n    /**\n     * Map of file path to file contents.\n     *\n     * All paths MUST be relative to the output directory into which ThriftRW\n     * is generating code. Plugins SHOULD NOT make any assumptions about the\n     * absolute location of the directory.\n     *\n     * The paths MUST NOT contain the string \"..\" or the request will fail.\n     */\n    1: optional map<string, binary> files\n}\n\n/**\n * ServiceGenerator generates arbitrary code for services.\n *\n * This MUST be implemented if the SERVICE_GENERATOR feature is enabled.\n */\nservice ServiceGenerator {\n    /**\n     * Generates code for requested services.\n     */\n    GenerateServiceResponse generate(1: GenerateServiceRequest request)\n}\n"
 
 // Plugin_Goodbye_Args represents the arguments for the Plugin.goodbye function.
 //
@@ -53,4 +53,45 @@
 //	thriftrw --plugin='myfancyplugin --useContext'
 //
 // Will pass `--useContext` to `thriftrw-plugin-myfancyplugin`.
+//
+// # Options
+//
+// Plugins may instead declare typed options in the Options field of Plugin.
+// ThriftRW validates values for these before sending them to the plugin in
+// the Options field of each GenerateServiceRequest. Options with default
+// values are always sent.
+//
+//	plugin.Main(&plugin.Plugin{
+//		Name: "myfancyplugin",
+//		Options: []*api.Option{
+//			{
+//				Name: "useContext",
+//				Type: api.OptionTypeBool,
+//				Help: ptr.String("Accept a context.Context in generated methods."),
+//			},
+//		},
+//		// ...
+//	})
+//
+// Users provide values for these with the --plugin-option flag, and may list
+// the options accepted by plugins by passing --help after --plugin.
+//
+//	thriftrw --plugin=myfancyplugin --plugin-option=myfancyplugin.useContext=true foo.thrift
+//	thriftrw --plugin=myfancyplugin --help
+//
+// # In-process plugins
+//
+// Programs that run the ThriftRW code generator as a library with
+// gen.Generate may instead pass plugins to it directly. These plugins run
+// inside the same process without a lookup on the $PATH.
+//
+//	err := gen.Generate(module, &gen.Options{
+//		// ...
+//		Plugins: []*plugin.Plugin{
+//			{Name: "myfancyplugin", ServiceGenerator: myServiceGenerator},
+//		},
+//		PluginOptions: map[string]map[string]string{
+//			"myfancyplugin": {"useContext": "true"},
+//		},
+//	})
 package plugin
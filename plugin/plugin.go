@@ -50,9 +50,14 @@ type Plugin struct {
 	// functionality.
 	ServiceGenerator api.ServiceGenerator
 
+	// Options accepted by this plugin. Users provide values for these with
+	// the --plugin-option flag, and ThriftRW validates them before sending
+	// them to the plugin in GenerateServiceRequest.Options.
+	Options []*api.Option
+
 	// Reader and Writer may be specified to change the communication channel
 	// this plugin uses. By default, plugins listen on stdin and write to
-	// stdout.
+	// stdout. These are not used for plugins passed to gen.Options.Plugins.
 	Reader io.Reader
 	Writer io.Writer
 }
@@ -117,6 +122,7 @@ func (h pluginHandler) Handshake(request *api.HandshakeRequest) (*api.HandshakeR
 		APIVersion:     api.APIVersion,
 		Features:       h.features,
 		LibraryVersion: ptr.String(version.Version),
+		Options:        h.plugin.Options,
 	}, nil
 }
 
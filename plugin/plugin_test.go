@@ -44,6 +44,32 @@ func TestEmptyPlugin(t *testing.T) {
 	assert.Equal(t, "hello", response.Name)
 	assert.Equal(t, version.Version, *response.LibraryVersion)
 	assert.Empty(t, response.Features)
+	assert.Empty(t, response.Options)
+
+	assert.NoError(t, client.Goodbye())
+}
+
+func TestPluginOptions(t *testing.T) {
+	stdinReader, stdinWriter := io.Pipe()
+	stdoutReader, stdoutWriter := io.Pipe()
+
+	options := []*api.Option{
+		{Name: "verbose", Type: api.OptionTypeBool, Help: ptr.String("Log more.")},
+		{Name: "limit", Type: api.OptionTypeInt, DefaultValue: ptr.String("10")},
+	}
+	go Main(&Plugin{
+		Name:    "hello",
+		Options: options,
+		Writer:  stdoutWriter,
+		Reader:  stdinReader,
+	})
+
+	transport := fakeEnvelopeClient(stdinWriter, stdoutReader)
+	client := api.NewPluginClient(multiplex.NewClient("Plugin", transport))
+
+	response, err := client.Handshake(&api.HandshakeRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, options, response.Options)
 
 	assert.NoError(t, client.Goodbye())
 }
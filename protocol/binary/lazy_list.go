@@ -22,6 +22,7 @@ package binary
 
 import (
 	"io"
+	"iter"
 	"sync"
 
 	"go.uber.org/thriftrw/wire"
@@ -84,6 +85,31 @@ func (ll *lazyValueList) ForEach(f func(wire.Value) error) error {
 	return nil
 }
 
+func (ll *lazyValueList) All() iter.Seq2[wire.Value, error] {
+	return func(yield func(wire.Value, error) bool) {
+		off := ll.startOffset
+		reader := newReader(ll.readerAt, off)
+		defer reader.close()
+
+		for i := int32(0); i < ll.count; i++ {
+			var (
+				val wire.Value
+				err error
+			)
+
+			val, off, err = reader.ReadValue(ll.typ, off)
+			if err != nil {
+				yield(wire.Value{}, err)
+				return
+			}
+
+			if !yield(val, nil) {
+				return
+			}
+		}
+	}
+}
+
 func (ll *lazyValueList) Close() {
 	ll.readerAt = nil
 	lazyValueListPool.Put(ll)
@@ -139,6 +165,37 @@ func (lm *lazyMapItemList) ForEach(f func(wire.MapItem) error) error {
 	return nil
 }
 
+func (lm *lazyMapItemList) Items() iter.Seq2[wire.MapItem, error] {
+	return func(yield func(wire.MapItem, error) bool) {
+		off := lm.startOffset
+		reader := newReader(lm.readerAt, off)
+		defer reader.close()
+
+		for i := int32(0); i < lm.count; i++ {
+			var (
+				k, v wire.Value
+				err  error
+			)
+
+			k, off, err = reader.ReadValue(lm.ktype, off)
+			if err != nil {
+				yield(wire.MapItem{}, err)
+				return
+			}
+
+			v, off, err = reader.ReadValue(lm.vtype, off)
+			if err != nil {
+				yield(wire.MapItem{}, err)
+				return
+			}
+
+			if !yield(wire.MapItem{Key: k, Value: v}, nil) {
+				return
+			}
+		}
+	}
+}
+
 func (lm *lazyMapItemList) Close() {
 	lm.readerAt = nil
 	lazyMapItemListPool.Put(lm)
@@ -0,0 +1,86 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package binary
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// sliceReader is an io.Reader and io.Seeker over a byte slice which is also
+// able to hand out sub-slices of its contents without copying them.
+type sliceReader struct {
+	b   []byte
+	off int
+}
+
+var _ io.ReadSeeker = (*sliceReader)(nil)
+
+func (r *sliceReader) Read(p []byte) (int, error) {
+	if r.off >= len(r.b) {
+		return 0, io.EOF
+	}
+	n := copy(p, r.b[r.off:])
+	r.off += n
+	return n, nil
+}
+
+func (r *sliceReader) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = int64(r.off) + offset
+	case io.SeekEnd:
+		abs = int64(len(r.b)) + offset
+	default:
+		return int64(r.off), fmt.Errorf("unsupported whence %d", whence)
+	}
+
+	if abs < 0 {
+		return int64(r.off), errors.New("negative position")
+	}
+	if abs > int64(len(r.b)) {
+		// Seeking past the end is valid for io.Seeker but we know that
+		// the caller intended to skip over bytes that don't exist.
+		r.off = len(r.b)
+		return abs, io.ErrUnexpectedEOF
+	}
+
+	r.off = int(abs)
+	return abs, nil
+}
+
+// next returns the next n bytes from the slice without copying them.
+func (r *sliceReader) next(n int) ([]byte, error) {
+	if n > len(r.b)-r.off {
+		r.off = len(r.b)
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	// Limit the capacity of the returned slice so that appending to it
+	// doesn't overwrite the rest of the input.
+	bs := r.b[r.off : r.off+n : r.off+n]
+	r.off += n
+	return bs, nil
+}
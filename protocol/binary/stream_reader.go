@@ -76,6 +76,11 @@ type StreamReader struct {
 	// This field is set only if the wrapped reader is an io.Seeker. ONLY
 	// USE if you are discardSeek.
 	_seeker io.Seeker
+
+	// This field is set only if the StreamReader was built with
+	// NewAliasingStreamReader. Binary and string values will be sliced
+	// directly out of it instead of being copied.
+	alias *sliceReader
 }
 
 var streamReaderPool = sync.Pool{
@@ -104,9 +109,25 @@ func NewStreamReader(r io.Reader) *StreamReader {
 	return sr
 }
 
+// NewAliasingStreamReader fetches a StreamReader from the system that reads
+// from the given byte slice.
+//
+// Unlike NewStreamReader, binary and string values returned by this
+// StreamReader are not copied: they point directly into b. The caller must
+// not modify b for as long as any values decoded from it are in use.
+//
+// This StreamReader must be closed using `Close()`
+func NewAliasingStreamReader(b []byte) *StreamReader {
+	alias := &sliceReader{b: b}
+	sr := NewStreamReader(alias)
+	sr.alias = alias
+	return sr
+}
+
 func returnStreamReader(sr *StreamReader) {
 	sr.reader = nil
 	sr._seeker = nil
+	sr.alias = nil
 	streamReaderPool.Put(sr)
 }
 
@@ -202,6 +223,10 @@ func (sr *StreamReader) ReadBinary() ([]byte, error) {
 		return []byte{}, nil
 	}
 
+	if sr.alias != nil {
+		return sr.alias.next(int(length))
+	}
+
 	if length > bytesAllocThreshold {
 		var buf bytes.Buffer
 		_, err := io.CopyN(&buf, sr.reader, int64(length))
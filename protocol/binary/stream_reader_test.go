@@ -25,6 +25,9 @@ import (
 	"io"
 	"testing"
 
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -48,3 +51,84 @@ func BenchmarkReadString(b *testing.B) {
 		enc.Seek(0, io.SeekStart)
 	}
 }
+
+func BenchmarkReadStringAliasing(b *testing.B) {
+	var streamBuff bytes.Buffer
+
+	w := NewStreamWriter(&streamBuff)
+	require.NoError(b, w.WriteString("the quick brown fox jumps over the lazy dog"))
+	require.NoError(b, w.Close())
+
+	encoded := streamBuff.Bytes()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sr := NewAliasingStreamReader(encoded)
+		sr.ReadString()
+		sr.Close()
+	}
+}
+
+func TestAliasingStreamReader(t *testing.T) {
+	var buff bytes.Buffer
+	w := NewStreamWriter(&buff)
+	require.NoError(t, w.WriteString("foo"))
+	require.NoError(t, w.WriteInt32(42))
+	require.NoError(t, w.WriteBinary([]byte("bar")))
+	require.NoError(t, w.WriteBinary(nil))
+	require.NoError(t, w.WriteString("baz"))
+	require.NoError(t, w.Close())
+
+	encoded := buff.Bytes()
+	sr := NewAliasingStreamReader(encoded)
+	defer sr.Close()
+
+	s, err := sr.ReadString()
+	require.NoError(t, err)
+	assert.Equal(t, "foo", s)
+
+	i, err := sr.ReadInt32()
+	require.NoError(t, err)
+	assert.Equal(t, int32(42), i)
+
+	bs, err := sr.ReadBinary()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("bar"), bs)
+	assert.Equal(t, len(bs), cap(bs), "capacity must be limited")
+
+	// Values alias the input.
+	bs[0] = 'c'
+	assert.Equal(t, "car", string(encoded[15:18]))
+
+	empty, err := sr.ReadBinary()
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	require.NoError(t, sr.Skip(wire.TBinary))
+
+	_, err = sr.ReadInt8()
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func TestAliasingStreamReaderTruncated(t *testing.T) {
+	var buff bytes.Buffer
+	w := NewStreamWriter(&buff)
+	require.NoError(t, w.WriteString("hello world"))
+	require.NoError(t, w.Close())
+
+	t.Run("read", func(t *testing.T) {
+		sr := NewAliasingStreamReader(buff.Bytes()[:8])
+		defer sr.Close()
+
+		_, err := sr.ReadString()
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		sr := NewAliasingStreamReader(buff.Bytes()[:8])
+		defer sr.Close()
+
+		assert.Error(t, sr.Skip(wire.TBinary))
+	})
+}
@@ -28,8 +28,11 @@ import "unsafe"
 // ReadString reads a Thrift encoded string.
 func (sr *StreamReader) ReadString() (string, error) {
 	bs, err := sr.ReadBinary()
-	// It is safe to use "unsafe" here because there are no
-	// mutable references to bs.
+	// It is safe to use "unsafe" here because nothing modifies bs after
+	// this. Usually, bs is a copy that only this string references. For
+	// StreamReaders built with NewAliasingStreamReader, bs is a view into
+	// the caller's buffer, and the caller must not modify that buffer while
+	// decoded values are in use.
 	return unsafe.String(unsafe.SliceData(bs), len(bs)), err
 }
 
@@ -38,11 +41,12 @@ func (sw *StreamWriter) WriteString(s string) error {
 	if err := sw.WriteInt32(int32(len(s))); err != nil {
 		return err
 	}
-	// It is safe to use "unsafe" here because there are no
-	// mutable references to the byte slice b.
+	// It is safe to use "unsafe" here because b is only read.
 	// sw.write() delegates to the underlying io.Writer,
 	// and according to its documentation, "Write must
 	// not modify the slice data, even temporarily."
+	// If s aliases a buffer passed to NewAliasingStreamReader, that buffer
+	// must not be modified until the write completes, as documented there.
 	b := unsafe.Slice(unsafe.StringData(s), len(s))
 	return sw.write(b)
 }
@@ -887,6 +887,44 @@ func TestMapEOFFailure(t *testing.T) {
 	checkEOFError(t, wire.TMap, tests)
 }
 
+func TestMapItems(t *testing.T) {
+	encoded := []byte{
+		0x08,                   // ktype = i32
+		0x0B,                   // vtype = binary
+		0x00, 0x00, 0x00, 0x02, // count:4 = 2
+
+		0x00, 0x00, 0x00, 0x01, // key = 1
+		0x00, 0x00, 0x00, 0x01, // len:4 = 1
+		0x61, // 'a'
+
+		0x00, 0x00, 0x00, 0x02, // key = 2
+		0x00, 0x00, 0x00, 0x01, // len:4 = 1
+		0x62, // 'b'
+	}
+
+	value, err := Binary.Decode(bytes.NewReader(encoded), wire.TMap)
+	require.NoError(t, err)
+	items := value.GetMap()
+	defer items.Close()
+
+	var got []wire.MapItem
+	for item, err := range wire.MapItemListItems(items) {
+		require.NoError(t, err)
+		got = append(got, item)
+	}
+	assert.Equal(t, []wire.MapItem{
+		vitem(vi32(1), vbinary("a")),
+		vitem(vi32(2), vbinary("b")),
+	}, got)
+
+	got = nil
+	for item := range wire.MapItemListItems(items) {
+		got = append(got, item)
+		break
+	}
+	assert.Equal(t, []wire.MapItem{vitem(vi32(1), vbinary("a"))}, got, "iteration must stop early")
+}
+
 func TestMapStreamingBegin(t *testing.T) {
 	tests := []struct {
 		msg     string
@@ -1172,6 +1210,60 @@ func TestListEOFFailure(t *testing.T) {
 	checkEOFError(t, wire.TList, tests)
 }
 
+func TestListAll(t *testing.T) {
+	encoded := []byte{
+		0x08,                   // type:1 = i32
+		0x00, 0x00, 0x00, 0x03, // count:4 = 3
+		0x00, 0x00, 0x00, 0x01, // 1
+		0x00, 0x00, 0x00, 0x02, // 2
+		0x00, 0x00, 0x00, 0x03, // 3
+	}
+
+	value, err := Binary.Decode(bytes.NewReader(encoded), wire.TList)
+	require.NoError(t, err)
+	list := value.GetList()
+	defer list.Close()
+
+	var got []wire.Value
+	for v, err := range wire.ValueListAll(list) {
+		require.NoError(t, err)
+		got = append(got, v)
+	}
+	assert.Equal(t, []wire.Value{vi32(1), vi32(2), vi32(3)}, got)
+
+	got = nil
+	for v := range wire.ValueListAll(list) {
+		got = append(got, v)
+		break
+	}
+	assert.Equal(t, []wire.Value{vi32(1)}, got, "iteration must stop early")
+}
+
+func TestListAllDecodeFailure(t *testing.T) {
+	value, err := Binary.Decode(bytes.NewReader([]byte{
+		0x02,                   // type:1 = bool
+		0x00, 0x00, 0x00, 0x02, // count:4 = 2
+		0x01, // true
+		0x10, // invalid bool
+	}), wire.TList)
+	require.NoError(t, err)
+
+	var (
+		got  []wire.Value
+		errs []error
+	)
+	for v, err := range wire.ValueListAll(value.GetList()) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		got = append(got, v)
+	}
+	assert.Equal(t, []wire.Value{vbool(true)}, got)
+	require.Len(t, errs, 1)
+	assert.True(t, binary.IsDecodeError(errs[0]), "expected decode error, got %v", errs[0])
+}
+
 func TestListStreamingBegin(t *testing.T) {
 	tests := []struct {
 		msg     string
@@ -19,6 +19,10 @@
 // THE SOFTWARE.
 
 // Package ptr provides helpers to convert basic types to pointers.
+//
+// In addition to the per-type helpers, the generic Of, Deref, and Clone
+// functions work with values of any type, including generated enums and
+// typedefs.
 package ptr
 
 // Bool converts a bool to a pointer
@@ -55,3 +59,31 @@ func Float64(x float64) *float64 {
 func String(x string) *string {
 	return &x
 }
+
+// Of converts a value of any type to a pointer.
+//
+//	ptr.Of(int32(42))      // *int32
+//	ptr.Of(MyEnumFoo)      // *MyEnum
+func Of[T any](x T) *T {
+	return &x
+}
+
+// Deref returns the value pointed to by p, or def if p is nil.
+//
+//	ptr.Deref(v.Name, "unknown")
+func Deref[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+	return *p
+}
+
+// Clone returns a pointer to a shallow copy of the value pointed to by p,
+// or nil if p is nil.
+func Clone[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	x := *p
+	return &x
+}
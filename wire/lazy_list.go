@@ -20,6 +20,8 @@
 
 package wire
 
+import "iter"
+
 // ValueList represents a collection of Value objects as an iteration through
 // it. This helps us avoid the cost of allocating memory for all collections
 // passing through the system.
@@ -91,6 +93,16 @@ func (vs sliceValueList) ForEach(f func(Value) error) error {
 	return nil
 }
 
+func (vs sliceValueList) All() iter.Seq2[Value, error] {
+	return func(yield func(Value, error) bool) {
+		for _, v := range vs.values {
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
 func (sliceValueList) Close() {}
 
 //////////////////////////////////////////////////////////////////////////////
@@ -126,10 +138,91 @@ func (vs sliceMapItemList) ForEach(f func(MapItem) error) error {
 	return nil
 }
 
+func (vs sliceMapItemList) Items() iter.Seq2[MapItem, error] {
+	return func(yield func(MapItem, error) bool) {
+		for _, v := range vs.items {
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
 func (sliceMapItemList) Close() {}
 
 //////////////////////////////////////////////////////////////////////////////
 
+// valueListIterator is implemented by ValueLists that provide their own
+// iterator.
+type valueListIterator interface {
+	All() iter.Seq2[Value, error]
+}
+
+// mapItemListIterator is implemented by MapItemLists that provide their own
+// iterator.
+type mapItemListIterator interface {
+	Items() iter.Seq2[MapItem, error]
+}
+
+// errStopIteration is returned from ForEach callbacks to stop the iteration
+// when the consumer of an iterator is done.
+type errStopIteration struct{}
+
+func (errStopIteration) Error() string { return "iteration stopped" }
+
+// ValueListAll returns an iterator over the elements of the given ValueList.
+//
+// If reading an element fails, the iterator yields the error with a zero
+// Value and stops.
+//
+// ValueLists may provide their own iterator with an All method with the
+// same signature as this function. ForEach is used otherwise.
+func ValueListAll(l ValueList) iter.Seq2[Value, error] {
+	if l, ok := l.(valueListIterator); ok {
+		return l.All()
+	}
+
+	return func(yield func(Value, error) bool) {
+		err := l.ForEach(func(v Value) error {
+			if !yield(v, nil) {
+				return errStopIteration{}
+			}
+			return nil
+		})
+		if err != nil && err != (errStopIteration{}) {
+			yield(Value{}, err)
+		}
+	}
+}
+
+// MapItemListItems returns an iterator over the items of the given
+// MapItemList.
+//
+// If reading an item fails, the iterator yields the error with a zero
+// MapItem and stops.
+//
+// MapItemLists may provide their own iterator with an Items method with the
+// same signature as this function. ForEach is used otherwise.
+func MapItemListItems(l MapItemList) iter.Seq2[MapItem, error] {
+	if l, ok := l.(mapItemListIterator); ok {
+		return l.Items()
+	}
+
+	return func(yield func(MapItem, error) bool) {
+		err := l.ForEach(func(item MapItem) error {
+			if !yield(item, nil) {
+				return errStopIteration{}
+			}
+			return nil
+		})
+		if err != nil && err != (errStopIteration{}) {
+			yield(MapItem{}, err)
+		}
+	}
+}
+
+//////////////////////////////////////////////////////////////////////////////
+
 // ValueListToSlice builds a slice of values from the given ValueList.
 func ValueListToSlice(l ValueList) []Value {
 	items := make([]Value, 0, l.Size())
@@ -22,9 +22,11 @@ package wire
 
 import (
 	"fmt"
+	"iter"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValueListFromSliceAll(t *testing.T) {
@@ -132,3 +134,179 @@ func TestMapItemListFromSliceBreak(t *testing.T) {
 	assert.Equal(t, expectedErr, err)
 	assert.Equal(t, 2, i)
 }
+
+//////////////////////////////////////////////////////////////////////////////
+
+// failingValueList is a ValueList whose ForEach fails after the given
+// number of values.
+type failingValueList struct {
+	ValueList
+
+	failAfter int
+	err       error
+}
+
+func (l failingValueList) ForEach(f func(Value) error) error {
+	i := 0
+	return l.ValueList.ForEach(func(v Value) error {
+		if i == l.failAfter {
+			return l.err
+		}
+		i++
+		return f(v)
+	})
+}
+
+type failingMapItemList struct {
+	MapItemList
+
+	failAfter int
+	err       error
+}
+
+func (l failingMapItemList) ForEach(f func(MapItem) error) error {
+	i := 0
+	return l.MapItemList.ForEach(func(item MapItem) error {
+		if i == l.failAfter {
+			return l.err
+		}
+		i++
+		return f(item)
+	})
+}
+
+func TestValueListIterators(t *testing.T) {
+	slice := []Value{
+		NewValueI32(1),
+		NewValueI32(2),
+		NewValueI32(3),
+	}
+	list := ValueListFromSlice(TI32, slice)
+
+	// struct{ ValueList } hides the All method of the slice-backed list so
+	// that ValueListAll falls back to ForEach.
+	for _, all := range []iter.Seq2[Value, error]{
+		ValueListAll(list),
+		ValueListAll(struct{ ValueList }{list}),
+	} {
+		var got []Value
+		for v, err := range all {
+			require.NoError(t, err)
+			got = append(got, v)
+		}
+		assert.Equal(t, slice, got)
+
+		got = nil
+		for v := range all {
+			got = append(got, v)
+			if len(got) == 2 {
+				break
+			}
+		}
+		assert.Equal(t, slice[:2], got)
+	}
+
+	expectedErr := fmt.Errorf("great sadness")
+	var (
+		got  []Value
+		errs []error
+	)
+	for v, err := range ValueListAll(failingValueList{ValueList: list, failAfter: 1, err: expectedErr}) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		got = append(got, v)
+	}
+	assert.Equal(t, slice[:1], got)
+	assert.Equal(t, []error{expectedErr}, errs)
+}
+
+func TestMapItemListIterators(t *testing.T) {
+	slice := []MapItem{
+		{Key: NewValueI32(1), Value: NewValueI64(101)},
+		{Key: NewValueI32(2), Value: NewValueI64(102)},
+		{Key: NewValueI32(3), Value: NewValueI64(103)},
+	}
+	list := MapItemListFromSlice(TI32, TI64, slice)
+
+	for _, items := range []iter.Seq2[MapItem, error]{
+		MapItemListItems(list),
+		MapItemListItems(struct{ MapItemList }{list}),
+	} {
+		var got []MapItem
+		for item, err := range items {
+			require.NoError(t, err)
+			got = append(got, item)
+		}
+		assert.Equal(t, slice, got)
+
+		got = nil
+		for item := range items {
+			got = append(got, item)
+			break
+		}
+		assert.Equal(t, slice[:1], got)
+	}
+
+	expectedErr := fmt.Errorf("great sadness")
+	var (
+		got  []MapItem
+		errs []error
+	)
+	for item, err := range MapItemListItems(failingMapItemList{MapItemList: list, failAfter: 2, err: expectedErr}) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		got = append(got, item)
+	}
+	assert.Equal(t, slice[:2], got)
+	assert.Equal(t, []error{expectedErr}, errs)
+}
+
+// iterValueList is a ValueList with its own All method.
+type iterValueList struct {
+	ValueList
+
+	all iter.Seq2[Value, error]
+}
+
+func (l iterValueList) All() iter.Seq2[Value, error] { return l.all }
+
+type iterMapItemList struct {
+	MapItemList
+
+	items iter.Seq2[MapItem, error]
+}
+
+func (l iterMapItemList) Items() iter.Seq2[MapItem, error] { return l.items }
+
+func TestIteratorsPreferOwnMethods(t *testing.T) {
+	var calls int
+	list := iterValueList{
+		ValueList: ValueListFromSlice(TI32, []Value{NewValueI32(1)}),
+		all: func(yield func(Value, error) bool) {
+			calls++
+			yield(NewValueI32(42), nil)
+		},
+	}
+	for v, err := range ValueListAll(list) {
+		require.NoError(t, err)
+		assert.Equal(t, NewValueI32(42), v)
+	}
+
+	items := iterMapItemList{
+		MapItemList: MapItemListFromSlice(TI32, TI32, nil),
+		items: func(yield func(MapItem, error) bool) {
+			calls++
+			yield(MapItem{Key: NewValueI32(1), Value: NewValueI32(2)}, nil)
+		},
+	}
+	for item, err := range MapItemListItems(items) {
+		require.NoError(t, err)
+		assert.Equal(t, MapItem{Key: NewValueI32(1), Value: NewValueI32(2)}, item)
+	}
+
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package wire
+
+// MergePolicy specifies how a collection in the source of a merge is
+// combined with the same collection in the destination.
+type MergePolicy int
+
+const (
+	// MergeReplace replaces the destination collection with the source
+	// collection. This is the default for all collections.
+	MergeReplace MergePolicy = iota
+
+	// MergeAppend combines the two collections. Lists are concatenated,
+	// sets receive the union of their items, and maps receive the union of
+	// their entries with entries from the source winning on key conflicts.
+	MergeAppend
+)
+
+// MergeOption customizes the behavior of MergeStructs.
+type MergeOption func(*mergeOptions)
+
+type mergeOptions struct {
+	lists MergePolicy
+	sets  MergePolicy
+	maps  MergePolicy
+}
+
+// MergeLists specifies the MergePolicy used for list fields.
+func MergeLists(p MergePolicy) MergeOption {
+	return func(o *mergeOptions) {
+		o.lists = p
+	}
+}
+
+// MergeSets specifies the MergePolicy used for set fields.
+func MergeSets(p MergePolicy) MergeOption {
+	return func(o *mergeOptions) {
+		o.sets = p
+	}
+}
+
+// MergeMaps specifies the MergePolicy used for map fields.
+func MergeMaps(p MergePolicy) MergeOption {
+	return func(o *mergeOptions) {
+		o.maps = p
+	}
+}
+
+// MergeStructs overlays the fields present in src onto dst and returns the
+// result. Neither of the provided structs is modified.
+//
+// Fields that are present only in dst are retained as-is. Fields that are
+// present only in src are added. If a field is present in both, it is
+// resolved as follows:
+//
+//   - If both values are structs, they are merged recursively.
+//   - If both values are collections of the same kind, they are combined
+//     according to the MergePolicy for that kind. By default, the value in
+//     src replaces the value in dst.
+//   - Otherwise, the value in src replaces the value in dst.
+//
+// Note that unions are indistinguishable from structs at this level so a
+// union present in both dst and src is merged field-by-field. Callers should
+// validate the result with FromWire if that is not desired.
+func MergeStructs(dst, src Struct, opts ...MergeOption) Struct {
+	var o mergeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o.mergeStructs(dst, src)
+}
+
+func (o *mergeOptions) mergeStructs(dst, src Struct) Struct {
+	srcFields := src.fieldMap()
+	fields := make([]Field, 0, len(dst.Fields)+len(src.Fields))
+
+	merged := make(map[int16]struct{}, len(src.Fields))
+	for _, f := range dst.Fields {
+		if sv, ok := srcFields[f.ID]; ok {
+			f.Value = o.mergeValues(f.Value, sv)
+			merged[f.ID] = struct{}{}
+		}
+		fields = append(fields, f)
+	}
+
+	for _, f := range src.Fields {
+		if _, ok := merged[f.ID]; !ok {
+			fields = append(fields, f)
+		}
+	}
+
+	return Struct{Fields: fields}
+}
+
+func (o *mergeOptions) mergeValues(dst, src Value) Value {
+	if dst.typ != src.typ {
+		return src
+	}
+
+	switch src.typ {
+	case TStruct:
+		return NewValueStruct(o.mergeStructs(dst.tstruct, src.tstruct))
+	case TList:
+		if o.lists == MergeAppend {
+			return NewValueList(appendLists(dst.GetList(), src.GetList()))
+		}
+	case TSet:
+		if o.sets == MergeAppend {
+			return NewValueSet(unionSets(dst.GetSet(), src.GetSet()))
+		}
+	case TMap:
+		if o.maps == MergeAppend {
+			return NewValueMap(unionMaps(dst.GetMap(), src.GetMap()))
+		}
+	}
+
+	return src
+}
+
+func appendLists(dst, src ValueList) ValueList {
+	if dst.ValueType() != src.ValueType() {
+		return src
+	}
+
+	items := make([]Value, 0, dst.Size()+src.Size())
+	items = append(items, ValueListToSlice(dst)...)
+	items = append(items, ValueListToSlice(src)...)
+	return ValueListFromSlice(src.ValueType(), items)
+}
+
+func unionSets(dst, src ValueList) ValueList {
+	if dst.ValueType() != src.ValueType() {
+		return src
+	}
+
+	items := ValueListToSlice(dst)
+	if isHashable(src.ValueType()) {
+		seen := make(map[interface{}]struct{}, len(items))
+		for _, v := range items {
+			seen[toHashable(v)] = struct{}{}
+		}
+
+		// explicitly ignoring since we know there will not be an error
+		_ = src.ForEach(func(v Value) error {
+			if _, ok := seen[toHashable(v)]; !ok {
+				seen[toHashable(v)] = struct{}{}
+				items = append(items, v)
+			}
+			return nil
+		})
+	} else {
+		// explicitly ignoring since we know there will not be an error
+		_ = src.ForEach(func(v Value) error {
+			for _, existing := range items {
+				if ValuesAreEqual(existing, v) {
+					return nil
+				}
+			}
+			items = append(items, v)
+			return nil
+		})
+	}
+
+	return ValueListFromSlice(src.ValueType(), items)
+}
+
+func unionMaps(dst, src MapItemList) MapItemList {
+	if dst.KeyType() != src.KeyType() || dst.ValueType() != src.ValueType() {
+		return src
+	}
+
+	items := MapItemListToSlice(dst)
+	if isHashable(src.KeyType()) {
+		index := make(map[interface{}]int, len(items))
+		for i, item := range items {
+			index[toHashable(item.Key)] = i
+		}
+
+		// explicitly ignoring since we know there will not be an error
+		_ = src.ForEach(func(item MapItem) error {
+			k := toHashable(item.Key)
+			if i, ok := index[k]; ok {
+				items[i] = item
+			} else {
+				index[k] = len(items)
+				items = append(items, item)
+			}
+			return nil
+		})
+	} else {
+		// explicitly ignoring since we know there will not be an error
+		_ = src.ForEach(func(item MapItem) error {
+			for i, existing := range items {
+				if ValuesAreEqual(existing.Key, item.Key) {
+					items[i] = item
+					return nil
+				}
+			}
+			items = append(items, item)
+			return nil
+		})
+	}
+
+	return MapItemListFromSlice(src.KeyType(), src.ValueType(), items)
+}
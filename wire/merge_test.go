@@ -0,0 +1,234 @@
+// Copyright (c) 2025 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package wire
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func vstruct(fs ...Field) Value {
+	return NewValueStruct(Struct{Fields: fs})
+}
+
+func TestMergeStructs(t *testing.T) {
+	tests := []struct {
+		desc string
+		dst  Struct
+		src  Struct
+		opts []MergeOption
+		want Struct
+	}{
+		{
+			desc: "empty",
+			want: Struct{Fields: []Field{}},
+		},
+		{
+			desc: "disjoint fields",
+			dst:  Struct{Fields: []Field{{ID: 1, Value: vi32(1)}}},
+			src:  Struct{Fields: []Field{{ID: 2, Value: vbinary("foo")}}},
+			want: Struct{Fields: []Field{
+				{ID: 1, Value: vi32(1)},
+				{ID: 2, Value: vbinary("foo")},
+			}},
+		},
+		{
+			desc: "source overwrites primitives",
+			dst: Struct{Fields: []Field{
+				{ID: 1, Value: vi32(1)},
+				{ID: 2, Value: vbinary("foo")},
+			}},
+			src: Struct{Fields: []Field{{ID: 1, Value: vi32(2)}}},
+			want: Struct{Fields: []Field{
+				{ID: 1, Value: vi32(2)},
+				{ID: 2, Value: vbinary("foo")},
+			}},
+		},
+		{
+			desc: "mismatched types",
+			dst:  Struct{Fields: []Field{{ID: 1, Value: vi32(1)}}},
+			src:  Struct{Fields: []Field{{ID: 1, Value: vbinary("foo")}}},
+			want: Struct{Fields: []Field{{ID: 1, Value: vbinary("foo")}}},
+		},
+		{
+			desc: "nested structs",
+			dst: Struct{Fields: []Field{
+				{ID: 1, Value: vstruct(
+					Field{ID: 1, Value: vi32(1)},
+					Field{ID: 2, Value: vi32(2)},
+				)},
+			}},
+			src: Struct{Fields: []Field{
+				{ID: 1, Value: vstruct(
+					Field{ID: 2, Value: vi32(3)},
+					Field{ID: 3, Value: vi32(4)},
+				)},
+			}},
+			want: Struct{Fields: []Field{
+				{ID: 1, Value: vstruct(
+					Field{ID: 1, Value: vi32(1)},
+					Field{ID: 2, Value: vi32(3)},
+					Field{ID: 3, Value: vi32(4)},
+				)},
+			}},
+		},
+		{
+			desc: "collections replaced by default",
+			dst: Struct{Fields: []Field{
+				{ID: 1, Value: vlist(TI32, vi32(1), vi32(2))},
+				{ID: 2, Value: vset(TI32, vi32(1), vi32(2))},
+				{ID: 3, Value: vmap(TI32, TI32, vitem(vi32(1), vi32(2)))},
+			}},
+			src: Struct{Fields: []Field{
+				{ID: 1, Value: vlist(TI32, vi32(3))},
+				{ID: 2, Value: vset(TI32, vi32(3))},
+				{ID: 3, Value: vmap(TI32, TI32, vitem(vi32(3), vi32(4)))},
+			}},
+			want: Struct{Fields: []Field{
+				{ID: 1, Value: vlist(TI32, vi32(3))},
+				{ID: 2, Value: vset(TI32, vi32(3))},
+				{ID: 3, Value: vmap(TI32, TI32, vitem(vi32(3), vi32(4)))},
+			}},
+		},
+		{
+			desc: "lists appended",
+			dst:  Struct{Fields: []Field{{ID: 1, Value: vlist(TI32, vi32(1), vi32(2))}}},
+			src:  Struct{Fields: []Field{{ID: 1, Value: vlist(TI32, vi32(2), vi32(3))}}},
+			opts: []MergeOption{MergeLists(MergeAppend)},
+			want: Struct{Fields: []Field{
+				{ID: 1, Value: vlist(TI32, vi32(1), vi32(2), vi32(2), vi32(3))},
+			}},
+		},
+		{
+			desc: "hashable sets unioned",
+			dst:  Struct{Fields: []Field{{ID: 1, Value: vset(TBinary, vbinary("a"), vbinary("b"))}}},
+			src:  Struct{Fields: []Field{{ID: 1, Value: vset(TBinary, vbinary("b"), vbinary("c"))}}},
+			opts: []MergeOption{MergeSets(MergeAppend)},
+			want: Struct{Fields: []Field{
+				{ID: 1, Value: vset(TBinary, vbinary("a"), vbinary("b"), vbinary("c"))},
+			}},
+		},
+		{
+			desc: "unhashable sets unioned",
+			dst: Struct{Fields: []Field{
+				{ID: 1, Value: vset(TList, vlist(TI32, vi32(1)), vlist(TI32, vi32(2)))},
+			}},
+			src: Struct{Fields: []Field{
+				{ID: 1, Value: vset(TList, vlist(TI32, vi32(2)), vlist(TI32, vi32(3)))},
+			}},
+			opts: []MergeOption{MergeSets(MergeAppend)},
+			want: Struct{Fields: []Field{
+				{ID: 1, Value: vset(
+					TList,
+					vlist(TI32, vi32(1)),
+					vlist(TI32, vi32(2)),
+					vlist(TI32, vi32(3)),
+				)},
+			}},
+		},
+		{
+			desc: "hashable maps unioned",
+			dst: Struct{Fields: []Field{
+				{ID: 1, Value: vmap(
+					TI32, TBinary,
+					vitem(vi32(1), vbinary("a")),
+					vitem(vi32(2), vbinary("b")),
+				)},
+			}},
+			src: Struct{Fields: []Field{
+				{ID: 1, Value: vmap(
+					TI32, TBinary,
+					vitem(vi32(2), vbinary("c")),
+					vitem(vi32(3), vbinary("d")),
+				)},
+			}},
+			opts: []MergeOption{MergeMaps(MergeAppend)},
+			want: Struct{Fields: []Field{
+				{ID: 1, Value: vmap(
+					TI32, TBinary,
+					vitem(vi32(1), vbinary("a")),
+					vitem(vi32(2), vbinary("c")),
+					vitem(vi32(3), vbinary("d")),
+				)},
+			}},
+		},
+		{
+			desc: "unhashable maps unioned",
+			dst: Struct{Fields: []Field{
+				{ID: 1, Value: vmap(
+					TList, TI32,
+					vitem(vlist(TI32, vi32(1)), vi32(1)),
+				)},
+			}},
+			src: Struct{Fields: []Field{
+				{ID: 1, Value: vmap(
+					TList, TI32,
+					vitem(vlist(TI32, vi32(1)), vi32(2)),
+					vitem(vlist(TI32, vi32(2)), vi32(3)),
+				)},
+			}},
+			opts: []MergeOption{MergeMaps(MergeAppend)},
+			want: Struct{Fields: []Field{
+				{ID: 1, Value: vmap(
+					TList, TI32,
+					vitem(vlist(TI32, vi32(1)), vi32(2)),
+					vitem(vlist(TI32, vi32(2)), vi32(3)),
+				)},
+			}},
+		},
+		{
+			desc: "append with mismatched element types",
+			dst:  Struct{Fields: []Field{{ID: 1, Value: vlist(TI32, vi32(1))}}},
+			src:  Struct{Fields: []Field{{ID: 1, Value: vlist(TBinary, vbinary("a"))}}},
+			opts: []MergeOption{MergeLists(MergeAppend)},
+			want: Struct{Fields: []Field{{ID: 1, Value: vlist(TBinary, vbinary("a"))}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := MergeStructs(tt.dst, tt.src, tt.opts...)
+			assert.True(t, StructsAreEqual(tt.want, got),
+				"expected %v, got %v", tt.want, got)
+			assert.Len(t, got.Fields, len(tt.want.Fields))
+		})
+	}
+}
+
+func TestMergeStructsDoesNotModifyInputs(t *testing.T) {
+	dst := Struct{Fields: []Field{
+		{ID: 1, Value: vi32(1)},
+		{ID: 2, Value: vmap(TI32, TI32, vitem(vi32(1), vi32(1)))},
+	}}
+	src := Struct{Fields: []Field{
+		{ID: 1, Value: vi32(2)},
+		{ID: 2, Value: vmap(TI32, TI32, vitem(vi32(1), vi32(2)))},
+	}}
+
+	MergeStructs(dst, src, MergeMaps(MergeAppend))
+
+	assert.Equal(t, vi32(1), dst.Fields[0].Value)
+	assert.True(t, ValuesAreEqual(
+		vmap(TI32, TI32, vitem(vi32(1), vi32(1))),
+		dst.Fields[1].Value,
+	))
+}